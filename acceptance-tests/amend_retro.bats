@@ -0,0 +1,98 @@
+#!/usr/bin/env bats
+
+load '/bats-libs/bats-support/load.bash'
+load '/bats-libs/bats-assert/load.bash'
+
+REPO_PATH=/tmp/repo/amend-retro
+REMOTE_PATH=/tmp/repo/amend-retro-remote
+
+setup() {
+	git config --global init.defaultBranch main
+	/usr/local/bin/git-team assignments add a 'A <a@x.y>'
+
+	git init --bare $REMOTE_PATH
+	mkdir -p $REPO_PATH
+	cd $REPO_PATH
+	git init
+	git config user.name git-team-acceptance-test
+	git config user.email acc@git.team
+	git remote add origin $REMOTE_PATH
+
+	git commit --allow-empty -m "base"
+	git push origin main
+	git commit --allow-empty -m "first"
+	git commit --allow-empty -m "second" -m "Signed-off-by: S <s@x.y>"
+}
+
+teardown() {
+	cd -
+	rm -rf $REPO_PATH $REMOTE_PATH
+
+	rm /root/.gitconfig
+}
+
+@test "git-team: amend should add the co-authors to HEAD" {
+	run /usr/local/bin/git-team amend a 'B <b@x.y>'
+	assert_success
+	assert_line "Amended HEAD with co-authors: B <b@x.y>, A <a@x.y>"
+
+	run bash -c "git log -1 --format=%B"
+	assert_success
+	assert_output "second
+
+Signed-off-by: S <s@x.y>
+Co-authored-by: B <b@x.y>
+Co-authored-by: A <a@x.y>"
+
+	run bash -c "git rev-parse ORIG_HEAD^{commit} && git log -1 --format=%s ORIG_HEAD"
+	assert_success
+	assert_line --index 1 "second"
+}
+
+@test "git-team: amend should default to the active co-authors" {
+	/usr/local/bin/git-team enable a
+
+	run /usr/local/bin/git-team amend
+	assert_success
+
+	run bash -c "git log -1 --format=%B | grep -c 'Co-authored-by: A <a@x.y>'"
+	assert_output "1"
+
+	/usr/local/bin/git-team disable
+}
+
+@test "git-team: amend should refuse to amend a pushed commit unless forced" {
+	git push origin main
+
+	run /usr/local/bin/git-team amend a
+	assert_failure
+	assert_line "error: HEAD is reachable from a remote-tracking branch, use --force to amend it anyway"
+
+	run /usr/local/bin/git-team amend --force a
+	assert_success
+}
+
+@test "git-team: retro should add the co-authors to the unpushed commits of the range" {
+	original_head=$(git rev-parse HEAD)
+
+	run /usr/local/bin/git-team retro origin/main a
+	assert_success
+	assert_line --index 0 "Rewrote 2 commit(s) with co-authors: A <a@x.y>"
+
+	run bash -c "git log --format=%B origin/main.. | grep -c 'Co-authored-by: A <a@x.y>'"
+	assert_output "2"
+
+	run git rev-parse ORIG_HEAD
+	assert_output "$original_head"
+}
+
+@test "git-team: retro should refuse to rewrite pushed commits unless forced" {
+	git push origin main
+
+	run /usr/local/bin/git-team retro HEAD~2 a
+	assert_failure
+	assert_line "error: 2 of the commits in 'HEAD~2' are reachable from a remote-tracking branch, use --force to rewrite them anyway"
+
+	run /usr/local/bin/git-team retro --force HEAD~2 a
+	assert_success
+}
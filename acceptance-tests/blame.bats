@@ -0,0 +1,51 @@
+#!/usr/bin/env bats
+
+load '/bats-libs/bats-support/load.bash'
+load '/bats-libs/bats-assert/load.bash'
+
+REPO_PATH=/tmp/repo/blame
+
+setup() {
+	git config --global init.defaultBranch main
+	/usr/local/bin/git-team assignments add a 'A <a@x.y>'
+
+	mkdir -p $REPO_PATH
+	cd $REPO_PATH
+	git init
+	git config user.name B
+	git config user.email b@x.y
+
+	printf 'one\ntwo\n' > THE_FILE
+	git add THE_FILE
+	git commit -m "paired" -m "Co-authored-by: A <a@x.y>"
+	echo 'three' >> THE_FILE
+	git commit -am "solo"
+}
+
+teardown() {
+	cd -
+	rm -rf $REPO_PATH
+
+	rm /root/.gitconfig
+}
+
+@test "git-team: blame should show the author and the co-authors of each line" {
+	run /usr/local/bin/git-team blame THE_FILE
+	assert_success
+	assert_line --index 0 --regexp '^[0-9a-f]{8} \(B, a [0-9-]{10} 1\) one$'
+	assert_line --index 1 --regexp '^[0-9a-f]{8} \(B, a [0-9-]{10} 2\) two$'
+	assert_line --index 2 --regexp '^[0-9a-f]{8} \(B    [0-9-]{10} 3\) three$'
+}
+
+@test "git-team: blame --summary should summarise the ownership of the file" {
+	run /usr/local/bin/git-team blame --summary THE_FILE
+	assert_success
+	assert_line --index 0 '     3 100.0%  B'
+	assert_line --index 1 '     2  66.7%  a'
+}
+
+@test "git-team: blame should fail for an unknown file" {
+	run /usr/local/bin/git-team blame NOPE
+	assert_failure 1
+	assert_line --partial "error: git blame failed: fatal: no such path 'NOPE' in HEAD"
+}
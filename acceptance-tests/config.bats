@@ -58,3 +58,138 @@ load '/bats-libs/bats-assert/load.bash'
 	assert_success
 	assert_line --index 0 'team.config.activation-scope global'
 }
+
+@test "git-team: config activation-scope directory should set the activation scope to 'directory'" {
+	run bash -c "git team config activation-scope directory"
+	assert_success
+	assert_line --index 0 "Configuration updated: 'activation-scope' → 'directory'"
+
+	/usr/local/bin/git-team config activation-scope global
+}
+
+@test "git-team: config activation-directory should append a trailing slash" {
+	run bash -c "git team config activation-directory '~/work'"
+	assert_success
+	assert_line --index 0 "Configuration updated: 'activation-directory' → '~/work/'"
+
+	git config --global --unset-all team.config.activation-directory
+}
+
+@test "git-team: config activation-directory should write the configuration to gitconfig" {
+	/usr/local/bin/git-team config activation-directory '~/work'
+
+	run bash -c "git config --global team.config.activation-directory"
+	assert_success
+	assert_line '~/work/'
+
+	git config --global --unset-all team.config.activation-directory
+}
+
+@test "git-team: config activation-directory with a relative path should fail" {
+	run bash -c "git team config activation-directory work"
+	assert_failure 1
+	assert_line "error: activation-directory must either be absolute or start with '~/': 'work'"
+}
+
+@test "git-team: config trailer-key should write the configuration to gitconfig" {
+	run bash -c "git team config trailer-key Pair"
+	assert_success
+	assert_line --index 0 "Configuration updated: 'trailer-key' → 'Pair'"
+
+	run bash -c "git config --global team.config.trailer-key"
+	assert_success
+	assert_line 'Pair'
+
+	/usr/local/bin/git-team config trailer-key default
+}
+
+@test "git-team: config trailer-key default should remove the configuration from gitconfig" {
+	/usr/local/bin/git-team config trailer-key Pair
+	/usr/local/bin/git-team config trailer-key default
+
+	run bash -c "git config --global team.config.trailer-key"
+	assert_failure 1
+}
+
+@test "git-team: config trailer-key with whitespace should fail" {
+	run bash -c "git team config trailer-key 'Paired with'"
+	assert_failure 1
+	assert_line "error: trailer-key must consist of alphanumeric characters and hyphens only: 'Paired with'"
+}
+
+@test "git-team: config trailer-format should write the configuration to gitconfig" {
+	run bash -c "git team config trailer-format '{key} → {value}'"
+	assert_success
+	assert_line --index 0 "Configuration updated: 'trailer-format' → '{key} → {value}'"
+
+	run bash -c "git config --global team.config.trailer-format"
+	assert_success
+	assert_line '{key} → {value}'
+
+	/usr/local/bin/git-team config trailer-format default
+}
+
+@test "git-team: config trailer-format without {value} should fail" {
+	run bash -c "git team config trailer-format '{key}:'"
+	assert_failure 1
+	assert_line "error: trailer-format must contain '{value}' exactly once: '{key}:'"
+}
+
+@test "git-team: config inject-sources should write the configuration to gitconfig" {
+	run bash -c "git team config inject-sources message,commit"
+	assert_success
+	assert_line --index 0 "Configuration updated: 'inject-sources' → 'message,commit'"
+
+	run bash -c "git config --global --get-all team.config.inject-sources"
+	assert_success
+	assert_line --index 0 'message'
+	assert_line --index 1 'commit'
+
+	/usr/local/bin/git-team config inject-sources default
+}
+
+@test "git-team: config inject-sources default should remove the configuration from gitconfig" {
+	/usr/local/bin/git-team config inject-sources message,commit
+	/usr/local/bin/git-team config inject-sources default
+
+	run bash -c "git config --global --get-all team.config.inject-sources"
+	assert_failure
+}
+
+@test "git-team: config inject-sources with an unknown source should fail" {
+	run bash -c "git team config inject-sources message,rebase"
+	assert_failure 1
+	assert_line "error: unknown commit source 'rebase', expected any of: message, template, merge, squash, commit, none"
+}
+
+@test "git-team: config require-coauthors should write the configuration to the repository's gitconfig and keep the hooks installed" {
+	mkdir -p /tmp/repo/require-coauthors
+	cd /tmp/repo/require-coauthors
+	git init
+
+	run bash -c "git team config require-coauthors true"
+	assert_success
+	assert_line --index 0 "Configuration updated: 'require-coauthors' → 'true'"
+
+	run bash -c "git config --local team.config.require-coauthors"
+	assert_success
+	assert_output 'true'
+
+	run bash -c "git config --local core.hooksPath"
+	assert_success
+	assert_output '/root/.git-team/hooks'
+
+	/usr/local/bin/git-team config require-coauthors false
+
+	run bash -c "git config --local core.hooksPath"
+	assert_failure
+
+	cd -
+	rm -rf /tmp/repo/require-coauthors
+}
+
+@test "git-team: config require-coauthors with an unknown value should fail" {
+	run bash -c "git team config require-coauthors maybe"
+	assert_failure 1
+	assert_line "error: unknown value for require-coauthors 'maybe', expected one of: true, false"
+}
@@ -66,3 +66,19 @@ teardown() {
 	assert_line "git-team disabled"
 }
 
+
+@test "git-team: (scope: global) disable should restore a previously displaced core.hooksPath and commit.template" {
+	git config --global core.hooksPath /path/to/foreign/hooks
+	git config --global commit.template /path/to/foreign/template
+
+	/usr/local/bin/git-team enable 'A <a@x.y>'
+	/usr/local/bin/git-team disable
+
+	run bash -c "git config --global core.hooksPath && git config --global commit.template"
+	assert_success
+	assert_line --index 0 '/path/to/foreign/hooks'
+	assert_line --index 1 '/path/to/foreign/template'
+
+	run git config --global --get-regexp team.displaced
+	assert_failure 1
+}
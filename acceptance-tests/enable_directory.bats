@@ -0,0 +1,70 @@
+#!/usr/bin/env bats
+
+load '/bats-libs/bats-support/load.bash'
+load '/bats-libs/bats-assert/load.bash'
+
+WORK_DIR=/tmp/work
+REPO_PATH=$WORK_DIR/enable-tests
+OTHER_REPO_PATH=/tmp/other/enable-tests
+
+setup() {
+	/usr/local/bin/git-team config activation-directory $WORK_DIR
+	/usr/local/bin/git-team config activation-scope directory
+
+	for repo in $REPO_PATH $OTHER_REPO_PATH; do
+		mkdir -p $repo
+		git -C $repo init
+		git -C $repo config user.name git-team-acceptance-test
+		git -C $repo config user.email foo@bar.baz
+	done
+
+	cd $REPO_PATH
+
+	/usr/local/bin/git-team assignments add a 'A <a@x.y>'
+}
+
+teardown() {
+	/usr/local/bin/git-team disable
+
+	/usr/local/bin/git-team config activation-scope global
+	git config --global --unset-all team.config.activation-directory
+
+	/usr/local/bin/git-team assignments rm a
+
+	cd -
+	rm -rf $WORK_DIR /tmp/other
+}
+
+@test "git-team: (scope: directory) enable should include the directory config for the activation-directory" {
+	run bash -c "/usr/local/bin/git-team enable a &>/dev/null && git config --global includeIf.gitdir:$WORK_DIR/.path"
+	assert_success
+	assert_line '/root/.git-team/directory.gitconfig'
+}
+
+@test "git-team: (scope: directory) enable should persist the current status to the directory config" {
+	/usr/local/bin/git-team enable a
+
+	run bash -c "git config --file /root/.git-team/directory.gitconfig --get-regexp team.state | sort"
+	assert_success
+	assert_line --index 0 'team.state.active-coauthors A <a@x.y>'
+	assert_line --index 1 'team.state.status enabled'
+}
+
+@test "git-team: (scope: directory) enable should set the prepare-commit-msg hook for repositories below the activation-directory only" {
+	/usr/local/bin/git-team enable a
+
+	run bash -c "git -C $REPO_PATH config core.hooksPath"
+	assert_success
+	assert_line '/root/.git-team/hooks'
+
+	run bash -c "git -C $OTHER_REPO_PATH config core.hooksPath"
+	assert_failure 1
+}
+
+@test "git-team: (scope: directory) disable should remove the include for the activation-directory" {
+	/usr/local/bin/git-team enable a
+	/usr/local/bin/git-team disable
+
+	run bash -c "git config --global includeIf.gitdir:$WORK_DIR/.path"
+	assert_failure 1
+}
@@ -123,3 +123,26 @@ teardown() {
 	assert_line 'error: failed to resolve alias team.alias.non-existing-alias'
 }
 
+
+@test "git-team: (scope: global) enable with roles should persist the roles to gitconfig" {
+	/usr/local/bin/git-team enable --reviewer b --role 'navigator=Ad-hoc <adhoc@tmp.se>' a
+
+	run bash -c "git config --global --get-all team.state.active-roles"
+	assert_success
+	assert_line --index 0 'navigator=Ad-hoc <adhoc@tmp.se>'
+	assert_line --index 1 'reviewer=B <b@x.y>'
+}
+
+@test "git-team: (scope: global) enable with roles should provision the commit template with a trailer per role" {
+	run bash -c "/usr/local/bin/git-team enable --reviewer b --navigator c a &>/dev/null && cat /root/.git-team/commit-templates/global/COMMIT_TEMPLATE"
+	assert_success
+	assert_line --index 0 'Co-authored-by: A <a@x.y>'
+	assert_line --index 1 'Navigated-by: C <c@x.y>'
+	assert_line --index 2 'Reviewed-by: B <b@x.y>'
+}
+
+@test "git-team: (scope: global) enable with an unknown role should fail" {
+	run bash -c "/usr/local/bin/git-team enable --role tester=b a"
+	assert_failure
+	assert_line "error: unknown role 'tester', expected one of: navigator, reviewer"
+}
@@ -0,0 +1,111 @@
+#!/usr/bin/env bats
+
+load '/bats-libs/bats-support/load.bash'
+load '/bats-libs/bats-assert/load.bash'
+
+REPO_PATH=/tmp/gc-tests
+
+setup() {
+	/usr/local/bin/git-team config activation-scope repo-local
+
+	mkdir -p $REPO_PATH
+	cd $REPO_PATH
+	git init
+}
+
+teardown() {
+	/usr/local/bin/git-team config activation-scope global
+
+	cd -
+	rm -rf $REPO_PATH
+}
+
+@test "git-team: gc should remove the commit template of a repository which no longer exists" {
+	/usr/local/bin/git-team enable 'A <a@x.y>'
+	template_dir=$(dirname "$(git config --local commit.template)")
+
+	cd -
+	rm -rf $REPO_PATH
+
+	run /usr/local/bin/git-team gc
+	assert_success
+	assert_line --index 0 'stale commit templates'
+	assert_line --index 1 "─ $template_dir (repository no longer exists: $REPO_PATH/.git)"
+
+	run ls $template_dir
+	assert_failure
+}
+
+@test "git-team: gc should keep the commit template of a repository with git-team enabled" {
+	/usr/local/bin/git-team enable 'A <a@x.y>'
+
+	run /usr/local/bin/git-team gc
+	assert_success
+	assert_line 'no stale commit templates found'
+
+	/usr/local/bin/git-team disable
+}
+
+@test "git-team: gc --dry-run should not remove anything" {
+	/usr/local/bin/git-team enable 'A <a@x.y>'
+	template_dir=$(dirname "$(git config --local commit.template)")
+	git config --local team.state.status disabled
+
+	run /usr/local/bin/git-team gc --dry-run
+	assert_success
+	assert_line --index 0 'stale commit templates'
+	assert_line --index 2 'dry-run: operations'
+	assert_line --index 3 "─ rm -r $template_dir"
+
+	run ls $template_dir
+	assert_success
+
+	/usr/local/bin/git-team gc
+}
+
+@test "git-team: gc should only report a commit template which is not tracked in the index" {
+	orphan_dir=$HOME/.git-team/commit-templates/repo-local/orphan
+	mkdir -p $orphan_dir
+	touch $orphan_dir/COMMIT_TEMPLATE
+
+	run /usr/local/bin/git-team gc
+	assert_success
+	assert_line --index 0 'untracked commit templates'
+	assert_line --index 1 "─ $orphan_dir (not tracked in the index, remove it with --remove-untracked)"
+
+	run ls $orphan_dir
+	assert_success
+
+	rm -rf $orphan_dir
+}
+
+@test "git-team: gc --remove-untracked should remove a commit template which is not tracked in the index" {
+	orphan_dir=$HOME/.git-team/commit-templates/repo-local/orphan
+	mkdir -p $orphan_dir
+	touch $orphan_dir/COMMIT_TEMPLATE
+
+	run /usr/local/bin/git-team gc --remove-untracked
+	assert_success
+	assert_line --index 0 'stale commit templates'
+	assert_line --index 1 "─ $orphan_dir (not tracked in the index)"
+
+	run ls $orphan_dir
+	assert_failure
+}
+
+@test "git-team: gc --remove-untracked should keep a commit template which is not tracked but still in use" {
+	orphan_dir=$HOME/.git-team/commit-templates/repo-local/orphan
+	mkdir -p $orphan_dir
+	touch $orphan_dir/COMMIT_TEMPLATE
+	git config --local commit.template $orphan_dir/COMMIT_TEMPLATE
+
+	run /usr/local/bin/git-team gc --remove-untracked
+	assert_success
+	assert_line --index 0 'untracked commit templates'
+	assert_line --index 1 "─ $orphan_dir (not tracked in the index, but still referred to by commit.template)"
+
+	run ls $orphan_dir
+	assert_success
+
+	rm -rf $orphan_dir
+}
@@ -0,0 +1,57 @@
+#!/usr/bin/env bats
+
+load '/bats-libs/bats-support/load.bash'
+load '/bats-libs/bats-assert/load.bash'
+
+REPO_PATH=/tmp/repo/log
+
+setup() {
+	git config --global init.defaultBranch main
+	/usr/local/bin/git-team assignments add a 'A <a@x.y>'
+
+	mkdir -p $REPO_PATH
+	cd $REPO_PATH
+	git init
+	git config user.name B
+	git config user.email b@x.y
+
+	git commit --allow-empty -m "paired" -m "Co-authored-by: A <a@x.y>"
+	git commit --allow-empty -m "mobbed" -m "Co-authored-by: A <a@x.y>
+Co-authored-by: C <c@x.y>"
+	git commit --allow-empty -m "solo"
+	git -c user.name=A -c user.email=a@x.y commit --allow-empty -m "authored by a"
+}
+
+teardown() {
+	cd -
+	rm -rf $REPO_PATH
+
+	rm /root/.gitconfig
+}
+
+@test "git-team: log --with should show the commits of a person as author or co-author" {
+	run /usr/local/bin/git-team log --with a --format=%s
+	assert_success
+	assert_line --index 0 'authored by a'
+	assert_line --index 1 'mobbed'
+	assert_line --index 2 'paired'
+	refute_output --partial 'solo'
+}
+
+@test "git-team: log --pair should show the commits the people made together" {
+	run /usr/local/bin/git-team log --pair a,c@x.y --format=%s
+	assert_success
+	assert_output 'mobbed'
+}
+
+@test "git-team: log should limit the number of matching commits" {
+	run /usr/local/bin/git-team log --with a -n 1 --skip 1 --format=%s
+	assert_success
+	assert_output 'mobbed'
+}
+
+@test "git-team: log should fail for an unknown alias" {
+	run /usr/local/bin/git-team log --with z
+	assert_failure 1
+	assert_line 'error: unknown person '\''z'\'', expected an alias, an email or "Name <email>"'
+}
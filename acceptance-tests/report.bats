@@ -0,0 +1,84 @@
+#!/usr/bin/env bats
+
+load '/bats-libs/bats-support/load.bash'
+load '/bats-libs/bats-assert/load.bash'
+
+REPO_PATH=/tmp/repo/report
+SESSION_LOG=/root/.git-team/sessions.log
+
+setup() {
+	git config --global init.defaultBranch main
+	/usr/local/bin/git-team assignments add a 'A <a@x.y>'
+
+	mkdir -p $REPO_PATH
+	cd $REPO_PATH
+	git init
+}
+
+teardown() {
+	/usr/local/bin/git-team disable
+
+	cd -
+	rm -rf $REPO_PATH
+
+	rm -f $SESSION_LOG
+	rm /root/.gitconfig
+}
+
+@test "git-team: enable and disable should append to the session log" {
+	/usr/local/bin/git-team enable a 'B <b@x.y>'
+	/usr/local/bin/git-team disable
+
+	run cut -f 2- $SESSION_LOG
+	assert_success
+	assert_line --index 0 "enable	global		A <a@x.y>	B <b@x.y>"
+	assert_line --index 1 "disable	global	"
+}
+
+@test "git-team: enable and disable should record the common git dir of repo-local sessions" {
+	/usr/local/bin/git-team config activation-scope repo-local
+	/usr/local/bin/git-team enable a
+	/usr/local/bin/git-team disable
+
+	run cut -f 2- $SESSION_LOG
+	assert_success
+	assert_line --index 0 "enable	repo-local	$REPO_PATH/.git	A <a@x.y>"
+	assert_line --index 1 "disable	repo-local	$REPO_PATH/.git"
+
+	/usr/local/bin/git-team config activation-scope global
+}
+
+@test "git-team: report should sum up the time per co-author, scope and repository" {
+	mkdir -p /root/.git-team
+	printf '2021-06-07T09:00:00Z\tenable\trepo-local\t/path/to/repo/.git\tA <a@x.y>\tB <b@x.y>\n2021-06-07T11:30:00Z\tdisable\trepo-local\t/path/to/repo/.git\n2021-06-07T11:30:00Z\tenable\tglobal\t\tA <a@x.y>\n2021-06-07T12:15:00Z\tdisable\tglobal\t\n' > $SESSION_LOG
+
+	run /usr/local/bin/git-team report --since 2021-06-01
+	assert_success
+	assert_line --index 0 'co-author  time'
+	assert_line --index 1 'A <a@x.y>  3h 15m'
+	assert_line --index 2 'B <b@x.y>  2h 30m'
+	assert_line --index 3 'scope   time'
+	assert_line --index 4 'global  0h 45m'
+	assert_line --index 5 'repository     time'
+	assert_line --index 6 '/path/to/repo  2h 30m'
+}
+
+@test "git-team: report --format csv should print the hours" {
+	mkdir -p /root/.git-team
+	printf '2021-06-07T09:00:00Z\tenable\tglobal\t\tA <a@x.y>\n2021-06-07T10:30:00Z\tdisable\tglobal\t\n' > $SESSION_LOG
+
+	run /usr/local/bin/git-team report --format csv
+	assert_success
+	assert_line --index 0 'type,name,hours'
+	assert_line --index 1 'co-author,A <a@x.y>,1.50'
+	assert_line --index 2 'scope,global,1.50'
+}
+
+@test "git-team: report should only consider the time since the given date" {
+	mkdir -p /root/.git-team
+	printf '2021-06-07T09:00:00Z\tenable\tglobal\t/path/to/repo\tA <a@x.y>\n2021-06-07T10:30:00Z\tdisable\tglobal\t/path/to/repo\n' > $SESSION_LOG
+
+	run /usr/local/bin/git-team report --since monday
+	assert_success
+	assert_line 'no sessions found'
+}
@@ -0,0 +1,55 @@
+#!/usr/bin/env bats
+
+load '/bats-libs/bats-support/load.bash'
+load '/bats-libs/bats-assert/load.bash'
+
+REPO_PATH=/tmp/repo/shortlog
+
+setup() {
+	git config --global init.defaultBranch main
+
+	mkdir -p $REPO_PATH
+	cd $REPO_PATH
+	git init
+	git config user.name B
+	git config user.email b@x.y
+
+	git commit --allow-empty -m "paired" -m "Co-authored-by: A <a@x.y>"
+	git commit --allow-empty -m "mobbed" -m "Co-authored-by: A <a@x.y>
+Co-authored-by: C <c@x.y>"
+	git commit --allow-empty -m "solo"
+}
+
+teardown() {
+	cd -
+	rm -rf $REPO_PATH
+
+	rm /root/.gitconfig
+}
+
+@test "git-team: shortlog should credit the author and every co-author" {
+	run /usr/local/bin/git-team shortlog
+	assert_success
+	assert_line --index 0 --regexp '^ +3	B$'
+	assert_line --index 1 --regexp '^ +2	A$'
+	assert_line --index 2 --regexp '^ +1	C$'
+}
+
+@test "git-team: shortlog --weighted should split shared commits" {
+	run /usr/local/bin/git-team shortlog --weighted HEAD~1
+	assert_success
+	assert_line --index 0 --regexp '^ +0.83	A$'
+	assert_line --index 1 --regexp '^ +0.83	B$'
+	assert_line --index 2 --regexp '^ +0.33	C$'
+}
+
+@test "git-team: shortlog should print a contributors section in markdown" {
+	echo 'Alice <a@x.y> A <a@x.y>' > .mailmap
+	echo 'Alice <a@x.y> <c@x.y>' >> .mailmap
+
+	run /usr/local/bin/git-team shortlog --mailmap --format markdown
+	assert_success
+	assert_line --index 0 '### Contributors'
+	assert_line --index 1 '- B (3 commits)'
+	assert_line --index 2 '- Alice (2 commits)'
+}
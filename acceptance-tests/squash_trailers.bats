@@ -0,0 +1,61 @@
+#!/usr/bin/env bats
+
+load '/bats-libs/bats-support/load.bash'
+load '/bats-libs/bats-assert/load.bash'
+
+REPO_PATH=/tmp/repo/squash-trailers
+
+setup() {
+	git config --global init.defaultBranch main
+
+	mkdir -p $REPO_PATH
+	cd $REPO_PATH
+	git init
+	git config user.name B
+	git config user.email b@x.y
+
+	git commit --allow-empty -m "base"
+	git checkout -b feature
+	touch a
+	git add a
+	git commit -m "paired" -m "Co-authored-by: A <a@x.y>"
+	touch c
+	git add c
+	git -c user.name=C -c user.email=c@x.y commit -m "mobbed" -m "Co-authored-by: A <a@x.y>
+Co-authored-by: B <b@x.y>"
+	git checkout main
+}
+
+teardown() {
+	cd -
+	rm -rf $REPO_PATH
+
+	rm /root/.gitconfig
+}
+
+@test "git-team: squash-trailers should print the authors and co-authors of the range except for the committer" {
+	run /usr/local/bin/git-team squash-trailers main..feature
+	assert_success
+	assert_output 'Co-authored-by: A <a@x.y>
+Co-authored-by: C <c@x.y>'
+}
+
+@test "git-team: squash-trailers --write-message should add the trailers to the squash message" {
+	git merge --squash feature
+
+	run /usr/local/bin/git-team squash-trailers --write-message main..feature
+	assert_success
+	assert_output 'Added 2 co-author(s) to .git/SQUASH_MSG'
+
+	git commit --no-edit
+
+	run bash -c "git log -1 --format=%B | tail -n 2"
+	assert_output 'Co-authored-by: A <a@x.y>
+Co-authored-by: C <c@x.y>'
+}
+
+@test "git-team: squash-trailers should fail without a range" {
+	run /usr/local/bin/git-team squash-trailers
+	assert_failure 1
+	assert_line 'error: the range of commits to squash is missing, e.g. main..feature'
+}
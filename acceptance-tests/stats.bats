@@ -0,0 +1,50 @@
+#!/usr/bin/env bats
+
+load '/bats-libs/bats-support/load.bash'
+load '/bats-libs/bats-assert/load.bash'
+
+REPO_PATH=/tmp/repo/stats
+
+setup() {
+	git config --global init.defaultBranch main
+	/usr/local/bin/git-team assignments add a 'A <a@x.y>'
+
+	mkdir -p $REPO_PATH
+	cd $REPO_PATH
+	git init
+	git config user.name B
+	git config user.email b@x.y
+
+	git commit --allow-empty -m "paired" -m "Co-authored-by: A <a@x.y>"
+	git commit --allow-empty -m "mobbed" -m "Co-authored-by: A <a@x.y>
+Co-authored-by: C <c@x.y>"
+	git commit --allow-empty -m "solo"
+}
+
+teardown() {
+	cd -
+	rm -rf $REPO_PATH
+
+	rm /root/.gitconfig
+}
+
+@test "git-team: stats should print the pairing matrix as csv" {
+	run /usr/local/bin/git-team stats --format csv
+	assert_success
+	assert_line --index 0 ',B <b@x.y>,a,C <c@x.y>'
+	assert_line --index 1 'B <b@x.y>,3,2,1'
+	assert_line --index 2 'a,2,2,1'
+	assert_line --index 3 'C <c@x.y>,1,1,1'
+}
+
+@test "git-team: stats should print the pairing matrix as json" {
+	run /usr/local/bin/git-team stats --format json HEAD~2
+	assert_success
+	assert_output '{"people":["B <b@x.y>","a"],"commits":[[1,1],[1,1]]}'
+}
+
+@test "git-team: stats with an unknown format should fail" {
+	run /usr/local/bin/git-team stats --format xml
+	assert_failure 1
+	assert_line 'error: unknown format '"'"'xml'"'"', expected one of: table, csv, json'
+}
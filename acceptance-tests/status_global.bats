@@ -27,3 +27,35 @@ setup() {
 	/usr/local/bin/git-team disable
 }
 
+
+@test 'git-team: (scope: global) status should display displaced settings' {
+	git config --global core.hooksPath /path/to/foreign/hooks
+	/usr/local/bin/git-team enable 'A <a@x.y>'
+
+	run /usr/local/bin/git-team status
+	assert_success
+	assert_line --index 0 'git-team enabled'
+	assert_line --index 1 'co-authors'
+	assert_line --index 2 '─ A <a@x.y>'
+	assert_line --index 3 'displaced settings (restored on disable)'
+	assert_line --index 4 '─ core.hooksPath: /path/to/foreign/hooks'
+
+	/usr/local/bin/git-team disable
+	git config --global --unset-all core.hooksPath
+}
+
+@test 'git-team: (scope: global) status should group the enabled identities by role' {
+	/usr/local/bin/git-team enable --reviewer 'B <b@x.y>' --navigator 'C <c@x.y>' 'A <a@x.y>'
+
+	run /usr/local/bin/git-team status
+	assert_success
+	assert_line --index 0 'git-team enabled'
+	assert_line --index 1 'co-authors'
+	assert_line --index 2 '─ A <a@x.y>'
+	assert_line --index 3 'navigators'
+	assert_line --index 4 '─ C <c@x.y>'
+	assert_line --index 5 'reviewers'
+	assert_line --index 6 '─ B <b@x.y>'
+
+	/usr/local/bin/git-team disable
+}
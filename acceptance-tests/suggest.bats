@@ -0,0 +1,46 @@
+#!/usr/bin/env bats
+
+load '/bats-libs/bats-support/load.bash'
+load '/bats-libs/bats-assert/load.bash'
+
+REPO_PATH=/tmp/repo/suggest
+
+setup() {
+	git config --global init.defaultBranch main
+	/usr/local/bin/git-team assignments add a 'A <a@x.y>'
+	/usr/local/bin/git-team assignments add b 'B <b@x.y>'
+	/usr/local/bin/git-team assignments add c 'C <c@x.y>'
+
+	mkdir -p $REPO_PATH
+	cd $REPO_PATH
+	git init
+	git config user.name U
+	git config user.email u@x.y
+
+	git commit --allow-empty -m "paired" -m "Co-authored-by: A <a@x.y>"
+}
+
+teardown() {
+	/usr/local/bin/git-team disable
+
+	cd -
+	rm -rf $REPO_PATH
+
+	rm /root/.gitconfig
+}
+
+@test "git-team: suggest should pair people who have not worked together" {
+	run /usr/local/bin/git-team suggest
+	assert_success
+	assert_line --index 0 'suggestions'
+	assert_line --index 1 '─ U <u@x.y> + b (never worked together) *'
+	assert_line --index 2 '─ a + c (never worked together)'
+}
+
+@test "git-team: suggest --enable should enable the co-authors of the own suggestion" {
+	run /usr/local/bin/git-team suggest --enable
+	assert_success
+	assert_line --index 0 'git-team enabled'
+	assert_line --index 1 'co-authors'
+	assert_line --index 2 '─ B <b@x.y>'
+}
@@ -56,6 +56,21 @@ teardown() {
 	assert_line --index 0 'prepare-commit-msg hook triggered with params: .git/COMMIT_EDITMSG message'
 }
 
+@test "use case: (scope: global) a repo-local core.hooksPath displaced by require-coauthors should be respected" {
+	mkdir -p $REPO_PATH/.githooks
+	echo -e '#!/bin/sh\necho "repo commit-msg hook triggered"\nexit 1' > $REPO_PATH/.githooks/commit-msg
+	chmod +x $REPO_PATH/.githooks/commit-msg
+	git config --local core.hooksPath .githooks
+
+	/usr/local/bin/git-team config require-coauthors true
+
+	git add -A
+	run git commit -m "test" -m "[solo]"
+
+	assert_failure
+	assert_line --index 0 'repo commit-msg hook triggered'
+}
+
 @test "use case: (scope: global) when git-team is enabled then 'git commit -m' should have the respective co-authors injected" {
 	/usr/local/bin/git-team enable 'B <b@x.y>' 'A <a@x.y>' 'C <c@x.y>'
 
@@ -158,6 +173,29 @@ EOF
 	/usr/local/bin/git-team disable
 }
 
+@test "use case: (scope: global) when git-team is enabled, squashing commits during an interactive rebase should result in a single co-author block" {
+	git commit --allow-empty -m 'initial commit'
+	git commit --allow-empty -m 'first' -m 'Co-authored-by: B <b@x.y>'
+	git commit --allow-empty -m 'second' -m 'Co-authored-by: B <b@x.y>
+Co-authored-by: C <c@x.y>'
+
+	/usr/local/bin/git-team enable 'A <a@x.y>'
+	GIT_SEQUENCE_EDITOR="sed -i -e '2s/^pick/squash/'" GIT_EDITOR=true git rebase -i HEAD~2
+
+	run git log -1 --format=%B
+
+	assert_success
+	assert_output 'first
+
+second
+
+Co-authored-by: B <b@x.y>
+Co-authored-by: C <c@x.y>
+Co-authored-by: A <a@x.y>'
+
+	/usr/local/bin/git-team disable
+}
+
 @test "use case: (scope: global) when git-team is enabled, an amended commit message should not have any co-authors injected" {
 	echo '# some-repository' > README.md
 	git add README.md
@@ -179,3 +217,28 @@ EOF
 
 	/usr/local/bin/git-team disable
 }
+
+@test "use case: (scope: global) when git-team is enabled, a commit with GIT_TEAM_SKIP=1 should not have any co-authors injected" {
+	/usr/local/bin/git-team enable 'A <a@x.y>'
+
+	git add -A
+	GIT_TEAM_SKIP=1 git commit -m "solo"
+
+	run git log -1 --format=%B
+	assert_success
+	assert_output 'solo'
+
+	run /usr/local/bin/git-team status
+	assert_line --index 0 'git-team enabled'
+}
+
+@test "use case: (scope: global) when git-team is enabled, a commit with a [solo] line should not have any co-authors injected" {
+	/usr/local/bin/git-team enable 'A <a@x.y>'
+
+	git add -A
+	git commit -m "solo" -m "[solo]"
+
+	run git log -1 --format=%B
+	assert_success
+	assert_output 'solo'
+}
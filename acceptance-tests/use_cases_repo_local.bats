@@ -56,6 +56,23 @@ teardown() {
 	assert_line --index 0 'prepare-commit-msg hook triggered with params: .git/COMMIT_EDITMSG message'
 }
 
+@test "use case: (scope: repo-local) a global hooks directory which was in effect before enabling git-team should be respected" {
+	mkdir -p /tmp/global-hooks
+	echo -e '#!/bin/sh\necho "global commit-msg hook triggered"\nexit 1' > /tmp/global-hooks/commit-msg
+	chmod +x /tmp/global-hooks/commit-msg
+	git config --global core.hooksPath /tmp/global-hooks
+
+	/usr/local/bin/git-team enable 'A <a@x.y>'
+
+	git add -A
+	run git commit -m "test"
+
+	rm -rf /tmp/global-hooks
+
+	assert_failure
+	assert_line --index 0 'global commit-msg hook triggered'
+}
+
 @test "use case: (scope: repo-local) when git-team is enabled then 'git commit -m' should have the respective co-authors injected" {
 	/usr/local/bin/git-team enable 'B <b@x.y>' 'A <a@x.y>' 'C <c@x.y>'
 
@@ -180,3 +197,44 @@ EOF
 
 	/usr/local/bin/git-team disable
 }
+
+@test "use case: (scope: repo-local) when co-authors are required, a commit without co-authors should be rejected" {
+	/usr/local/bin/git-team config require-coauthors true
+
+	git add -A
+	run git commit -m "test"
+	assert_failure
+	assert_line "error: commit-msg: this repository requires co-authors, but the commit message does not contain any 'Co-authored-by:' trailer. Use 'git team enable' to add them, or commit solo with a '[solo]' line in the message, GIT_TEAM_SKIP=1 or 'git commit --no-verify'"
+
+	/usr/local/bin/git-team config require-coauthors false
+}
+
+@test "use case: (scope: repo-local) when co-authors are required, a commit with co-authors should be accepted" {
+	/usr/local/bin/git-team config require-coauthors true
+	/usr/local/bin/git-team enable 'A <a@x.y>'
+
+	git add -A
+	run git commit -m "test"
+	assert_success
+
+	run bash -c "git show --name-only HEAD | grep -e 'Co-authored-by: A <a@x.y>'"
+	assert_success
+
+	/usr/local/bin/git-team config require-coauthors false
+}
+
+@test "use case: (scope: repo-local) when co-authors are required, a commit with a [solo] line should be accepted even though git-team is disabled" {
+	/usr/local/bin/git-team config require-coauthors true
+	/usr/local/bin/git-team enable 'A <a@x.y>'
+	/usr/local/bin/git-team disable
+
+	git add -A
+	run git commit -m "solo" -m "[solo]"
+	assert_success
+
+	run bash -c "git log -1 --format=%B"
+	assert_success
+	assert_output 'solo'
+
+	/usr/local/bin/git-team config require-coauthors false
+}
@@ -0,0 +1,61 @@
+#!/usr/bin/env bats
+
+load '/bats-libs/bats-support/load.bash'
+load '/bats-libs/bats-assert/load.bash'
+
+REPO_PATH=/tmp/repo/verify
+
+setup() {
+	git config --global init.defaultBranch main
+	/usr/local/bin/git-team assignments add a 'A <a@x.y>'
+
+	mkdir -p $REPO_PATH
+	cd $REPO_PATH
+	git init
+	git config user.name Me
+	git config user.email me@x.y
+
+	git commit --allow-empty -m "base"
+	git commit --allow-empty -m "paired" -m "Co-authored-by: A <a@x.y>"
+}
+
+teardown() {
+	cd -
+	rm -rf $REPO_PATH
+
+	rm /root/.gitconfig
+}
+
+@test "git-team: verify should succeed when all co-author trailers are fine" {
+	run /usr/local/bin/git-team verify --strict --allowed-domain x.y main~1..main
+	assert_success
+	assert_line '1 commit verified'
+}
+
+@test "git-team: verify should fail on malformed and duplicate co-author trailers" {
+	git commit --allow-empty -m "broken" -m "Co-authored-by: foo
+Co-authored-by: A <a@x.y>
+Co-authored-by: A <A@x.y>"
+
+	run /usr/local/bin/git-team verify main~2..main
+	assert_failure 1
+	assert_line --index 1 '─ [malformed] not a valid coauthor: foo'
+	assert_line --index 2 "─ [duplicate] co-author 'A <A@x.y>' is named more than once"
+	assert_line --index 3 '1 of 2 commits violates the co-author rules'
+}
+
+@test "git-team: verify --strict should fail on co-authors outside of the allowed domains and unknown ones as json" {
+	git commit --allow-empty -m "stranger" -m "Co-authored-by: C <c@evil.com>"
+
+	run /usr/local/bin/git-team verify --strict --allowed-domain x.y --format json main~1..main
+	assert_failure 1
+	assert_output --partial '"commits":1'
+	assert_output --partial '"kind":"domain","line":"Co-authored-by: C <c@evil.com>"'
+	assert_output --partial '"kind":"unknown","line":"Co-authored-by: C <c@evil.com>"'
+}
+
+@test "git-team: verify should fail without a range" {
+	run /usr/local/bin/git-team verify
+	assert_failure 1
+	assert_line 'error: the range of commits to verify is missing, e.g. origin/main..HEAD'
+}
@@ -0,0 +1,59 @@
+#!/usr/bin/env bats
+
+load '/bats-libs/bats-support/load.bash'
+load '/bats-libs/bats-assert/load.bash'
+
+REPO_PATH=/tmp/repo/who
+
+setup() {
+	git config --global init.defaultBranch main
+	/usr/local/bin/git-team assignments add a 'A <a@x.y>'
+	/usr/local/bin/git-team assignments add b 'B <b@x.y>'
+
+	mkdir -p $REPO_PATH
+	cd $REPO_PATH
+	git init
+	git config user.name Me
+	git config user.email me@x.y
+
+	mkdir -p .github src/api
+	echo '/src/api/ @a' > .github/CODEOWNERS
+	touch README.md src/api/handler.go
+	git add -A
+	git commit -m "initial commit" -m "Co-authored-by: C <c@x.y>"
+	echo 'change' > src/api/handler.go
+	git commit -am "change" -m "Co-authored-by: B <b@x.y>"
+}
+
+teardown() {
+	/usr/local/bin/git-team disable
+
+	cd -
+	rm -rf $REPO_PATH
+
+	rm /root/.gitconfig
+}
+
+@test "git-team: who should rank the code owners and the recent authors and co-authors of the paths" {
+	run /usr/local/bin/git-team who src/api
+	assert_success
+	assert_line --index 0 'candidates'
+	assert_line --index 1 '─ a (code owner)'
+	assert_line --index 2 '─ C <c@x.y> (1 commit)'
+	assert_line --index 3 '─ b (1 commit)'
+}
+
+@test "git-team: who --enable should enable the top candidates" {
+	run /usr/local/bin/git-team who --enable 2 src/api
+	assert_success
+	assert_line --index 0 'git-team enabled'
+	assert_line --index 1 'co-authors'
+	assert_line --index 2 '─ A <a@x.y>'
+	assert_line --index 3 '─ C <c@x.y>'
+}
+
+@test "git-team: who should fail for paths without tracked files" {
+	run /usr/local/bin/git-team who nope
+	assert_failure 1
+	assert_line 'error: no tracked files match: nope'
+}
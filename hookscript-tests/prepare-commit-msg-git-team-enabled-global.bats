@@ -55,3 +55,120 @@ teardown() {
 	assert_line --index 2 'Co-authored-by: C <c@x.y>'
 }
 
+@test "prepare-commit-msg: git-team enabled: (scope: global) - message with a configured trailer-key and trailer-format" {
+	git config --global team.config.trailer-key Pair
+	git config --global team.config.trailer-format '{key} → {value}'
+
+	run bash -c "/usr/local/bin/prepare-commit-msg-git-team /tmp/COMMIT_MSG message && cat /tmp/COMMIT_MSG"
+
+	git config --global --unset team.config.trailer-key
+	git config --global --unset team.config.trailer-format
+
+	assert_success
+	assert_line --index 0 'Pair → A <a@x.y>'
+	assert_line --index 1 'Pair → B <b@x.y>'
+	assert_line --index 2 'Pair → C <c@x.y>'
+}
+
+@test "prepare-commit-msg: git-team enabled: (scope: global) - message which already contains some of the co-authors" {
+	printf 'subject\n\nSigned-off-by: S <s@x.y>\nCo-authored-by: b <B@X.Y>\n' > /tmp/COMMIT_MSG
+
+	run bash -c "/usr/local/bin/prepare-commit-msg-git-team /tmp/COMMIT_MSG message && cat /tmp/COMMIT_MSG"
+	assert_success
+	assert_output "$(printf 'subject\n\nSigned-off-by: S <s@x.y>\nCo-authored-by: b <B@X.Y>\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>')"
+}
+
+@test "prepare-commit-msg: git-team enabled: (scope: global) - message which quotes a trailer within its body" {
+	printf 'subject\n\nstop adding Co-authored-by: lines twice\n' > /tmp/COMMIT_MSG
+
+	run bash -c "/usr/local/bin/prepare-commit-msg-git-team /tmp/COMMIT_MSG message && cat /tmp/COMMIT_MSG"
+	assert_success
+	assert_output "$(printf 'subject\n\nstop adding Co-authored-by: lines twice\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>')"
+}
+
+@test "prepare-commit-msg: git-team enabled: (scope: global) - merge with comments" {
+	printf 'Merge branch x\n\n# Please enter a commit message\n' > /tmp/COMMIT_MSG
+
+	run bash -c "/usr/local/bin/prepare-commit-msg-git-team /tmp/COMMIT_MSG merge && cat /tmp/COMMIT_MSG"
+	assert_success
+	assert_output "$(printf 'Merge branch x\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n\n# Please enter a commit message')"
+}
+
+@test "prepare-commit-msg: git-team enabled: (scope: global) - merge with comments and a custom core.commentChar" {
+	git config --global core.commentChar ';'
+	printf 'Merge branch x\n\n; Please enter a commit message\n' > /tmp/COMMIT_MSG
+
+	run bash -c "/usr/local/bin/prepare-commit-msg-git-team /tmp/COMMIT_MSG merge && cat /tmp/COMMIT_MSG"
+
+	git config --global --unset core.commentChar
+
+	assert_success
+	assert_output "$(printf 'Merge branch x\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n\n; Please enter a commit message')"
+}
+
+@test "prepare-commit-msg: git-team enabled: (scope: global) - merge with commit.cleanup verbatim" {
+	git config --global commit.cleanup verbatim
+	printf 'Merge branch x\n\n# kept\n' > /tmp/COMMIT_MSG
+
+	run bash -c "/usr/local/bin/prepare-commit-msg-git-team /tmp/COMMIT_MSG merge && cat /tmp/COMMIT_MSG"
+
+	git config --global --unset commit.cleanup
+
+	assert_success
+	assert_output "$(printf 'Merge branch x\n\n# kept\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>')"
+}
+
+@test "prepare-commit-msg: git-team enabled: (scope: global) - squash above the scissors line" {
+	printf 'squash\n\n# ------------------------ >8 ------------------------\ndiff\n' > /tmp/COMMIT_MSG
+
+	run bash -c "/usr/local/bin/prepare-commit-msg-git-team /tmp/COMMIT_MSG squash && cat /tmp/COMMIT_MSG"
+	assert_success
+	assert_output "$(printf 'squash\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n\n# ------------------------ >8 ------------------------\ndiff')"
+}
+
+@test "prepare-commit-msg: git-team enabled: (scope: global) - message with roles" {
+	/usr/local/bin/git-team enable --reviewer 'R <r@x.y>' 'A <a@x.y>'
+
+	run bash -c "/usr/local/bin/prepare-commit-msg-git-team /tmp/COMMIT_MSG message && cat /tmp/COMMIT_MSG"
+	assert_success
+	assert_line --index 0 'Co-authored-by: A <a@x.y>'
+	assert_line --index 1 'Reviewed-by: R <r@x.y>'
+}
+
+@test "prepare-commit-msg: git-team enabled: (scope: global) - commit with commit in inject-sources" {
+	git config --global --add team.config.inject-sources message
+	git config --global --add team.config.inject-sources commit
+	printf 'amended\n\nCo-authored-by: B <b@x.y>\n' > /tmp/COMMIT_MSG
+
+	run bash -c "/usr/local/bin/prepare-commit-msg-git-team /tmp/COMMIT_MSG commit && cat /tmp/COMMIT_MSG"
+
+	git config --global --unset-all team.config.inject-sources
+
+	assert_success
+	assert_output "$(printf 'amended\n\nCo-authored-by: B <b@x.y>\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>')"
+}
+
+@test "prepare-commit-msg: git-team enabled: (scope: global) - merge without merge in inject-sources" {
+	git config --global team.config.inject-sources message
+
+	run bash -c "/usr/local/bin/prepare-commit-msg-git-team /tmp/COMMIT_MSG merge && cat /tmp/COMMIT_MSG"
+
+	git config --global --unset-all team.config.inject-sources
+
+	assert_success
+	refute_output --regexp '\w+'
+}
+
+@test "prepare-commit-msg: git-team enabled: (scope: global) - message with GIT_TEAM_SKIP=1" {
+	run bash -c "GIT_TEAM_SKIP=1 /usr/local/bin/prepare-commit-msg-git-team /tmp/COMMIT_MSG message && cat /tmp/COMMIT_MSG"
+	assert_success
+	refute_output --regexp '\w+'
+}
+
+@test "prepare-commit-msg: git-team enabled: (scope: global) - message with a [solo] line" {
+	printf 'bump version\n\n[solo]\n' > /tmp/COMMIT_MSG
+
+	run bash -c "/usr/local/bin/prepare-commit-msg-git-team /tmp/COMMIT_MSG message && cat /tmp/COMMIT_MSG"
+	assert_success
+	assert_output 'bump version'
+}
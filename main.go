@@ -10,16 +10,30 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/hekmekk/git-team/src/shared/cli/effects"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
 
+	amendcmdadapter "github.com/hekmekk/git-team/src/command/amend/cliadapter/cmd"
 	addcmdadapter "github.com/hekmekk/git-team/src/command/assignments/add/cliadapter/cmd"
 	assignmentscmdadapter "github.com/hekmekk/git-team/src/command/assignments/cliadapter/cmd"
 	listcmdadapter "github.com/hekmekk/git-team/src/command/assignments/list/cliadapter/cmd"
 	removecmdadapter "github.com/hekmekk/git-team/src/command/assignments/remove/cliadapter/cmd"
+	blamecmdadapter "github.com/hekmekk/git-team/src/command/blame/cliadapter/cmd"
 	completioncmdadapter "github.com/hekmekk/git-team/src/command/completion/cliadapter/cmd"
 	configcmdadapter "github.com/hekmekk/git-team/src/command/config/cliadapter/cmd"
 	disablecmdadapter "github.com/hekmekk/git-team/src/command/disable/cliadapter/cmd"
 	enablecmdadapter "github.com/hekmekk/git-team/src/command/enable/cliadapter/cmd"
+	gccmdadapter "github.com/hekmekk/git-team/src/command/gc/cliadapter/cmd"
+	hookcmdadapter "github.com/hekmekk/git-team/src/command/hook/cliadapter/cmd"
+	logcmdadapter "github.com/hekmekk/git-team/src/command/log/cliadapter/cmd"
+	reportcmdadapter "github.com/hekmekk/git-team/src/command/report/cliadapter/cmd"
+	retrocmdadapter "github.com/hekmekk/git-team/src/command/retro/cliadapter/cmd"
+	shortlogcmdadapter "github.com/hekmekk/git-team/src/command/shortlog/cliadapter/cmd"
+	squashtrailerscmdadapter "github.com/hekmekk/git-team/src/command/squashtrailers/cliadapter/cmd"
+	statscmdadapter "github.com/hekmekk/git-team/src/command/stats/cliadapter/cmd"
 	statuscmdadapter "github.com/hekmekk/git-team/src/command/status/cliadapter/cmd"
+	suggestcmdadapter "github.com/hekmekk/git-team/src/command/suggest/cliadapter/cmd"
+	verifycmdadapter "github.com/hekmekk/git-team/src/command/verify/cliadapter/cmd"
+	whocmdadapter "github.com/hekmekk/git-team/src/command/who/cliadapter/cmd"
 )
 
 const (
@@ -53,6 +67,7 @@ func newApplication() *cli.App {
 		HideVersion:          false,
 		Flags: []cli.Flag{
 			&cli.BoolFlag{Name: "generate-man-page", Value: false, Usage: "Generate man page for this"},
+			dryrun.Flag(),
 		},
 		Commands: []*cli.Command{
 			enablecmdadapter.Command(),
@@ -64,6 +79,19 @@ func newApplication() *cli.App {
 			removecmdadapter.Command(),
 			configcmdadapter.Command(),
 			completioncmdadapter.Command(),
+			gccmdadapter.Command(),
+			amendcmdadapter.Command(),
+			retrocmdadapter.Command(),
+			statscmdadapter.Command(),
+			suggestcmdadapter.Command(),
+			logcmdadapter.Command(),
+			shortlogcmdadapter.Command(),
+			squashtrailerscmdadapter.Command(),
+			blamecmdadapter.Command(),
+			whocmdadapter.Command(),
+			reportcmdadapter.Command(),
+			verifycmdadapter.Command(),
+			hookcmdadapter.Command(),
 		},
 		Action: func(c *cli.Context) error {
 			shouldGenerateManPage := c.Bool("generate-man-page")
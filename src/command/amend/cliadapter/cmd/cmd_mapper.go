@@ -0,0 +1,61 @@
+package amendcmdadapter
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/hekmekk/git-team/src/command/amend"
+	amendeventadapter "github.com/hekmekk/git-team/src/command/amend/cliadapter/event"
+	"github.com/hekmekk/git-team/src/core/validation"
+	activation "github.com/hekmekk/git-team/src/shared/activation/impl"
+	commandadapter "github.com/hekmekk/git-team/src/shared/cli/commandadapter"
+	aliascompletion "github.com/hekmekk/git-team/src/shared/completion"
+	configds "github.com/hekmekk/git-team/src/shared/config/datasource"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/impl"
+	"github.com/hekmekk/git-team/src/shared/gitrepo"
+	state "github.com/hekmekk/git-team/src/shared/state/impl"
+)
+
+// Command the amend command
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:      "amend",
+		Usage:     "Add co-authors to the last commit, defaults to the active co-authors",
+		ArgsUsage: "[<co-authors>] (A co-author must either be an alias or of the shape \"Name <email>\")",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "force", Value: false, Aliases: []string{"f"}, Usage: "Amend the commit even if it has been pushed already"},
+		},
+		Action: func(c *cli.Context) error {
+			coauthors := c.Args().Slice()
+			force := c.Bool("force")
+			return commandadapter.Run(policy(&coauthors, &force), amendeventadapter.MapEventToEffect)
+		},
+		BashComplete: func(c *cli.Context) {
+			remainingAliases := aliascompletion.NewAliasShellCompletion(gitconfig.NewDataSource()).Complete(c.Args().Slice())
+			for _, alias := range remainingAliases {
+				fmt.Println(alias)
+			}
+		},
+	}
+}
+
+func policy(coauthors *[]string, force *bool) amend.Policy {
+	return amend.Policy{
+		Req: amend.Request{
+			AliasesAndCoauthors: coauthors,
+			Force:               force,
+		},
+		Deps: amend.Dependencies{
+			SanityCheckCoauthors: validation.SanityCheckCoauthors,
+			GitResolveAliases:    commandadapter.ResolveAliases,
+			ConfigReader:         configds.NewGitconfigDataSource(gitconfig.NewDataSource()),
+			StateReader:          state.NewGitConfigDataSource(gitconfig.NewDataSource()),
+			ActivationValidator:  activation.NewGitConfigDataSource(gitconfig.NewDataSource()),
+			RevList:              gitrepo.RevList,
+			CommitMessage:        gitrepo.CommitMessage,
+			UpdateRef:            gitrepo.UpdateRef,
+			AmendMessage:         gitrepo.AmendMessage,
+		},
+	}
+}
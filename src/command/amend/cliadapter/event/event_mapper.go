@@ -0,0 +1,35 @@
+package amendeventadapter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hekmekk/git-team/src/command/amend"
+	"github.com/hekmekk/git-team/src/core/events"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+// MapEventToEffect convert amend events to effects for the cli
+func MapEventToEffect(event events.Event) effects.Effect {
+	switch evt := event.(type) {
+	case amend.Succeeded:
+		return effects.NewExitOkMsg(fmt.Sprintf("Amended HEAD with co-authors: %s", strings.Join(evt.Coauthors, ", ")))
+	case amend.Unchanged:
+		return effects.NewExitOkMsg("HEAD contains the co-authors already")
+	case amend.Failed:
+		return effects.NewExitErrMsg(foldErrors(evt.Reason))
+	default:
+		return effects.NewExitOk()
+	}
+}
+
+func foldErrors(validationErrors []error) error {
+	var buffer bytes.Buffer
+	for _, err := range validationErrors {
+		buffer.WriteString(err.Error())
+		buffer.WriteString("; ")
+	}
+	return errors.New(strings.TrimRight(buffer.String(), "; "))
+}
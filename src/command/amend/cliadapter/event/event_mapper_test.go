@@ -0,0 +1,54 @@
+package amendeventadapter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hekmekk/git-team/src/command/amend"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+func TestMapEventToEffectSucceeded(t *testing.T) {
+	expectedEffect := effects.NewExitOkMsg("Amended HEAD with co-authors: A <a@x.y>, B <b@x.y>")
+
+	effect := MapEventToEffect(amend.Succeeded{Coauthors: []string{"A <a@x.y>", "B <b@x.y>"}})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectUnchanged(t *testing.T) {
+	expectedEffect := effects.NewExitOkMsg("HEAD contains the co-authors already")
+
+	effect := MapEventToEffect(amend.Unchanged{})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectFailed(t *testing.T) {
+	expectedEffect := effects.NewExitErrMsg(errors.New("failure_a; failure_b"))
+
+	effect := MapEventToEffect(amend.Failed{Reason: []error{errors.New("failure_a"), errors.New("failure_b")}})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectUnknownEvent(t *testing.T) {
+	expectedEffect := effects.NewExitOk()
+
+	effect := MapEventToEffect("UNKNOWN_EVENT")
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
@@ -0,0 +1,14 @@
+package amend
+
+// Succeeded the co-authors have been added to HEAD
+type Succeeded struct {
+	Coauthors []string
+}
+
+// Unchanged HEAD contains all of the co-authors already
+type Unchanged struct{}
+
+// Failed failed to add the co-authors to HEAD
+type Failed struct {
+	Reason []error
+}
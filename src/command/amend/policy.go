@@ -0,0 +1,108 @@
+package amend
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	utils "github.com/hekmekk/git-team/src/command/enable/utils"
+	"github.com/hekmekk/git-team/src/core/events"
+	activation "github.com/hekmekk/git-team/src/shared/activation/interface"
+	config "github.com/hekmekk/git-team/src/shared/config/interface"
+	"github.com/hekmekk/git-team/src/shared/role"
+	state "github.com/hekmekk/git-team/src/shared/state/interface"
+	"github.com/hekmekk/git-team/src/shared/trailer"
+)
+
+// Dependencies the dependencies of the amend Policy module
+type Dependencies struct {
+	SanityCheckCoauthors func([]string) []error
+	GitResolveAliases    func(aliases []string) ([]string, []error)
+	ConfigReader         config.Reader
+	StateReader          state.Reader
+	ActivationValidator  activation.Validator
+	RevList              func(args ...string) ([]string, error)
+	CommitMessage        func(rev string) (string, error)
+	UpdateRef            func(ref string, rev string) error
+	AmendMessage         func(message string) error
+}
+
+// Request the co-authors to add to HEAD, falling back to the active co-authors
+type Request struct {
+	AliasesAndCoauthors *[]string
+	Force               *bool
+}
+
+// Policy the policy to apply
+type Policy struct {
+	Deps Dependencies
+	Req  Request
+}
+
+// Apply add co-author trailers to the message of HEAD, merging them into its trailer block
+func (policy Policy) Apply() events.Event {
+	deps := policy.Deps
+	req := policy.Req
+
+	if !deps.ActivationValidator.IsInsideAGitRepository() {
+		return Failed{Reason: []error{errors.New("failed to amend HEAD: not inside a git repository")}}
+	}
+
+	cfg, err := deps.ConfigReader.Read()
+	if err != nil {
+		return Failed{Reason: []error{fmt.Errorf("failed to read config: %s", err)}}
+	}
+
+	coauthors, errs := utils.ResolveCoauthors(deps.SanityCheckCoauthors, deps.GitResolveAliases, *req.AliasesAndCoauthors)
+	if len(errs) > 0 {
+		return Failed{Reason: errs}
+	}
+
+	if len(coauthors) == 0 {
+		currState, err := deps.StateReader.Query(cfg.ActivationScope)
+		if err != nil {
+			return Failed{Reason: []error{fmt.Errorf("failed to query current state: %s", err)}}
+		}
+
+		coauthors = append([]string{}, currState.Coauthors...)
+		sort.Strings(coauthors)
+	}
+
+	if len(coauthors) == 0 {
+		return Failed{Reason: []error{errors.New("no co-authors given and none are active")}}
+	}
+
+	if !*req.Force {
+		unpublished, err := deps.RevList("--max-count=1", "HEAD", "--not", "--remotes")
+		if err != nil {
+			return Failed{Reason: []error{err}}
+		}
+
+		if len(unpublished) == 0 {
+			return Failed{Reason: []error{errors.New("HEAD is reachable from a remote-tracking branch, use --force to amend it anyway")}}
+		}
+	}
+
+	message, err := deps.CommitMessage("HEAD")
+	if err != nil {
+		return Failed{Reason: []error{err}}
+	}
+
+	format := cfg.Trailer()
+	amended := trailer.Inject(message, role.TrailerLines(coauthors, nil, format), role.TrailerPrefixes(format), trailer.NewCleanup("", "verbatim"))
+
+	if strings.TrimRight(amended, "\n") == strings.TrimRight(message, "\n") {
+		return Unchanged{}
+	}
+
+	if err := deps.UpdateRef("ORIG_HEAD", "HEAD"); err != nil {
+		return Failed{Reason: []error{err}}
+	}
+
+	if err := deps.AmendMessage(amended); err != nil {
+		return Failed{Reason: []error{err}}
+	}
+
+	return Succeeded{Coauthors: coauthors}
+}
@@ -0,0 +1,516 @@
+package amend
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	config "github.com/hekmekk/git-team/src/shared/config/entity/config"
+	state "github.com/hekmekk/git-team/src/shared/state/entity"
+)
+
+type configReaderMock struct {
+	read func() (config.Config, error)
+}
+
+func (mock configReaderMock) Read() (config.Config, error) {
+	return mock.read()
+}
+
+type stateReaderMock struct {
+	query func(activationscope.Scope) (state.State, error)
+}
+
+func (mock stateReaderMock) Query(scope activationscope.Scope) (state.State, error) {
+	return mock.query(scope)
+}
+
+type activationValidatorMock struct {
+	isInsideAGitRepository func() bool
+}
+
+func (mock activationValidatorMock) IsInsideAGitRepository() bool {
+	return mock.isInsideAGitRepository()
+}
+
+type repository struct {
+	message     string
+	unpublished []string
+	origHead    string
+	amended     bool
+}
+
+func TestAmendShouldAddTheGivenCoauthorsToTheTrailerBlock(t *testing.T) {
+	t.Parallel()
+
+	repo := &repository{message: "subject\n\nSigned-off-by: S <s@x.y>\n", unpublished: []string{"abc"}}
+
+	deps := Dependencies{
+		SanityCheckCoauthors: func([]string) []error { return []error{} },
+		GitResolveAliases: func(aliases []string) ([]string, []error) {
+			resolved := []string{}
+			for _, alias := range aliases {
+				resolved = append(resolved, fmt.Sprintf("%s <%s@x.y>", alias, alias))
+			}
+			return resolved, []error{}
+		},
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		StateReader: &stateReaderMock{
+			query: func(activationscope.Scope) (state.State, error) {
+				return state.NewStateDisabled(), nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevList: func(args ...string) ([]string, error) {
+			return repo.unpublished, nil
+		},
+		CommitMessage: func(rev string) (string, error) {
+			if rev != "HEAD" {
+				return "", fmt.Errorf("wrong rev: %s", rev)
+			}
+			return repo.message, nil
+		},
+		UpdateRef: func(ref string, rev string) error {
+			repo.origHead = ref + "=" + rev
+			return nil
+		},
+		AmendMessage: func(message string) error {
+			repo.message = message
+			repo.amended = true
+			return nil
+		},
+	}
+
+	aliasesAndCoauthors := []string{"b", "A <a@x.y>"}
+	force := false
+	req := Request{AliasesAndCoauthors: &aliasesAndCoauthors, Force: &force}
+
+	expectedEvent := Succeeded{Coauthors: []string{"A <a@x.y>", "b <b@x.y>"}}
+	expectedMessage := "subject\n\nSigned-off-by: S <s@x.y>\nCo-authored-by: A <a@x.y>\nCo-authored-by: b <b@x.y>\n"
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if expectedMessage != repo.message {
+		t.Errorf("expected: %q, got: %q", expectedMessage, repo.message)
+		t.Fail()
+	}
+
+	if repo.origHead != "ORIG_HEAD=HEAD" {
+		t.Errorf("expected ORIG_HEAD to be updated, got: %s", repo.origHead)
+		t.Fail()
+	}
+}
+
+func TestAmendShouldFallBackToTheActiveCoauthors(t *testing.T) {
+	t.Parallel()
+
+	repo := &repository{message: "subject\n", unpublished: []string{"abc"}}
+
+	deps := Dependencies{
+		SanityCheckCoauthors: func([]string) []error { return []error{} },
+		GitResolveAliases: func(aliases []string) ([]string, []error) {
+			resolved := []string{}
+			for _, alias := range aliases {
+				resolved = append(resolved, fmt.Sprintf("%s <%s@x.y>", alias, alias))
+			}
+			return resolved, []error{}
+		},
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		StateReader: &stateReaderMock{
+			query: func(activationscope.Scope) (state.State, error) {
+				return state.NewStateEnabled([]string{"B <b@x.y>", "A <a@x.y>"}), nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevList: func(args ...string) ([]string, error) {
+			return repo.unpublished, nil
+		},
+		CommitMessage: func(rev string) (string, error) {
+			if rev != "HEAD" {
+				return "", fmt.Errorf("wrong rev: %s", rev)
+			}
+			return repo.message, nil
+		},
+		UpdateRef: func(ref string, rev string) error {
+			repo.origHead = ref + "=" + rev
+			return nil
+		},
+		AmendMessage: func(message string) error {
+			repo.message = message
+			repo.amended = true
+			return nil
+		},
+	}
+
+	aliasesAndCoauthors := []string{}
+	force := false
+	req := Request{AliasesAndCoauthors: &aliasesAndCoauthors, Force: &force}
+
+	expectedEvent := Succeeded{Coauthors: []string{"A <a@x.y>", "B <b@x.y>"}}
+	expectedMessage := "subject\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\n"
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if expectedMessage != repo.message {
+		t.Errorf("expected: %q, got: %q", expectedMessage, repo.message)
+		t.Fail()
+	}
+}
+
+func TestAmendShouldNotRewriteACommitWhichContainsTheCoauthorsAlready(t *testing.T) {
+	t.Parallel()
+
+	repo := &repository{message: "subject\n\nCo-authored-by: A <A@x.y>\n", unpublished: []string{"abc"}}
+
+	deps := Dependencies{
+		SanityCheckCoauthors: func([]string) []error { return []error{} },
+		GitResolveAliases: func(aliases []string) ([]string, []error) {
+			resolved := []string{}
+			for _, alias := range aliases {
+				resolved = append(resolved, fmt.Sprintf("%s <%s@x.y>", alias, alias))
+			}
+			return resolved, []error{}
+		},
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		StateReader: &stateReaderMock{
+			query: func(activationscope.Scope) (state.State, error) {
+				return state.NewStateDisabled(), nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevList: func(args ...string) ([]string, error) {
+			return repo.unpublished, nil
+		},
+		CommitMessage: func(rev string) (string, error) {
+			if rev != "HEAD" {
+				return "", fmt.Errorf("wrong rev: %s", rev)
+			}
+			return repo.message, nil
+		},
+		UpdateRef: func(ref string, rev string) error {
+			repo.origHead = ref + "=" + rev
+			return nil
+		},
+		AmendMessage: func(message string) error {
+			repo.message = message
+			repo.amended = true
+			return nil
+		},
+	}
+
+	aliasesAndCoauthors := []string{"A <a@x.y>"}
+	force := false
+	req := Request{AliasesAndCoauthors: &aliasesAndCoauthors, Force: &force}
+
+	expectedEvent := Unchanged{}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if repo.amended {
+		t.Error("expected HEAD not to be amended")
+		t.Fail()
+	}
+}
+
+func TestAmendShouldRefuseToAmendAPublishedCommit(t *testing.T) {
+	t.Parallel()
+
+	repo := &repository{message: "subject\n", unpublished: []string{}}
+
+	deps := Dependencies{
+		SanityCheckCoauthors: func([]string) []error { return []error{} },
+		GitResolveAliases: func(aliases []string) ([]string, []error) {
+			resolved := []string{}
+			for _, alias := range aliases {
+				resolved = append(resolved, fmt.Sprintf("%s <%s@x.y>", alias, alias))
+			}
+			return resolved, []error{}
+		},
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		StateReader: &stateReaderMock{
+			query: func(activationscope.Scope) (state.State, error) {
+				return state.NewStateDisabled(), nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevList: func(args ...string) ([]string, error) {
+			return repo.unpublished, nil
+		},
+		CommitMessage: func(rev string) (string, error) {
+			if rev != "HEAD" {
+				return "", fmt.Errorf("wrong rev: %s", rev)
+			}
+			return repo.message, nil
+		},
+		UpdateRef: func(ref string, rev string) error {
+			repo.origHead = ref + "=" + rev
+			return nil
+		},
+		AmendMessage: func(message string) error {
+			repo.message = message
+			repo.amended = true
+			return nil
+		},
+	}
+
+	aliasesAndCoauthors := []string{"A <a@x.y>"}
+	force := false
+	req := Request{AliasesAndCoauthors: &aliasesAndCoauthors, Force: &force}
+
+	expectedEvent := Failed{Reason: []error{errors.New("HEAD is reachable from a remote-tracking branch, use --force to amend it anyway")}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if repo.amended {
+		t.Error("expected HEAD not to be amended")
+		t.Fail()
+	}
+}
+
+func TestAmendShouldAmendAPublishedCommitWhenForced(t *testing.T) {
+	t.Parallel()
+
+	repo := &repository{message: "subject\n", unpublished: []string{}}
+
+	deps := Dependencies{
+		SanityCheckCoauthors: func([]string) []error { return []error{} },
+		GitResolveAliases: func(aliases []string) ([]string, []error) {
+			resolved := []string{}
+			for _, alias := range aliases {
+				resolved = append(resolved, fmt.Sprintf("%s <%s@x.y>", alias, alias))
+			}
+			return resolved, []error{}
+		},
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		StateReader: &stateReaderMock{
+			query: func(activationscope.Scope) (state.State, error) {
+				return state.NewStateDisabled(), nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevList: func(args ...string) ([]string, error) {
+			return repo.unpublished, nil
+		},
+		CommitMessage: func(rev string) (string, error) {
+			if rev != "HEAD" {
+				return "", fmt.Errorf("wrong rev: %s", rev)
+			}
+			return repo.message, nil
+		},
+		UpdateRef: func(ref string, rev string) error {
+			repo.origHead = ref + "=" + rev
+			return nil
+		},
+		AmendMessage: func(message string) error {
+			repo.message = message
+			repo.amended = true
+			return nil
+		},
+	}
+
+	aliasesAndCoauthors := []string{"A <a@x.y>"}
+	force := true
+	req := Request{AliasesAndCoauthors: &aliasesAndCoauthors, Force: &force}
+
+	expectedEvent := Succeeded{Coauthors: []string{"A <a@x.y>"}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestAmendShouldFailWithoutCoauthors(t *testing.T) {
+	t.Parallel()
+
+	repo := &repository{message: "subject\n", unpublished: []string{"abc"}}
+
+	deps := Dependencies{
+		SanityCheckCoauthors: func([]string) []error { return []error{} },
+		GitResolveAliases: func(aliases []string) ([]string, []error) {
+			resolved := []string{}
+			for _, alias := range aliases {
+				resolved = append(resolved, fmt.Sprintf("%s <%s@x.y>", alias, alias))
+			}
+			return resolved, []error{}
+		},
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		StateReader: &stateReaderMock{
+			query: func(activationscope.Scope) (state.State, error) {
+				return state.NewStateDisabled(), nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevList: func(args ...string) ([]string, error) {
+			return repo.unpublished, nil
+		},
+		CommitMessage: func(rev string) (string, error) {
+			if rev != "HEAD" {
+				return "", fmt.Errorf("wrong rev: %s", rev)
+			}
+			return repo.message, nil
+		},
+		UpdateRef: func(ref string, rev string) error {
+			repo.origHead = ref + "=" + rev
+			return nil
+		},
+		AmendMessage: func(message string) error {
+			repo.message = message
+			repo.amended = true
+			return nil
+		},
+	}
+
+	aliasesAndCoauthors := []string{}
+	force := false
+	req := Request{AliasesAndCoauthors: &aliasesAndCoauthors, Force: &force}
+
+	expectedEvent := Failed{Reason: []error{errors.New("no co-authors given and none are active")}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestAmendShouldFailWhenResolvingAnAliasFails(t *testing.T) {
+	t.Parallel()
+
+	repo := &repository{message: "subject\n", unpublished: []string{"abc"}}
+
+	deps := Dependencies{
+		SanityCheckCoauthors: func([]string) []error { return []error{} },
+		GitResolveAliases: func(aliases []string) ([]string, []error) {
+			return nil, []error{errors.New("failed to resolve alias team.alias.x")}
+		},
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		StateReader: &stateReaderMock{
+			query: func(activationscope.Scope) (state.State, error) {
+				return state.NewStateDisabled(), nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevList: func(args ...string) ([]string, error) {
+			return repo.unpublished, nil
+		},
+		CommitMessage: func(rev string) (string, error) {
+			if rev != "HEAD" {
+				return "", fmt.Errorf("wrong rev: %s", rev)
+			}
+			return repo.message, nil
+		},
+		UpdateRef: func(ref string, rev string) error {
+			repo.origHead = ref + "=" + rev
+			return nil
+		},
+		AmendMessage: func(message string) error {
+			repo.message = message
+			repo.amended = true
+			return nil
+		},
+	}
+
+	aliasesAndCoauthors := []string{"x"}
+	force := false
+	req := Request{AliasesAndCoauthors: &aliasesAndCoauthors, Force: &force}
+
+	expectedEvent := Failed{Reason: []error{errors.New("failed to resolve alias team.alias.x")}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestAmendShouldFailOutsideOfAGitRepository(t *testing.T) {
+	t.Parallel()
+
+	deps := Dependencies{
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return false },
+		},
+	}
+
+	aliasesAndCoauthors := []string{"A <a@x.y>"}
+	force := false
+	req := Request{AliasesAndCoauthors: &aliasesAndCoauthors, Force: &force}
+
+	expectedEvent := Failed{Reason: []error{errors.New("failed to amend HEAD: not inside a git repository")}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
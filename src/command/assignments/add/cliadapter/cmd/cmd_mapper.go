@@ -14,7 +14,9 @@ import (
 	"github.com/hekmekk/git-team/src/core/validation"
 	commandadapter "github.com/hekmekk/git-team/src/shared/cli/commandadapter"
 	"github.com/hekmekk/git-team/src/shared/cli/effects"
-	gitconfiglegacy "github.com/hekmekk/git-team/src/shared/gitconfig/impl/legacy"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/impl"
+	gitconfiginterface "github.com/hekmekk/git-team/src/shared/gitconfig/interface"
 	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
 )
 
@@ -27,13 +29,15 @@ func Command() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.BoolFlag{Name: "force-override", Value: false, Aliases: []string{"f"}, Usage: "Override an existing assignment"},
 			&cli.BoolFlag{Name: "keep-existing", Value: false, Aliases: []string{"k"}, Usage: "Keep existing assignment"},
+			dryrun.Flag(),
 		},
 		Action: func(c *cli.Context) error {
 			forceOverride := c.Bool("force-override")
 			keepExisting := c.Bool("keep-existing")
+			dryRun := dryrun.IsRequested(c)
 
 			if c.NArg() == 0 {
-				return handleInputFromStdin(forceOverride, keepExisting).Run()
+				return handleInputFromStdin(forceOverride, keepExisting, dryRun).Run()
 			}
 
 			if c.NArg() != 2 {
@@ -43,12 +47,12 @@ func Command() *cli.Command {
 			args := c.Args()
 			alias := args.First()
 			coauthor := args.Get(1)
-			return commandadapter.Run(policy(&alias, &coauthor, &forceOverride, &keepExisting), addeventadapter.MapEventToEffect)
+			return applyPolicy(&alias, &coauthor, &forceOverride, &keepExisting, dryRun).Run()
 		},
 	}
 }
 
-func handleInputFromStdin(forceOverride bool, keepExisting bool) effects.Effect {
+func handleInputFromStdin(forceOverride bool, keepExisting bool, dryRun bool) effects.Effect {
 	lines, err := readLinesFromStdin()
 
 	if err != nil {
@@ -66,7 +70,7 @@ func handleInputFromStdin(forceOverride bool, keepExisting bool) effects.Effect
 
 			alias := argsFromStdin[0]
 			coauthor := argsFromStdin[1]
-			effect = commandadapter.ApplyPolicy(policy(&alias, &coauthor, &forceOverride, &keepExisting), addeventadapter.MapEventToEffect)
+			effect = applyPolicy(&alias, &coauthor, &forceOverride, &keepExisting, dryRun)
 		}
 		err := effect.Run()
 		if err != nil {
@@ -81,6 +85,15 @@ func handleInputFromStdin(forceOverride bool, keepExisting bool) effects.Effect
 	return effects.NewExitOk()
 }
 
+func applyPolicy(alias *string, coauthor *string, forceOverride *bool, keepExisting *bool, dryRun bool) effects.Effect {
+	if dryRun {
+		recorder := dryrun.NewRecorder()
+		return commandadapter.ApplyPolicy(policy(alias, coauthor, forceOverride, keepExisting, dryrun.NewGitConfigWriter(recorder, os.Getenv("HOME"))), addeventadapter.MapDryRunEventToEffectFactory(recorder))
+	}
+
+	return commandadapter.ApplyPolicy(policy(alias, coauthor, forceOverride, keepExisting, gitconfig.NewDataSink()), addeventadapter.MapEventToEffect)
+}
+
 func readLinesFromStdin() ([]string, error) {
 	s := bufio.NewScanner(os.Stdin)
 	lines := []string{}
@@ -96,7 +109,7 @@ func readLinesFromStdin() ([]string, error) {
 	return lines, nil
 }
 
-func policy(alias *string, coauthor *string, forceOverride *bool, keepExisting *bool) add.Policy {
+func policy(alias *string, coauthor *string, forceOverride *bool, keepExisting *bool, gitConfigWriter gitconfiginterface.Writer) add.Policy {
 	return add.Policy{
 		Req: add.AssignmentRequest{
 			Alias:         alias,
@@ -108,7 +121,7 @@ func policy(alias *string, coauthor *string, forceOverride *bool, keepExisting *
 			SanityCheckCoauthor: validation.SanityCheckCoauthor,
 			GitResolveAlias:     commandadapter.ResolveAlias,
 			GitAddAlias: func(alias string, coauthor string) error {
-				return gitconfiglegacy.ReplaceAll(gitconfigscope.Global, fmt.Sprintf("team.alias.%s", alias), coauthor)
+				return gitConfigWriter.ReplaceAll(gitconfigscope.Global, fmt.Sprintf("team.alias.%s", alias), coauthor)
 			},
 			GetAnswerFromUser: func(question string) (string, error) {
 				_, err := os.Stdout.WriteString(question)
@@ -8,6 +8,7 @@ import (
 	"github.com/hekmekk/git-team/src/command/assignments/add"
 	"github.com/hekmekk/git-team/src/core/events"
 	"github.com/hekmekk/git-team/src/shared/cli/effects"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
 )
 
 // MapEventToEffect convert assignment events to effects for the cli
@@ -23,3 +24,15 @@ func MapEventToEffect(event events.Event) effects.Effect {
 		return effects.NewExitOk()
 	}
 }
+
+// MapDryRunEventToEffectFactory convert assignment events to effects for the cli when running with --dry-run
+func MapDryRunEventToEffectFactory(recorder dryrun.Recorder) func(events.Event) effects.Effect {
+	return func(event events.Event) effects.Effect {
+		switch event.(type) {
+		case add.AssignmentSucceeded, add.AssignmentAborted:
+			return dryrun.MapOperationsToEffect(recorder.Operations())
+		default:
+			return MapEventToEffect(event)
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/hekmekk/git-team/src/command/assignments/add"
 	"github.com/hekmekk/git-team/src/shared/cli/effects"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
+	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
 )
 
 func TestMapEventToEffectAssignmentSucceeded(t *testing.T) {
@@ -58,3 +60,30 @@ func TestMapEventToEffectUnknownEvent(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestMapDryRunEventToEffectAssignmentSucceeded(t *testing.T) {
+	recorder := dryrun.NewRecorder()
+	dryrun.NewGitConfigWriter(recorder, "/home/some-user").ReplaceAll(gitconfigscope.Global, "team.alias.mr", "mr")
+
+	expectedEffect := dryrun.MapOperationsToEffect([]string{"git config --global --replace-all team.alias.mr mr"})
+
+	effect := MapDryRunEventToEffectFactory(recorder)(add.AssignmentSucceeded{Alias: "mr", Coauthor: "mr"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapDryRunEventToEffectAssignmentFailed(t *testing.T) {
+	err := errors.New("failure")
+
+	expectedEffect := effects.NewExitErrMsg(err)
+
+	effect := MapDryRunEventToEffectFactory(dryrun.NewRecorder())(add.AssignmentFailed{Reason: err})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
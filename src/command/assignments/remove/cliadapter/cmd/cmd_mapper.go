@@ -3,6 +3,7 @@ package removecmdadapter
 import (
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/urfave/cli/v2"
 
@@ -11,8 +12,9 @@ import (
 	commandadapter "github.com/hekmekk/git-team/src/shared/cli/commandadapter"
 	"github.com/hekmekk/git-team/src/shared/cli/effects"
 	aliascompletion "github.com/hekmekk/git-team/src/shared/completion"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
 	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/impl"
-	gitconfiglegacy "github.com/hekmekk/git-team/src/shared/gitconfig/impl/legacy"
+	gitconfiginterface "github.com/hekmekk/git-team/src/shared/gitconfig/interface"
 	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
 )
 
@@ -23,6 +25,9 @@ func Command() *cli.Command {
 		Aliases:   []string{"rm"},
 		Usage:     "Remove an alias to co-author assignment",
 		ArgsUsage: "<alias>",
+		Flags: []cli.Flag{
+			dryrun.Flag(),
+		},
 		Action: func(c *cli.Context) error {
 			args := c.Args()
 			if args.Len() != 1 {
@@ -30,7 +35,13 @@ func Command() *cli.Command {
 			}
 
 			alias := args.First()
-			return commandadapter.Run(policy(&alias), removeeventadapter.MapEventToEffect)
+
+			if dryrun.IsRequested(c) {
+				recorder := dryrun.NewRecorder()
+				return commandadapter.Run(policy(&alias, dryrun.NewGitConfigWriter(recorder, os.Getenv("HOME"))), removeeventadapter.MapDryRunEventToEffectFactory(recorder))
+			}
+
+			return commandadapter.Run(policy(&alias, gitconfig.NewDataSink()), removeeventadapter.MapEventToEffect)
 		},
 		BashComplete: func(c *cli.Context) {
 			args := c.Args()
@@ -46,14 +57,14 @@ func Command() *cli.Command {
 	}
 }
 
-func policy(alias *string) remove.Policy {
+func policy(alias *string, gitConfigWriter gitconfiginterface.Writer) remove.Policy {
 	return remove.Policy{
 		Req: remove.DeAllocationRequest{
 			Alias: alias,
 		},
 		Deps: remove.Dependencies{
 			GitRemoveAlias: func(alias string) error {
-				return gitconfiglegacy.UnsetAll(gitconfigscope.Global, fmt.Sprintf("team.alias.%s", alias))
+				return gitConfigWriter.UnsetAll(gitconfigscope.Global, fmt.Sprintf("team.alias.%s", alias))
 			},
 		},
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/hekmekk/git-team/src/command/assignments/remove"
 	"github.com/hekmekk/git-team/src/core/events"
 	"github.com/hekmekk/git-team/src/shared/cli/effects"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
 )
 
 // MapEventToEffect convert deallocation events to effects for the cli
@@ -21,3 +22,15 @@ func MapEventToEffect(event events.Event) effects.Effect {
 		return effects.NewExitOk()
 	}
 }
+
+// MapDryRunEventToEffectFactory convert deallocation events to effects for the cli when running with --dry-run
+func MapDryRunEventToEffectFactory(recorder dryrun.Recorder) func(events.Event) effects.Effect {
+	return func(event events.Event) effects.Effect {
+		switch event.(type) {
+		case remove.DeAllocationSucceeded:
+			return dryrun.MapOperationsToEffect(recorder.Operations())
+		default:
+			return MapEventToEffect(event)
+		}
+	}
+}
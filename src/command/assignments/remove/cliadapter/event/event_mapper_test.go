@@ -8,6 +8,8 @@ import (
 
 	"github.com/hekmekk/git-team/src/command/assignments/remove"
 	"github.com/hekmekk/git-team/src/shared/cli/effects"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
+	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
 )
 
 func TestMapEventToEffectDeAllocationSucceeded(t *testing.T) {
@@ -47,3 +49,30 @@ func TestMapEventToEffectUnknownEvent(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestMapDryRunEventToEffectDeAllocationSucceeded(t *testing.T) {
+	recorder := dryrun.NewRecorder()
+	dryrun.NewGitConfigWriter(recorder, "/home/some-user").UnsetAll(gitconfigscope.Global, "team.alias.mr")
+
+	expectedEffect := dryrun.MapOperationsToEffect([]string{"git config --global --unset-all team.alias.mr"})
+
+	effect := MapDryRunEventToEffectFactory(recorder)(remove.DeAllocationSucceeded{Alias: "mr"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapDryRunEventToEffectDeAllocationFailed(t *testing.T) {
+	err := errors.New("failure")
+
+	expectedEffect := effects.NewExitErrMsg(err)
+
+	effect := MapDryRunEventToEffectFactory(dryrun.NewRecorder())(remove.DeAllocationFailed{Reason: err})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
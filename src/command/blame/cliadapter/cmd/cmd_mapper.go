@@ -0,0 +1,53 @@
+package blamecmdadapter
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/hekmekk/git-team/src/command/blame"
+	blameeventadapter "github.com/hekmekk/git-team/src/command/blame/cliadapter/event"
+	activation "github.com/hekmekk/git-team/src/shared/activation/impl"
+	commandadapter "github.com/hekmekk/git-team/src/shared/cli/commandadapter"
+	configds "github.com/hekmekk/git-team/src/shared/config/datasource"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/impl"
+	"github.com/hekmekk/git-team/src/shared/gitrepo"
+	"github.com/hekmekk/git-team/src/shared/history"
+)
+
+// Command the blame command
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:      "blame",
+		Usage:     "Show the author and the co-authors of the commit which last changed each line of a file",
+		ArgsUsage: "[<rev>] <file>",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "summary", Aliases: []string{"s"}, Usage: "Summarise the number of lines each person has written instead"},
+		},
+		Action: func(c *cli.Context) error {
+			revision := ""
+			file := c.Args().First()
+			if c.NArg() > 1 {
+				revision = c.Args().Get(0)
+				file = c.Args().Get(1)
+			}
+			summary := c.Bool("summary")
+			return commandadapter.Run(policy(&revision, &file, &summary), blameeventadapter.MapEventToEffect)
+		},
+	}
+}
+
+func policy(revision *string, file *string, summary *bool) blame.Policy {
+	return blame.Policy{
+		Req: blame.Request{
+			Revision: revision,
+			File:     file,
+			Summary:  summary,
+		},
+		Deps: blame.Dependencies{
+			ConfigReader:        configds.NewGitconfigDataSource(gitconfig.NewDataSource()),
+			GitConfigReader:     gitconfig.NewDataSource(),
+			ActivationValidator: activation.NewGitConfigDataSource(gitconfig.NewDataSource()),
+			Blame:               gitrepo.Blame,
+			Log:                 history.Log,
+		},
+	}
+}
@@ -0,0 +1,63 @@
+package blameeventadapter
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hekmekk/git-team/src/command/blame"
+	"github.com/hekmekk/git-team/src/core/events"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+const shortCommitLength = 8
+
+// MapEventToEffect convert blame events to effects for the cli
+func MapEventToEffect(event events.Event) effects.Effect {
+	switch evt := event.(type) {
+	case blame.Succeeded:
+		if len(evt.Lines) == 0 {
+			return effects.NewExitOk()
+		}
+		if evt.Ownership != nil {
+			return effects.NewExitOkMsg(toSummary(evt.Ownership, len(evt.Lines)))
+		}
+		return effects.NewExitOkMsg(toLines(evt.Lines))
+	case blame.Failed:
+		return effects.NewExitErrMsg(evt.Reason)
+	default:
+		return effects.NewExitOk()
+	}
+}
+
+// toLines render the lines like git blame does, naming all the people who wrote a line
+func toLines(lines []blame.Line) string {
+	peopleWidth := 0
+	for _, line := range lines {
+		if width := len(strings.Join(line.People, ", ")); width > peopleWidth {
+			peopleWidth = width
+		}
+	}
+	numberWidth := len(strconv.Itoa(lines[len(lines)-1].Number))
+
+	var buffer bytes.Buffer
+	for _, line := range lines {
+		commit := line.Commit
+		if len(commit) > shortCommitLength {
+			commit = commit[:shortCommitLength]
+		}
+		fmt.Fprintf(&buffer, "%s (%-*s %s %*d) %s\n", commit, peopleWidth, strings.Join(line.People, ", "), line.Date.Format("2006-01-02"), numberWidth, line.Number, line.Content)
+	}
+
+	return strings.TrimRight(buffer.String(), "\n")
+}
+
+// toSummary render the number of lines of each person along with their share of the file
+func toSummary(ownership []blame.Ownership, total int) string {
+	var buffer bytes.Buffer
+	for _, owner := range ownership {
+		fmt.Fprintf(&buffer, "%6d %5.1f%%  %s\n", owner.Lines, 100*float64(owner.Lines)/float64(total), owner.Person)
+	}
+	return strings.TrimRight(buffer.String(), "\n")
+}
@@ -0,0 +1,66 @@
+package blameeventadapter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hekmekk/git-team/src/command/blame"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+var lines = []blame.Line{
+	{Commit: "1111111111111111111111111111111111111111", People: []string{"Me", "a"}, Date: time.Date(2021, 3, 4, 12, 0, 0, 0, time.Local), Number: 9, Content: "one"},
+	{Commit: "2222222222222222222222222222222222222222", People: []string{"B"}, Date: time.Date(2021, 3, 5, 12, 0, 0, 0, time.Local), Number: 10, Content: "\ttwo"},
+}
+
+func TestMapEventToEffectSucceeded(t *testing.T) {
+	msg := "11111111 (Me, a 2021-03-04  9) one\n22222222 (B     2021-03-05 10) \ttwo"
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffect(blame.Succeeded{Lines: lines})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %q, got: %q", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectSucceededWithSummary(t *testing.T) {
+	msg := "     2 100.0%  a\n     1  50.0%  B"
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffect(blame.Succeeded{Lines: lines, Ownership: []blame.Ownership{{Person: "a", Lines: 2}, {Person: "B", Lines: 1}}})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %q, got: %q", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectSucceededForAnEmptyFile(t *testing.T) {
+	expectedEffect := effects.NewExitOk()
+
+	effect := MapEventToEffect(blame.Succeeded{Lines: []blame.Line{}})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectFailed(t *testing.T) {
+	err := errors.New("failure")
+
+	expectedEffect := effects.NewExitErrMsg(err)
+
+	effect := MapEventToEffect(blame.Failed{Reason: err})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
@@ -0,0 +1,29 @@
+package blame
+
+import "time"
+
+// Line a line of the file along with the people who wrote it
+type Line struct {
+	Commit  string
+	People  []string
+	Date    time.Time
+	Number  int
+	Content string
+}
+
+// Ownership the number of lines a person has written as author or co-author
+type Ownership struct {
+	Person string
+	Lines  int
+}
+
+// Succeeded the file has been blamed, the ownership is only given if a summary has been requested
+type Succeeded struct {
+	Lines     []Line
+	Ownership []Ownership
+}
+
+// Failed failed to blame the file
+type Failed struct {
+	Reason error
+}
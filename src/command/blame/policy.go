@@ -0,0 +1,155 @@
+package blame
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hekmekk/git-team/src/core/events"
+	activation "github.com/hekmekk/git-team/src/shared/activation/interface"
+	config "github.com/hekmekk/git-team/src/shared/config/interface"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/interface"
+	"github.com/hekmekk/git-team/src/shared/history"
+	"github.com/hekmekk/git-team/src/shared/roster"
+)
+
+// Dependencies the dependencies of the blame Policy module
+type Dependencies struct {
+	ConfigReader        config.Reader
+	GitConfigReader     gitconfig.Reader
+	ActivationValidator activation.Validator
+	Blame               func(args ...string) (string, error)
+	Log                 func(args ...string) ([]history.Commit, error)
+}
+
+// Request the file to blame, optionally at a given revision
+type Request struct {
+	Revision *string
+	File     *string
+	Summary  *bool
+}
+
+// Policy the policy to apply
+type Policy struct {
+	Deps Dependencies
+	Req  Request
+}
+
+// Apply attribute each line of the file to the author and the co-authors of the commit which last changed it
+func (policy Policy) Apply() events.Event {
+	deps := policy.Deps
+	req := policy.Req
+
+	if *req.File == "" {
+		return Failed{Reason: errors.New("the file to blame is missing")}
+	}
+
+	if !deps.ActivationValidator.IsInsideAGitRepository() {
+		return Failed{Reason: errors.New("failed to blame: not inside a git repository")}
+	}
+
+	cfg, err := deps.ConfigReader.Read()
+	if err != nil {
+		return Failed{Reason: fmt.Errorf("failed to read config: %s", err)}
+	}
+
+	knownPeople, err := roster.Load(deps.GitConfigReader)
+	if err != nil {
+		return Failed{Reason: fmt.Errorf("failed to retrieve assignments: %s", err)}
+	}
+
+	blameArgs := []string{}
+	if *req.Revision != "" {
+		blameArgs = append(blameArgs, *req.Revision)
+	}
+
+	out, err := deps.Blame(append(blameArgs, "--", *req.File)...)
+	if err != nil {
+		return Failed{Reason: err}
+	}
+
+	blamedLines := parsePorcelain(out)
+
+	// the co-authors are looked up once per commit, as a commit usually accounts for many lines
+	commits := []string{}
+	seen := map[string]bool{}
+	for _, line := range blamedLines {
+		if isCommitted(line.commit) && !seen[line.commit] {
+			seen[line.commit] = true
+			commits = append(commits, line.commit)
+		}
+	}
+
+	peopleByCommit := map[string][]string{}
+	if len(commits) > 0 {
+		logEntries, err := deps.Log(append([]string{"--no-walk=unsorted"}, commits...)...)
+		if err != nil {
+			return Failed{Reason: err}
+		}
+
+		for _, commit := range logEntries {
+			peopleByCommit[commit.Hash] = commit.People(cfg.Trailer())
+		}
+	}
+
+	lines := []Line{}
+	for _, blamedLine := range blamedLines {
+		people, ok := peopleByCommit[blamedLine.commit]
+		if !ok {
+			people = []string{blamedLine.author}
+		}
+
+		names := []string{}
+		for _, person := range people {
+			names = append(names, shortName(knownPeople, person))
+		}
+
+		lines = append(lines, Line{Commit: blamedLine.commit, People: names, Date: blamedLine.date, Number: blamedLine.number, Content: blamedLine.content})
+	}
+
+	if !*req.Summary {
+		return Succeeded{Lines: lines}
+	}
+
+	return Succeeded{Lines: lines, Ownership: ownership(lines)}
+}
+
+// shortName the alias of a person if there is one, the name otherwise
+func shortName(knownPeople roster.Roster, identity string) string {
+	if alias, ok := knownPeople.Alias(identity); ok {
+		return alias
+	}
+
+	if start := strings.LastIndex(identity, "<"); start > 0 {
+		return strings.TrimSpace(identity[:start])
+	}
+
+	return identity
+}
+
+// ownership the number of lines each person has written, ordered by the number of lines
+func ownership(lines []Line) []Ownership {
+	index := map[string]int{}
+	owners := []Ownership{}
+	for _, line := range lines {
+		for _, person := range line.People {
+			i, exists := index[person]
+			if !exists {
+				i = len(owners)
+				index[person] = i
+				owners = append(owners, Ownership{Person: person})
+			}
+			owners[i].Lines++
+		}
+	}
+
+	sort.SliceStable(owners, func(i, j int) bool {
+		if owners[i].Lines != owners[j].Lines {
+			return owners[i].Lines > owners[j].Lines
+		}
+		return owners[i].Person < owners[j].Person
+	})
+
+	return owners
+}
@@ -0,0 +1,290 @@
+package blame
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	config "github.com/hekmekk/git-team/src/shared/config/entity/config"
+	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+	"github.com/hekmekk/git-team/src/shared/history"
+)
+
+type configReaderMock struct {
+	read func() (config.Config, error)
+}
+
+func (mock configReaderMock) Read() (config.Config, error) {
+	return mock.read()
+}
+
+type gitConfigReaderMock struct {
+	getRegexp func(gitconfigscope.Scope, string) (map[string]string, error)
+}
+
+func (mock gitConfigReaderMock) Get(scope gitconfigscope.Scope, key string) (string, error) {
+	return "", nil
+}
+
+func (mock gitConfigReaderMock) GetAll(scope gitconfigscope.Scope, key string) ([]string, error) {
+	return []string{}, nil
+}
+
+func (mock gitConfigReaderMock) GetRegexp(scope gitconfigscope.Scope, pattern string) (map[string]string, error) {
+	return mock.getRegexp(scope, pattern)
+}
+
+func (mock gitConfigReaderMock) List(scope gitconfigscope.Scope) (map[string]string, error) {
+	return nil, nil
+}
+
+type activationValidatorMock struct {
+	isInsideAGitRepository func() bool
+}
+
+func (mock activationValidatorMock) IsInsideAGitRepository() bool {
+	return mock.isInsideAGitRepository()
+}
+
+const (
+	first  = "1111111111111111111111111111111111111111"
+	second = "2222222222222222222222222222222222222222"
+	none   = "0000000000000000000000000000000000000000"
+)
+
+var porcelain = first + ` 1 1 2
+author Me
+author-mail <me@x.y>
+author-time 86400
+author-tz +0000
+summary one
+filename f
+	one
+` + first + ` 2 2
+	two
+` + second + ` 3 3 1
+author B
+author-mail <b@x.y>
+author-time 172800
+author-tz +0000
+summary three
+previous ` + first + ` f
+filename f
+	three
+` + none + ` 4 4 1
+author Not Committed Yet
+author-mail <not.committed.yet>
+author-time 259200
+author-tz +0000
+summary Version of f from f
+filename f
+	four
+`
+
+var expectedLines = []Line{
+	{Commit: first, People: []string{"Me", "a"}, Date: time.Unix(86400, 0), Number: 1, Content: "one"},
+	{Commit: first, People: []string{"Me", "a"}, Date: time.Unix(86400, 0), Number: 2, Content: "two"},
+	{Commit: second, People: []string{"B", "C", "a"}, Date: time.Unix(172800, 0), Number: 3, Content: "three"},
+	{Commit: none, People: []string{"Not Committed Yet"}, Date: time.Unix(259200, 0), Number: 4, Content: "four"},
+}
+
+func TestBlameShouldAttributeEachLineToTheAuthorAndTheCoauthors(t *testing.T) {
+	commits := []history.Commit{
+		{Hash: first, Author: "Me <me@x.y>", Message: "one\n\nCo-authored-by: A <A@x.y>\n"},
+		{Hash: second, Author: "B <b@x.y>", Message: "three\n\nCo-authored-by: C <c@x.y>\nCo-authored-by: A <a@x.y>\n"},
+	}
+
+	var blameArgs []string
+	var logArgs []string
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Blame: func(args ...string) (string, error) {
+			blameArgs = args
+			return porcelain, nil
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			logArgs = args
+			return commits, nil
+		},
+	}
+
+	revision := ""
+	file := "f"
+	summary := false
+	req := Request{Revision: &revision, File: &file, Summary: &summary}
+
+	expectedEvent := Succeeded{Lines: expectedLines}
+	expectedBlameArgs := []string{"--", "f"}
+	expectedLogArgs := []string{"--no-walk=unsorted", first, second}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedBlameArgs, blameArgs) {
+		t.Errorf("expected: %s, got: %s", expectedBlameArgs, blameArgs)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedLogArgs, logArgs) {
+		t.Errorf("expected: %s, got: %s", expectedLogArgs, logArgs)
+		t.Fail()
+	}
+}
+
+func TestBlameShouldSummariseTheOwnership(t *testing.T) {
+	commits := []history.Commit{
+		{Hash: first, Author: "Me <me@x.y>", Message: "one\n\nCo-authored-by: A <A@x.y>\n"},
+		{Hash: second, Author: "B <b@x.y>", Message: "three\n\nCo-authored-by: C <c@x.y>\nCo-authored-by: A <a@x.y>\n"},
+	}
+
+	var blameArgs []string
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Blame: func(args ...string) (string, error) {
+			blameArgs = args
+			return porcelain, nil
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return commits, nil
+		},
+	}
+
+	revision := "v1.0.0"
+	file := "f"
+	summary := true
+	req := Request{Revision: &revision, File: &file, Summary: &summary}
+
+	expectedEvent := Succeeded{
+		Lines: expectedLines,
+		Ownership: []Ownership{
+			{Person: "a", Lines: 3},
+			{Person: "Me", Lines: 2},
+			{Person: "B", Lines: 1},
+			{Person: "C", Lines: 1},
+			{Person: "Not Committed Yet", Lines: 1},
+		},
+	}
+	expectedBlameArgs := []string{"v1.0.0", "--", "f"}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedBlameArgs, blameArgs) {
+		t.Errorf("expected: %s, got: %s", expectedBlameArgs, blameArgs)
+		t.Fail()
+	}
+}
+
+func TestBlameShouldFailWithoutAFile(t *testing.T) {
+	deps := Dependencies{}
+
+	revision := ""
+	file := ""
+	summary := false
+	req := Request{Revision: &revision, File: &file, Summary: &summary}
+
+	expectedEvent := Failed{Reason: errors.New("the file to blame is missing")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestBlameShouldFailOutsideOfAGitRepository(t *testing.T) {
+	deps := Dependencies{
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return false },
+		},
+	}
+
+	revision := ""
+	file := "f"
+	summary := false
+	req := Request{Revision: &revision, File: &file, Summary: &summary}
+
+	expectedEvent := Failed{Reason: errors.New("failed to blame: not inside a git repository")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestBlameShouldFailWhenGitBlameFails(t *testing.T) {
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Blame: func(args ...string) (string, error) {
+			return "", errors.New("git blame failed: fatal: no such path 'f' in HEAD")
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return []history.Commit{}, nil
+		},
+	}
+
+	revision := ""
+	file := "f"
+	summary := false
+	req := Request{Revision: &revision, File: &file, Summary: &summary}
+
+	expectedEvent := Failed{Reason: errors.New("git blame failed: fatal: no such path 'f' in HEAD")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
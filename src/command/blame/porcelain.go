@@ -0,0 +1,60 @@
+package blame
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var commitHeaderPattern = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64}) [0-9]+ ([0-9]+)`)
+
+// blamedLine a line as reported by git blame --porcelain
+type blamedLine struct {
+	commit  string
+	author  string
+	date    time.Time
+	number  int
+	content string
+}
+
+// parsePorcelain parse the output of git blame --porcelain, in which the details of a commit are only given along with its first line
+func parsePorcelain(out string) []blamedLine {
+	authors := map[string]string{}
+	mails := map[string]string{}
+	dates := map[string]time.Time{}
+
+	lines := []blamedLine{}
+	commit := ""
+	number := 0
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "\t") {
+			author := strings.TrimSpace(authors[commit] + " " + mails[commit])
+			lines = append(lines, blamedLine{commit: commit, author: author, date: dates[commit], number: number, content: line[1:]})
+			continue
+		}
+
+		if match := commitHeaderPattern.FindStringSubmatch(line); match != nil {
+			commit = match[1]
+			number, _ = strconv.Atoi(match[2])
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "author "):
+			authors[commit] = strings.TrimPrefix(line, "author ")
+		case strings.HasPrefix(line, "author-mail "):
+			mails[commit] = strings.TrimPrefix(line, "author-mail ")
+		case strings.HasPrefix(line, "author-time "):
+			timestamp, _ := strconv.ParseInt(strings.TrimPrefix(line, "author-time "), 10, 64)
+			dates[commit] = time.Unix(timestamp, 0)
+		}
+	}
+
+	return lines
+}
+
+// isCommitted whether the line has been committed, uncommitted lines are attributed to a commit id consisting of zeros
+func isCommitted(commit string) bool {
+	return strings.Trim(commit, "0") != ""
+}
@@ -2,15 +2,22 @@ package configcmdadapter
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 
 	"github.com/urfave/cli/v2"
 
 	configeventadapter "github.com/hekmekk/git-team/src/command/config/cliadapter/event"
 	configpolicy "github.com/hekmekk/git-team/src/command/config/policy"
+	commitsettingsds "github.com/hekmekk/git-team/src/command/enable/commitsettings/datasource"
+	"github.com/hekmekk/git-team/src/command/enable/hookscript"
+	activation "github.com/hekmekk/git-team/src/shared/activation/impl"
 	commandadapter "github.com/hekmekk/git-team/src/shared/cli/commandadapter"
 	configdatasink "github.com/hekmekk/git-team/src/shared/config/datasink"
 	configdatasource "github.com/hekmekk/git-team/src/shared/config/datasource"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
 	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/impl"
+	gitconfiginterface "github.com/hekmekk/git-team/src/shared/gitconfig/interface"
 )
 
 // Command the config command
@@ -19,15 +26,31 @@ func Command() *cli.Command {
 		Name:      "config",
 		Usage:     "Edit configuration",
 		ArgsUsage: "<key> <value>",
+		Flags: []cli.Flag{
+			dryrun.Flag(),
+		},
 		Action: func(c *cli.Context) error {
 			args := c.Args()
 			key := args.First()
 			value := args.Get(1)
-			return commandadapter.Run(policy(&key, &value), configeventadapter.MapEventToEffect)
+
+			if dryrun.IsRequested(c) {
+				recorder := dryrun.NewRecorder()
+				fs := dryrun.NewFileSystem(recorder)
+				return commandadapter.Run(policy(&key, &value, dryrun.NewGitConfigWriter(recorder, os.Getenv("HOME")), hookscript.FileSystem{CreateDir: fs.MkdirAll, WriteFile: fs.WriteFile, Lstat: os.Lstat, Remove: fs.Remove, Symlink: fs.Symlink}), configeventadapter.MapDryRunEventToEffectFactory(recorder))
+			}
+
+			return commandadapter.Run(policy(&key, &value, gitconfig.NewDataSink(), hookscript.FileSystem{CreateDir: os.MkdirAll, WriteFile: ioutil.WriteFile, Lstat: os.Lstat, Remove: os.Remove, Symlink: os.Symlink}), configeventadapter.MapEventToEffect)
 		},
 		BashComplete: func(c *cli.Context) {
 			options := map[string][]string{
-				"activation-scope": []string{"repo-local", "global"},
+				"activation-scope":     []string{"repo-local", "global", "directory"},
+				"activation-directory": []string{},
+				"hooks-chain":          []string{"default"},
+				"inject-sources":       []string{"default", "message,merge,squash,commit"},
+				"require-coauthors":    []string{"true", "false"},
+				"trailer-key":          []string{"default", "Co-authored-by"},
+				"trailer-format":       []string{"default"},
 			}
 
 			args := c.Args()
@@ -49,15 +72,22 @@ func Command() *cli.Command {
 	}
 }
 
-func policy(key *string, value *string) configpolicy.Policy {
+func policy(key *string, value *string, gitConfigWriter gitconfiginterface.Writer, hooksFileSystem hookscript.FileSystem) configpolicy.Policy {
 	return configpolicy.Policy{
 		Req: configpolicy.Request{
 			Key:   key,
 			Value: value,
 		},
 		Deps: configpolicy.Dependencies{
-			ConfigReader: configdatasource.NewGitconfigDataSource(gitconfig.NewDataSource()),
-			ConfigWriter: configdatasink.NewGitconfigDataSink(gitconfig.NewDataSink()),
+			ConfigReader:         configdatasource.NewGitconfigDataSource(gitconfig.NewDataSource()),
+			ConfigWriter:         configdatasink.NewGitconfigDataSink(gitConfigWriter),
+			GitConfigReader:      gitconfig.NewDataSource(),
+			GitConfigWriter:      gitConfigWriter,
+			ActivationValidator:  activation.NewGitConfigDataSource(gitconfig.NewDataSource()),
+			CommitSettingsReader: commitsettingsds.NewStaticValueDataSource(),
+			InstallHooks: func(hooksDir string) error {
+				return hookscript.Install(hooksFileSystem, hooksDir)
+			},
 		},
 	}
 }
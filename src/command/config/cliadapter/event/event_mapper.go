@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/fatih/color"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/hekmekk/git-team/src/core/events"
 	"github.com/hekmekk/git-team/src/shared/cli/effects"
 	config "github.com/hekmekk/git-team/src/shared/config/entity/config"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
 )
 
 // MapEventToEffect convert config events to effects for the cli
@@ -32,9 +34,36 @@ func MapEventToEffect(event events.Event) effects.Effect {
 	}
 }
 
+// MapDryRunEventToEffectFactory convert config events to effects for the cli when running with --dry-run
+func MapDryRunEventToEffectFactory(recorder dryrun.Recorder) func(events.Event) effects.Effect {
+	return func(event events.Event) effects.Effect {
+		switch event.(type) {
+		case configevents.SettingModificationSucceeded:
+			return dryrun.MapOperationsToEffect(recorder.Operations())
+		default:
+			return MapEventToEffect(event)
+		}
+	}
+}
+
 func toString(cfg config.Config) string {
 	properties := make(map[string]string)
 	properties["activation-scope"] = cfg.ActivationScope.String()
+	if cfg.ActivationDirectory != "" {
+		properties["activation-directory"] = cfg.ActivationDirectory
+	}
+	if len(cfg.HooksChain) > 0 {
+		properties["hooks-chain"] = strings.Join(cfg.HooksChain, ":")
+	}
+	if len(cfg.InjectSources) > 0 {
+		properties["inject-sources"] = strings.Join(cfg.InjectSources, ",")
+	}
+	if cfg.TrailerKey != "" {
+		properties["trailer-key"] = cfg.TrailerKey
+	}
+	if cfg.TrailerFormat != "" {
+		properties["trailer-format"] = cfg.TrailerFormat
+	}
 
 	var propertyStrings []string
 
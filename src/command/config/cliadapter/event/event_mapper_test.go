@@ -10,6 +10,8 @@ import (
 	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
 	"github.com/hekmekk/git-team/src/shared/cli/effects"
 	config "github.com/hekmekk/git-team/src/shared/config/entity/config"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
+	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
 )
 
 func TestMapEventToEffectRetrievalSucceeded(t *testing.T) {
@@ -29,6 +31,75 @@ func TestMapEventToEffectRetrievalSucceeded(t *testing.T) {
 	}
 }
 
+func TestMapEventToEffectRetrievalSucceededWithActivationDirectory(t *testing.T) {
+	msg := "config\n─ activation-directory: ~/work/\n─ activation-scope: directory"
+	cfg := config.Config{
+		ActivationScope:     activationscope.Directory,
+		ActivationDirectory: "~/work/",
+	}
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffect(configevents.RetrievalSucceeded{Config: cfg})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectRetrievalSucceededWithHooksChain(t *testing.T) {
+	msg := "config\n─ activation-scope: global\n─ hooks-chain: .githooks:.git/hooks"
+	cfg := config.Config{
+		ActivationScope: activationscope.Global,
+		HooksChain:      []string{".githooks", ".git/hooks"},
+	}
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffect(configevents.RetrievalSucceeded{Config: cfg})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectRetrievalSucceededWithTrailerSettings(t *testing.T) {
+	msg := "config\n─ activation-scope: global\n─ trailer-format: {key} → {value}\n─ trailer-key: Pair"
+	cfg := config.Config{
+		ActivationScope: activationscope.Global,
+		TrailerKey:      "Pair",
+		TrailerFormat:   "{key} → {value}",
+	}
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffect(configevents.RetrievalSucceeded{Config: cfg})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectRetrievalSucceededWithInjectSources(t *testing.T) {
+	msg := "config\n─ activation-scope: global\n─ inject-sources: message,commit"
+	cfg := config.Config{
+		ActivationScope: activationscope.Global,
+		InjectSources:   []string{"message", "commit"},
+	}
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffect(configevents.RetrievalSucceeded{Config: cfg})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
 func TestMapEventToEffectRetrievalFailed(t *testing.T) {
 	err := errors.New("failed to retrieve config")
 
@@ -94,3 +165,30 @@ func TestMapEventToEffectUnknownEvent(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestMapDryRunEventToEffectSettingModificationSucceeded(t *testing.T) {
+	recorder := dryrun.NewRecorder()
+	dryrun.NewGitConfigWriter(recorder, "/home/some-user").ReplaceAll(gitconfigscope.Global, "team.config.activation-scope", "repo-local")
+
+	expectedEffect := dryrun.MapOperationsToEffect([]string{"git config --global --replace-all team.config.activation-scope repo-local"})
+
+	effect := MapDryRunEventToEffectFactory(recorder)(configevents.SettingModificationSucceeded{Key: "activation-scope", Value: "repo-local"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapDryRunEventToEffectSettingModificationFailed(t *testing.T) {
+	err := errors.New("failure")
+
+	expectedEffect := effects.NewExitErrMsg(err)
+
+	effect := MapDryRunEventToEffectFactory(dryrun.NewRecorder())(configevents.SettingModificationFailed{Reason: err})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
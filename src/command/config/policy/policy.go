@@ -1,12 +1,22 @@
 package policy
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	configevents "github.com/hekmekk/git-team/src/command/config/events"
+	commitsettings "github.com/hekmekk/git-team/src/command/enable/commitsettings/interface"
 	"github.com/hekmekk/git-team/src/core/events"
+	activation "github.com/hekmekk/git-team/src/shared/activation/interface"
 	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	configentity "github.com/hekmekk/git-team/src/shared/config/entity/config"
 	config "github.com/hekmekk/git-team/src/shared/config/interface"
+	"github.com/hekmekk/git-team/src/shared/displaced"
+	giterror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/interface"
+	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+	"github.com/hekmekk/git-team/src/shared/trailer"
 )
 
 // Request defines which config setting to modify or if the config should just be displayed
@@ -17,8 +27,13 @@ type Request struct {
 
 // Dependencies the dependencies of the config Policy module
 type Dependencies struct {
-	ConfigWriter config.Writer
-	ConfigReader config.Reader
+	ConfigWriter         config.Writer
+	ConfigReader         config.Reader
+	GitConfigReader      gitconfig.Reader
+	GitConfigWriter      gitconfig.Writer
+	ActivationValidator  activation.Validator
+	CommitSettingsReader commitsettings.Reader
+	InstallHooks         func(hooksDir string) error
 }
 
 // Policy the policy to apply
@@ -50,10 +65,27 @@ func (policy Policy) Apply() events.Event {
 	key := *keyPtr
 	value := *valuePtr
 
-	if key != "activation-scope" {
+	switch key {
+	case "activation-scope":
+		return setActivationScope(deps, value)
+	case "activation-directory":
+		return setActivationDirectory(deps, value)
+	case "hooks-chain":
+		return setHooksChain(deps, value)
+	case "inject-sources":
+		return setInjectSources(deps, value)
+	case "trailer-key":
+		return setTrailerKey(deps, value)
+	case "trailer-format":
+		return setTrailerFormat(deps, value)
+	case "require-coauthors":
+		return setRequireCoauthors(deps, value)
+	default:
 		return configevents.SettingModificationFailed{Reason: fmt.Errorf("unknown setting '%s'", key)}
 	}
+}
 
+func setActivationScope(deps Dependencies, value string) events.Event {
 	desiredScope := activationscope.FromString(value)
 	if desiredScope == activationscope.Unknown {
 		return configevents.SettingModificationFailed{Reason: fmt.Errorf("unknown activation-scope '%s'", value)}
@@ -63,5 +95,207 @@ func (policy Policy) Apply() events.Event {
 		return configevents.SettingModificationFailed{Reason: fmt.Errorf("failed to modify setting 'activation-scope': %s", err)}
 	}
 
-	return configevents.SettingModificationSucceeded{Key: key, Value: value}
+	return configevents.SettingModificationSucceeded{Key: "activation-scope", Value: value}
+}
+
+// the directory is used as a gitdir prefix within an includeIf section, hence the trailing slash
+func setActivationDirectory(deps Dependencies, value string) events.Event {
+	if !strings.HasPrefix(value, "/") && !strings.HasPrefix(value, "~/") {
+		return configevents.SettingModificationFailed{Reason: fmt.Errorf("activation-directory must either be absolute or start with '~/': '%s'", value)}
+	}
+
+	directory := value
+	if !strings.HasSuffix(directory, "/") {
+		directory = directory + "/"
+	}
+
+	if err := deps.ConfigWriter.SetActivationDirectory(directory); err != nil {
+		return configevents.SettingModificationFailed{Reason: fmt.Errorf("failed to modify setting 'activation-directory': %s", err)}
+	}
+
+	return configevents.SettingModificationSucceeded{Key: "activation-directory", Value: directory}
+}
+
+// the hooks directories are provided in order and separated by ':' just like $PATH, "default" resets the setting
+func setHooksChain(deps Dependencies, value string) events.Event {
+	hooksDirs := []string{}
+	if value != "default" {
+		for _, hooksDir := range strings.Split(value, ":") {
+			if hooksDir != "" {
+				hooksDirs = append(hooksDirs, hooksDir)
+			}
+		}
+	}
+
+	if err := deps.ConfigWriter.SetHooksChain(hooksDirs); err != nil {
+		return configevents.SettingModificationFailed{Reason: fmt.Errorf("failed to modify setting 'hooks-chain': %s", err)}
+	}
+
+	return configevents.SettingModificationSucceeded{Key: "hooks-chain", Value: value}
+}
+
+// the sources are separated by ',', "default" resets the setting to "message,merge,squash"
+func setInjectSources(deps Dependencies, value string) events.Event {
+	sources := []string{}
+	if value != "default" {
+		for _, source := range strings.Split(value, ",") {
+			source = strings.TrimSpace(source)
+			if source == "" {
+				continue
+			}
+			if !isCommitSource(source) {
+				return configevents.SettingModificationFailed{Reason: fmt.Errorf("unknown commit source '%s', expected any of: %s", source, strings.Join(configentity.CommitSources, ", "))}
+			}
+			sources = append(sources, source)
+		}
+	}
+
+	if err := deps.ConfigWriter.SetInjectSources(sources); err != nil {
+		return configevents.SettingModificationFailed{Reason: fmt.Errorf("failed to modify setting 'inject-sources': %s", err)}
+	}
+
+	return configevents.SettingModificationSucceeded{Key: "inject-sources", Value: value}
+}
+
+func isCommitSource(source string) bool {
+	for _, commitSource := range configentity.CommitSources {
+		if source == commitSource {
+			return true
+		}
+	}
+	return false
+}
+
+// "default" resets the setting to "Co-authored-by"
+func setTrailerKey(deps Dependencies, value string) events.Event {
+	key := ""
+	if value != "default" {
+		if err := trailer.ValidateKey(value); err != nil {
+			return configevents.SettingModificationFailed{Reason: err}
+		}
+		key = value
+	}
+
+	if err := deps.ConfigWriter.SetTrailerKey(key); err != nil {
+		return configevents.SettingModificationFailed{Reason: fmt.Errorf("failed to modify setting 'trailer-key': %s", err)}
+	}
+
+	return configevents.SettingModificationSucceeded{Key: "trailer-key", Value: value}
+}
+
+// "default" resets the setting to "{key}: {value}"
+func setTrailerFormat(deps Dependencies, value string) events.Event {
+	format := ""
+	if value != "default" {
+		if err := trailer.ValidateFormat(value); err != nil {
+			return configevents.SettingModificationFailed{Reason: err}
+		}
+		format = value
+	}
+
+	if err := deps.ConfigWriter.SetTrailerFormat(format); err != nil {
+		return configevents.SettingModificationFailed{Reason: fmt.Errorf("failed to modify setting 'trailer-format': %s", err)}
+	}
+
+	return configevents.SettingModificationSucceeded{Key: "trailer-format", Value: value}
+}
+
+// require-coauthors is a setting of the current repository, which keeps git-team's hooks in place even while git-team is disabled
+func setRequireCoauthors(deps Dependencies, value string) events.Event {
+	if value != "true" && value != "false" {
+		return configevents.SettingModificationFailed{Reason: fmt.Errorf("unknown value for require-coauthors '%s', expected one of: true, false", value)}
+	}
+
+	if !deps.ActivationValidator.IsInsideAGitRepository() {
+		return configevents.SettingModificationFailed{Reason: errors.New("failed to modify setting 'require-coauthors': not inside a git repository")}
+	}
+
+	hooksDir := deps.CommitSettingsReader.Read().HooksDir
+
+	var err error
+	if value == "true" {
+		err = keepHooksInstalled(deps, hooksDir)
+	} else {
+		err = releaseHooks(deps, hooksDir)
+	}
+	if err != nil {
+		return configevents.SettingModificationFailed{Reason: fmt.Errorf("failed to modify setting 'require-coauthors': %s", err)}
+	}
+
+	if err := deps.ConfigWriter.SetRequireCoauthors(value == "true"); err != nil {
+		return configevents.SettingModificationFailed{Reason: fmt.Errorf("failed to modify setting 'require-coauthors': %s", err)}
+	}
+
+	return configevents.SettingModificationSucceeded{Key: "require-coauthors", Value: value}
+}
+
+// keepHooksInstalled point the repository to git-team's hooks, so that the commit-msg check runs regardless of git-team being enabled
+func keepHooksInstalled(deps Dependencies, hooksDir string) error {
+	if err := deps.InstallHooks(hooksDir); err != nil {
+		return fmt.Errorf("failed to install hooks: %s", err)
+	}
+
+	hooksPath, err := deps.GitConfigReader.Get(gitconfigscope.Local, displaced.HooksPath.Key)
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return fmt.Errorf("failed to get %s: %s", displaced.HooksPath.Key, err)
+	}
+
+	if hooksPath == hooksDir {
+		return nil
+	}
+
+	if hooksPath != "" {
+		if err := deps.GitConfigWriter.ReplaceAll(gitconfigscope.Local, displaced.HooksPath.BackupKey, hooksPath); err != nil {
+			return fmt.Errorf("failed to set %s: %s", displaced.HooksPath.BackupKey, err)
+		}
+	}
+
+	if err := deps.GitConfigWriter.ReplaceAll(gitconfigscope.Local, displaced.HooksPath.Key, hooksDir); err != nil {
+		return fmt.Errorf("failed to set %s: %s", displaced.HooksPath.Key, err)
+	}
+
+	return nil
+}
+
+// releaseHooks undo keepHooksInstalled, unless git-team is enabled for the repository and needs its hooks anyway
+func releaseHooks(deps Dependencies, hooksDir string) error {
+	status, err := deps.GitConfigReader.Get(gitconfigscope.Local, "team.state.status")
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return fmt.Errorf("failed to get team.state.status: %s", err)
+	}
+
+	if status == "enabled" {
+		return nil
+	}
+
+	hooksPath, err := deps.GitConfigReader.Get(gitconfigscope.Local, displaced.HooksPath.Key)
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return fmt.Errorf("failed to get %s: %s", displaced.HooksPath.Key, err)
+	}
+
+	if hooksPath != hooksDir {
+		return nil
+	}
+
+	backup, err := deps.GitConfigReader.Get(gitconfigscope.Local, displaced.HooksPath.BackupKey)
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return fmt.Errorf("failed to get %s: %s", displaced.HooksPath.BackupKey, err)
+	}
+
+	if backup == "" {
+		if err := deps.GitConfigWriter.UnsetAll(gitconfigscope.Local, displaced.HooksPath.Key); err != nil && !errors.Is(err, giterror.ErrTryingToUnsetAnOptionWhichDoesNotExist) {
+			return fmt.Errorf("failed to unset %s: %s", displaced.HooksPath.Key, err)
+		}
+		return nil
+	}
+
+	if err := deps.GitConfigWriter.ReplaceAll(gitconfigscope.Local, displaced.HooksPath.Key, backup); err != nil {
+		return fmt.Errorf("failed to set %s: %s", displaced.HooksPath.Key, err)
+	}
+
+	if err := deps.GitConfigWriter.UnsetAll(gitconfigscope.Local, displaced.HooksPath.BackupKey); err != nil && !errors.Is(err, giterror.ErrTryingToUnsetAnOptionWhichDoesNotExist) {
+		return fmt.Errorf("failed to unset %s: %s", displaced.HooksPath.BackupKey, err)
+	}
+
+	return nil
 }
@@ -7,8 +7,11 @@ import (
 	"testing"
 
 	configevents "github.com/hekmekk/git-team/src/command/config/events"
+	commitsettings "github.com/hekmekk/git-team/src/command/enable/commitsettings/entity"
 	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
 	config "github.com/hekmekk/git-team/src/shared/config/entity/config"
+	gitconfigerror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
+	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
 )
 
 type configReaderMock struct {
@@ -22,13 +25,94 @@ func (mock configReaderMock) Read() (config.Config, error) {
 var cfg = config.Config{ActivationScope: activationscope.Global}
 
 type configWriterMock struct {
-	setActivationScope func(scope activationscope.Scope) error
+	setActivationScope     func(scope activationscope.Scope) error
+	setActivationDirectory func(directory string) error
+	setHooksChain          func(hooksDirs []string) error
+	setTrailerKey          func(key string) error
+	setTrailerFormat       func(format string) error
+	setInjectSources       func(sources []string) error
+	setRequireCoauthors    func(required bool) error
 }
 
 func (mock configWriterMock) SetActivationScope(scope activationscope.Scope) error {
 	return mock.setActivationScope(scope)
 }
 
+func (mock configWriterMock) SetActivationDirectory(directory string) error {
+	return mock.setActivationDirectory(directory)
+}
+
+func (mock configWriterMock) SetHooksChain(hooksDirs []string) error {
+	return mock.setHooksChain(hooksDirs)
+}
+
+func (mock configWriterMock) SetTrailerKey(key string) error {
+	return mock.setTrailerKey(key)
+}
+
+func (mock configWriterMock) SetTrailerFormat(format string) error {
+	return mock.setTrailerFormat(format)
+}
+
+func (mock configWriterMock) SetInjectSources(sources []string) error {
+	return mock.setInjectSources(sources)
+}
+
+func (mock configWriterMock) SetRequireCoauthors(required bool) error {
+	return mock.setRequireCoauthors(required)
+}
+
+type gitConfigReaderMock struct {
+	get func(gitconfigscope.Scope, string) (string, error)
+}
+
+func (mock gitConfigReaderMock) Get(scope gitconfigscope.Scope, key string) (string, error) {
+	return mock.get(scope, key)
+}
+
+func (mock gitConfigReaderMock) GetAll(scope gitconfigscope.Scope, key string) ([]string, error) {
+	return nil, nil
+}
+
+func (mock gitConfigReaderMock) GetRegexp(scope gitconfigscope.Scope, pattern string) (map[string]string, error) {
+	return nil, nil
+}
+
+func (mock gitConfigReaderMock) List(scope gitconfigscope.Scope) (map[string]string, error) {
+	return nil, nil
+}
+
+type gitConfigWriterMock struct {
+	replaceAll func(gitconfigscope.Scope, string, string) error
+	unsetAll   func(gitconfigscope.Scope, string) error
+}
+
+func (mock gitConfigWriterMock) Add(scope gitconfigscope.Scope, key string, value string) error {
+	return nil
+}
+
+func (mock gitConfigWriterMock) ReplaceAll(scope gitconfigscope.Scope, key string, value string) error {
+	return mock.replaceAll(scope, key, value)
+}
+
+func (mock gitConfigWriterMock) UnsetAll(scope gitconfigscope.Scope, key string) error {
+	return mock.unsetAll(scope, key)
+}
+
+type activationValidatorMock struct {
+	isInsideAGitRepository func() bool
+}
+
+func (mock activationValidatorMock) IsInsideAGitRepository() bool {
+	return mock.isInsideAGitRepository()
+}
+
+type commitSettingsReaderMock struct{}
+
+func (mock commitSettingsReaderMock) Read() commitsettings.CommitSettings {
+	return commitsettings.CommitSettings{TemplatesBaseDir: "/home/user/.git-team/commit-templates", HooksDir: "/home/user/.git-team/hooks"}
+}
+
 func TestConfigShouldBeRetrieved(t *testing.T) {
 	expectedEvent := configevents.RetrievalSucceeded{Config: cfg}
 
@@ -80,10 +164,10 @@ func TestFailIfOnlyOneArgumentIsProvided(t *testing.T) {
 	value := "B"
 	emptyString := ""
 
-	eventKeyOnlyNil := Policy{Req: Request{&key, nil}, Deps: Dependencies{nil, nil}}.Apply()
-	eventKeyOnlyEmptyString := Policy{Req: Request{&key, &emptyString}, Deps: Dependencies{nil, nil}}.Apply()
-	eventValueOnlyNil := Policy{Req: Request{nil, &value}, Deps: Dependencies{nil, nil}}.Apply()
-	eventValueOnlyEmptyString := Policy{Req: Request{&emptyString, &value}, Deps: Dependencies{nil, nil}}.Apply()
+	eventKeyOnlyNil := Policy{Req: Request{&key, nil}, Deps: Dependencies{}}.Apply()
+	eventKeyOnlyEmptyString := Policy{Req: Request{&key, &emptyString}, Deps: Dependencies{}}.Apply()
+	eventValueOnlyNil := Policy{Req: Request{nil, &value}, Deps: Dependencies{}}.Apply()
+	eventValueOnlyEmptyString := Policy{Req: Request{&emptyString, &value}, Deps: Dependencies{}}.Apply()
 
 	if !reflect.DeepEqual(expectedEvent, eventKeyOnlyNil) {
 		t.Errorf("expected: %s, got: %s", expectedEvent, eventKeyOnlyNil)
@@ -113,7 +197,7 @@ func TestFailOnUnknownSetting(t *testing.T) {
 
 	key := "A"
 	value := "B"
-	event := Policy{Req: Request{&key, &value}, Deps: Dependencies{nil, nil}}.Apply()
+	event := Policy{Req: Request{&key, &value}, Deps: Dependencies{}}.Apply()
 
 	if !reflect.DeepEqual(expectedEvent, event) {
 		t.Errorf("expected: %s, got: %s", expectedEvent, event)
@@ -150,7 +234,7 @@ func TestShouldFailWhenConfigWriterFails(t *testing.T) {
 	err := errors.New("unable to write to gitconfig")
 	expectedEvent := configevents.SettingModificationFailed{Reason: fmt.Errorf("failed to modify setting 'activation-scope': %s", err)}
 
-	for _, loopValue := range []string{"global", "repo-local"} {
+	for _, loopValue := range []string{"global", "repo-local", "directory"} {
 		value := loopValue
 		t.Run(value, func(t *testing.T) {
 			t.Parallel()
@@ -176,7 +260,7 @@ func TestShouldModifyActivationScopeSetting(t *testing.T) {
 
 	key := "activation-scope"
 
-	for _, loopValue := range []string{"global", "repo-local"} {
+	for _, loopValue := range []string{"global", "repo-local", "directory"} {
 		value := loopValue
 		t.Run(value, func(t *testing.T) {
 			t.Parallel()
@@ -197,3 +281,491 @@ func TestShouldModifyActivationScopeSetting(t *testing.T) {
 		})
 	}
 }
+
+func TestShouldModifyActivationDirectorySetting(t *testing.T) {
+	t.Parallel()
+
+	key := "activation-directory"
+
+	cases := []struct {
+		value             string
+		expectedDirectory string
+	}{
+		{"~/work", "~/work/"},
+		{"~/work/", "~/work/"},
+		{"/home/someone/work", "/home/someone/work/"},
+	}
+
+	for _, caseLoopVar := range cases {
+		value := caseLoopVar.value
+		expectedDirectory := caseLoopVar.expectedDirectory
+
+		t.Run(value, func(t *testing.T) {
+			t.Parallel()
+
+			expectedEvent := configevents.SettingModificationSucceeded{Key: key, Value: expectedDirectory}
+
+			configWriter := &configWriterMock{
+				setActivationDirectory: func(directory string) error {
+					if directory != expectedDirectory {
+						return fmt.Errorf("wrong directory: %s", directory)
+					}
+					return nil
+				},
+			}
+
+			event := Policy{Req: Request{&key, &value}, Deps: Dependencies{ConfigReader: nil, ConfigWriter: configWriter}}.Apply()
+
+			if !reflect.DeepEqual(expectedEvent, event) {
+				t.Errorf("expected: %s, got: %s", expectedEvent, event)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestFailOnRelativeActivationDirectory(t *testing.T) {
+	key := "activation-directory"
+	value := "work"
+
+	expectedEvent := configevents.SettingModificationFailed{Reason: errors.New("activation-directory must either be absolute or start with '~/': 'work'")}
+
+	event := Policy{Req: Request{&key, &value}, Deps: Dependencies{ConfigReader: nil, ConfigWriter: nil}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestShouldFailWhenConfigWriterFailsToSetActivationDirectory(t *testing.T) {
+	key := "activation-directory"
+	value := "~/work/"
+	err := errors.New("unable to write to gitconfig")
+
+	expectedEvent := configevents.SettingModificationFailed{Reason: fmt.Errorf("failed to modify setting 'activation-directory': %s", err)}
+
+	configWriter := &configWriterMock{
+		setActivationDirectory: func(directory string) error {
+			return err
+		},
+	}
+
+	event := Policy{Req: Request{&key, &value}, Deps: Dependencies{ConfigReader: nil, ConfigWriter: configWriter}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestShouldModifyHooksChainSetting(t *testing.T) {
+	t.Parallel()
+
+	key := "hooks-chain"
+
+	cases := []struct {
+		value              string
+		expectedHooksChain []string
+	}{
+		{".githooks:.git/hooks", []string{".githooks", ".git/hooks"}},
+		{"~/hooks::/path/to/hooks:", []string{"~/hooks", "/path/to/hooks"}},
+		{"default", []string{}},
+	}
+
+	for _, caseLoopVar := range cases {
+		value := caseLoopVar.value
+		expectedHooksChain := caseLoopVar.expectedHooksChain
+
+		t.Run(value, func(t *testing.T) {
+			t.Parallel()
+
+			expectedEvent := configevents.SettingModificationSucceeded{Key: key, Value: value}
+
+			configWriter := &configWriterMock{
+				setHooksChain: func(hooksDirs []string) error {
+					if !reflect.DeepEqual(expectedHooksChain, hooksDirs) {
+						return fmt.Errorf("wrong hooks chain: %s", hooksDirs)
+					}
+					return nil
+				},
+			}
+
+			event := Policy{Req: Request{&key, &value}, Deps: Dependencies{ConfigReader: nil, ConfigWriter: configWriter}}.Apply()
+
+			if !reflect.DeepEqual(expectedEvent, event) {
+				t.Errorf("expected: %s, got: %s", expectedEvent, event)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestShouldFailWhenConfigWriterFailsToSetHooksChain(t *testing.T) {
+	key := "hooks-chain"
+	value := ".githooks"
+	err := errors.New("unable to write to gitconfig")
+
+	expectedEvent := configevents.SettingModificationFailed{Reason: fmt.Errorf("failed to modify setting 'hooks-chain': %s", err)}
+
+	configWriter := &configWriterMock{
+		setHooksChain: func(hooksDirs []string) error {
+			return err
+		},
+	}
+
+	event := Policy{Req: Request{&key, &value}, Deps: Dependencies{ConfigReader: nil, ConfigWriter: configWriter}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestShouldModifyTrailerKeySetting(t *testing.T) {
+	t.Parallel()
+
+	key := "trailer-key"
+
+	cases := []struct {
+		value              string
+		expectedTrailerKey string
+	}{
+		{"Pair", "Pair"},
+		{"default", ""},
+	}
+
+	for _, caseLoopVar := range cases {
+		value := caseLoopVar.value
+		expectedTrailerKey := caseLoopVar.expectedTrailerKey
+
+		t.Run(value, func(t *testing.T) {
+			t.Parallel()
+
+			expectedEvent := configevents.SettingModificationSucceeded{Key: key, Value: value}
+
+			configWriter := &configWriterMock{
+				setTrailerKey: func(trailerKey string) error {
+					if expectedTrailerKey != trailerKey {
+						return fmt.Errorf("wrong trailer key: %s", trailerKey)
+					}
+					return nil
+				},
+			}
+
+			event := Policy{Req: Request{&key, &value}, Deps: Dependencies{ConfigReader: nil, ConfigWriter: configWriter}}.Apply()
+
+			if !reflect.DeepEqual(expectedEvent, event) {
+				t.Errorf("expected: %s, got: %s", expectedEvent, event)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestFailOnInvalidTrailerKey(t *testing.T) {
+	key := "trailer-key"
+	value := "Paired with"
+
+	expectedEvent := configevents.SettingModificationFailed{Reason: errors.New("trailer-key must consist of alphanumeric characters and hyphens only: 'Paired with'")}
+
+	event := Policy{Req: Request{&key, &value}, Deps: Dependencies{ConfigReader: nil, ConfigWriter: &configWriterMock{}}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestShouldModifyTrailerFormatSetting(t *testing.T) {
+	t.Parallel()
+
+	key := "trailer-format"
+
+	cases := []struct {
+		value                 string
+		expectedTrailerFormat string
+	}{
+		{"{key} → {value}", "{key} → {value}"},
+		{"default", ""},
+	}
+
+	for _, caseLoopVar := range cases {
+		value := caseLoopVar.value
+		expectedTrailerFormat := caseLoopVar.expectedTrailerFormat
+
+		t.Run(value, func(t *testing.T) {
+			t.Parallel()
+
+			expectedEvent := configevents.SettingModificationSucceeded{Key: key, Value: value}
+
+			configWriter := &configWriterMock{
+				setTrailerFormat: func(trailerFormat string) error {
+					if expectedTrailerFormat != trailerFormat {
+						return fmt.Errorf("wrong trailer format: %s", trailerFormat)
+					}
+					return nil
+				},
+			}
+
+			event := Policy{Req: Request{&key, &value}, Deps: Dependencies{ConfigReader: nil, ConfigWriter: configWriter}}.Apply()
+
+			if !reflect.DeepEqual(expectedEvent, event) {
+				t.Errorf("expected: %s, got: %s", expectedEvent, event)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestFailOnTrailerFormatWithoutValue(t *testing.T) {
+	key := "trailer-format"
+	value := "{key}:"
+
+	expectedEvent := configevents.SettingModificationFailed{Reason: errors.New("trailer-format must contain '{value}' exactly once: '{key}:'")}
+
+	event := Policy{Req: Request{&key, &value}, Deps: Dependencies{ConfigReader: nil, ConfigWriter: &configWriterMock{}}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestShouldFailWhenConfigWriterFailsToSetTrailerKey(t *testing.T) {
+	key := "trailer-key"
+	value := "Pair"
+	err := errors.New("unable to write to gitconfig")
+
+	expectedEvent := configevents.SettingModificationFailed{Reason: fmt.Errorf("failed to modify setting 'trailer-key': %s", err)}
+
+	configWriter := &configWriterMock{
+		setTrailerKey: func(trailerKey string) error {
+			return err
+		},
+	}
+
+	event := Policy{Req: Request{&key, &value}, Deps: Dependencies{ConfigReader: nil, ConfigWriter: configWriter}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestShouldModifyInjectSourcesSetting(t *testing.T) {
+	t.Parallel()
+
+	key := "inject-sources"
+
+	cases := []struct {
+		value                 string
+		expectedInjectSources []string
+	}{
+		{"message,merge,squash,commit", []string{"message", "merge", "squash", "commit"}},
+		{"message, template,", []string{"message", "template"}},
+		{"default", []string{}},
+	}
+
+	for _, caseLoopVar := range cases {
+		value := caseLoopVar.value
+		expectedInjectSources := caseLoopVar.expectedInjectSources
+
+		t.Run(value, func(t *testing.T) {
+			t.Parallel()
+
+			expectedEvent := configevents.SettingModificationSucceeded{Key: key, Value: value}
+
+			configWriter := &configWriterMock{
+				setInjectSources: func(sources []string) error {
+					if !reflect.DeepEqual(expectedInjectSources, sources) {
+						return fmt.Errorf("wrong inject sources: %s", sources)
+					}
+					return nil
+				},
+			}
+
+			event := Policy{Req: Request{&key, &value}, Deps: Dependencies{ConfigReader: nil, ConfigWriter: configWriter}}.Apply()
+
+			if !reflect.DeepEqual(expectedEvent, event) {
+				t.Errorf("expected: %s, got: %s", expectedEvent, event)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestFailOnUnknownInjectSource(t *testing.T) {
+	key := "inject-sources"
+	value := "message,amend"
+
+	expectedEvent := configevents.SettingModificationFailed{Reason: errors.New("unknown commit source 'amend', expected any of: message, template, merge, squash, commit, none")}
+
+	event := Policy{Req: Request{&key, &value}, Deps: Dependencies{ConfigReader: nil, ConfigWriter: &configWriterMock{}}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func requireCoauthorsDeps(localConfig map[string]string, written map[string]string, required *bool) Dependencies {
+	return Dependencies{
+		ConfigWriter: &configWriterMock{
+			setRequireCoauthors: func(value bool) error {
+				*required = value
+				return nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			get: func(scope gitconfigscope.Scope, key string) (string, error) {
+				if scope != gitconfigscope.Local {
+					return "", fmt.Errorf("wrong scope: %s", scope)
+				}
+				value, ok := localConfig[key]
+				if !ok {
+					return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+				}
+				return value, nil
+			},
+		},
+		GitConfigWriter: &gitConfigWriterMock{
+			replaceAll: func(scope gitconfigscope.Scope, key string, value string) error {
+				if scope != gitconfigscope.Local {
+					return fmt.Errorf("wrong scope: %s", scope)
+				}
+				written[key] = value
+				return nil
+			},
+			unsetAll: func(scope gitconfigscope.Scope, key string) error {
+				if scope != gitconfigscope.Local {
+					return fmt.Errorf("wrong scope: %s", scope)
+				}
+				written[key] = ""
+				return nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		CommitSettingsReader: &commitSettingsReaderMock{},
+		InstallHooks: func(hooksDir string) error {
+			if hooksDir != "/home/user/.git-team/hooks" {
+				return fmt.Errorf("wrong hooks dir: %s", hooksDir)
+			}
+			return nil
+		},
+	}
+}
+
+func TestShouldRequireCoauthors(t *testing.T) {
+	key := "require-coauthors"
+	value := "true"
+
+	written := map[string]string{}
+	required := false
+	deps := requireCoauthorsDeps(map[string]string{"core.hooksPath": ".githooks"}, written, &required)
+
+	expectedEvent := configevents.SettingModificationSucceeded{Key: key, Value: value}
+	expectedWritten := map[string]string{"team.displaced.hooks-path": ".githooks", "core.hooksPath": "/home/user/.git-team/hooks"}
+
+	event := Policy{Req: Request{Key: &key, Value: &value}, Deps: deps}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedWritten, written) {
+		t.Errorf("expected: %s, got: %s", expectedWritten, written)
+		t.Fail()
+	}
+
+	if !required {
+		t.Error("expected require-coauthors to be set")
+		t.Fail()
+	}
+}
+
+func TestShouldNoLongerRequireCoauthors(t *testing.T) {
+	key := "require-coauthors"
+	value := "false"
+
+	written := map[string]string{}
+	required := true
+	deps := requireCoauthorsDeps(map[string]string{"core.hooksPath": "/home/user/.git-team/hooks", "team.displaced.hooks-path": ".githooks"}, written, &required)
+
+	expectedEvent := configevents.SettingModificationSucceeded{Key: key, Value: value}
+	expectedWritten := map[string]string{"team.displaced.hooks-path": "", "core.hooksPath": ".githooks"}
+
+	event := Policy{Req: Request{Key: &key, Value: &value}, Deps: deps}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedWritten, written) {
+		t.Errorf("expected: %s, got: %s", expectedWritten, written)
+		t.Fail()
+	}
+
+	if required {
+		t.Error("expected require-coauthors to be removed")
+		t.Fail()
+	}
+}
+
+func TestShouldKeepTheHooksWhenNoLongerRequiringCoauthorsWhileEnabled(t *testing.T) {
+	key := "require-coauthors"
+	value := "false"
+
+	written := map[string]string{}
+	required := true
+	deps := requireCoauthorsDeps(map[string]string{"core.hooksPath": "/home/user/.git-team/hooks", "team.state.status": "enabled"}, written, &required)
+
+	event := Policy{Req: Request{Key: &key, Value: &value}, Deps: deps}.Apply()
+
+	if !reflect.DeepEqual(configevents.SettingModificationSucceeded{Key: key, Value: value}, event) {
+		t.Errorf("expected: %s, got: %s", configevents.SettingModificationSucceeded{Key: key, Value: value}, event)
+		t.Fail()
+	}
+
+	if len(written) != 0 {
+		t.Errorf("expected no modifications, got: %s", written)
+		t.Fail()
+	}
+}
+
+func TestFailToRequireCoauthorsOutsideOfAGitRepository(t *testing.T) {
+	key := "require-coauthors"
+	value := "true"
+
+	deps := requireCoauthorsDeps(map[string]string{}, map[string]string{}, new(bool))
+	deps.ActivationValidator = &activationValidatorMock{
+		isInsideAGitRepository: func() bool { return false },
+	}
+
+	expectedEvent := configevents.SettingModificationFailed{Reason: errors.New("failed to modify setting 'require-coauthors': not inside a git repository")}
+
+	event := Policy{Req: Request{Key: &key, Value: &value}, Deps: deps}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestFailOnUnknownRequireCoauthorsValue(t *testing.T) {
+	key := "require-coauthors"
+	value := "yes"
+
+	expectedEvent := configevents.SettingModificationFailed{Reason: errors.New("unknown value for require-coauthors 'yes', expected one of: true, false")}
+
+	event := Policy{Req: Request{Key: &key, Value: &value}, Deps: Dependencies{}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
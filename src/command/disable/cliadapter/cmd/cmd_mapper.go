@@ -1,18 +1,25 @@
 package disablecmdadapter
 
 import (
+	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/urfave/cli/v2"
 
 	"github.com/hekmekk/git-team/src/command/disable"
 	disableeventadapter "github.com/hekmekk/git-team/src/command/disable/cliadapter/event"
+	commitsettingsds "github.com/hekmekk/git-team/src/command/enable/commitsettings/datasource"
 	statuscmdmapper "github.com/hekmekk/git-team/src/command/status/cliadapter/cmd"
 	activation "github.com/hekmekk/git-team/src/shared/activation/impl"
 	commandadapter "github.com/hekmekk/git-team/src/shared/cli/commandadapter"
 	configds "github.com/hekmekk/git-team/src/shared/config/datasource"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
 	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/impl"
+	"github.com/hekmekk/git-team/src/shared/gitrepo"
+	sessionlog "github.com/hekmekk/git-team/src/shared/sessionlog/impl"
 	state "github.com/hekmekk/git-team/src/shared/state/impl"
+	templateindex "github.com/hekmekk/git-team/src/shared/templateindex/impl"
 )
 
 // Command the disable command
@@ -20,7 +27,15 @@ func Command() *cli.Command {
 	return &cli.Command{
 		Name:  "disable",
 		Usage: "Use default commit template and remove prepare-commit-msg hook",
+		Flags: []cli.Flag{
+			dryrun.Flag(),
+		},
 		Action: func(c *cli.Context) error {
+			if dryrun.IsRequested(c) {
+				recorder := dryrun.NewRecorder()
+				return commandadapter.Run(dryRunPolicy(recorder), disableeventadapter.MapDryRunEventToEffectFactory(recorder))
+			}
+
 			return commandadapter.Run(policy(), disableeventadapter.MapEventToEffectFactory(statuscmdmapper.Policy()))
 		},
 	}
@@ -36,6 +51,28 @@ func policy() disable.Policy {
 			RemoveFile:          os.RemoveAll,
 			StateWriter:         state.NewGitConfigDataSink(gitconfig.NewDataSink()),
 			ActivationValidator: activation.NewGitConfigDataSource(gitconfig.NewDataSource()),
+			TemplateIndexWriter: templateindex.NewFileDataSink(templateIndexPath(), ioutil.ReadFile, ioutil.WriteFile),
+			SessionLogWriter:    sessionlog.NewFileDataSink(sessionlog.Path(os.Getenv("HOME")), sessionlog.AppendFile),
+			Now:                 time.Now,
+			GetGitCommonDir:     gitrepo.CommonDir,
 		},
 	}
 }
+
+func dryRunPolicy(recorder dryrun.Recorder) disable.Policy {
+	fs := dryrun.NewFileSystem(recorder)
+	gitConfigWriter := dryrun.NewGitConfigWriter(recorder, os.Getenv("HOME"))
+
+	disablePolicy := policy()
+	disablePolicy.Deps.GitConfigWriter = gitConfigWriter
+	disablePolicy.Deps.RemoveFile = fs.RemoveAll
+	disablePolicy.Deps.TemplateIndexWriter = templateindex.NewFileDataSink(templateIndexPath(), ioutil.ReadFile, fs.WriteFile)
+	disablePolicy.Deps.StateWriter = state.NewGitConfigDataSink(gitConfigWriter)
+	disablePolicy.Deps.SessionLogWriter = sessionlog.NewFileDataSink(sessionlog.Path(os.Getenv("HOME")), fs.AppendFile)
+
+	return disablePolicy
+}
+
+func templateIndexPath() string {
+	return templateindex.Path(commitsettingsds.NewStaticValueDataSource().Read().TemplatesBaseDir)
+}
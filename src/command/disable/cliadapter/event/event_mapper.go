@@ -6,6 +6,7 @@ import (
 	"github.com/hekmekk/git-team/src/core/events"
 	"github.com/hekmekk/git-team/src/core/policy"
 	"github.com/hekmekk/git-team/src/shared/cli/effects"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
 )
 
 // MapEventToEffectFactory convert disable events to effects for the cli
@@ -21,3 +22,17 @@ func MapEventToEffectFactory(statusPolicy policy.Policy) func(events.Event) effe
 		}
 	}
 }
+
+// MapDryRunEventToEffectFactory convert disable events to effects for the cli when running with --dry-run
+func MapDryRunEventToEffectFactory(recorder dryrun.Recorder) func(events.Event) effects.Effect {
+	return func(event events.Event) effects.Effect {
+		switch evt := event.(type) {
+		case disable.Succeeded:
+			return dryrun.MapOperationsToEffect(recorder.Operations())
+		case disable.Failed:
+			return effects.NewExitErrMsg(evt.Reason)
+		default:
+			return effects.NewExitOk()
+		}
+	}
+}
@@ -9,6 +9,8 @@ import (
 	status "github.com/hekmekk/git-team/src/command/status"
 	"github.com/hekmekk/git-team/src/core/events"
 	"github.com/hekmekk/git-team/src/shared/cli/effects"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
+	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
 	state "github.com/hekmekk/git-team/src/shared/state/entity"
 )
 
@@ -79,3 +81,30 @@ func TestMapEventToEffectUnknownEvent(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestMapDryRunEventToEffectSucceeded(t *testing.T) {
+	recorder := dryrun.NewRecorder()
+	dryrun.NewGitConfigWriter(recorder, "/home/some-user").UnsetAll(gitconfigscope.Global, "core.hooksPath")
+
+	expectedEffect := dryrun.MapOperationsToEffect([]string{"git config --global --unset-all core.hooksPath"})
+
+	effect := MapDryRunEventToEffectFactory(recorder)(disable.Succeeded{})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapDryRunEventToEffectFailed(t *testing.T) {
+	err := errors.New("failure")
+
+	expectedEffect := effects.NewExitErrMsg(err)
+
+	effect := MapDryRunEventToEffectFactory(dryrun.NewRecorder())(disable.Failed{Reason: err})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
@@ -5,15 +5,21 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/hekmekk/git-team/src/core/events"
 	activation "github.com/hekmekk/git-team/src/shared/activation/interface"
 	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
 	config "github.com/hekmekk/git-team/src/shared/config/interface"
+	"github.com/hekmekk/git-team/src/shared/displaced"
 	giterror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
 	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/interface"
 	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+	"github.com/hekmekk/git-team/src/shared/sessionlog"
+	sessionlogentity "github.com/hekmekk/git-team/src/shared/sessionlog/entity"
+	sessionloginterface "github.com/hekmekk/git-team/src/shared/sessionlog/interface"
 	state "github.com/hekmekk/git-team/src/shared/state/interface"
+	templateindex "github.com/hekmekk/git-team/src/shared/templateindex/interface"
 )
 
 // Dependencies the dependencies of the disable Policy module
@@ -25,6 +31,10 @@ type Dependencies struct {
 	StateWriter         state.Writer
 	ConfigReader        config.Reader
 	ActivationValidator activation.Validator
+	TemplateIndexWriter templateindex.Writer
+	SessionLogWriter    sessionloginterface.Writer
+	Now                 func() time.Time
+	GetGitCommonDir     func() (string, error)
 }
 
 // Policy the policy to apply
@@ -48,15 +58,21 @@ func (policy Policy) Apply() events.Event {
 		return Failed{Reason: fmt.Errorf("failed to disable with activation-scope=%s: not inside a git repository", activationScope)}
 	}
 
-	var gitConfigScope gitconfigscope.Scope
-	if activationScope == activationscope.Global {
-		gitConfigScope = gitconfigscope.Global
-	} else {
-		gitConfigScope = gitconfigscope.Local
+	if activationScope == activationscope.Directory && cfg.ActivationDirectory == "" {
+		return Failed{Reason: fmt.Errorf("failed to disable with activation-scope=%s: activation-directory is not configured", activationScope)}
 	}
 
-	if err := gitConfigWriter.UnsetAll(gitConfigScope, "core.hooksPath"); err != nil && !errors.Is(err, giterror.ErrTryingToUnsetAnOptionWhichDoesNotExist) {
-		return Failed{Reason: fmt.Errorf("failed to unset core.hooksPath: %s", err)}
+	gitConfigScope := gitconfigscope.FromActivationScope(activationScope)
+
+	keepHooks, err := isRequiringCoauthors(gitConfigScope, deps)
+	if err != nil {
+		return Failed{Reason: fmt.Errorf("failed to get team.config.require-coauthors: %s", err)}
+	}
+
+	if !keepHooks {
+		if err := gitConfigWriter.UnsetAll(gitConfigScope, "core.hooksPath"); err != nil && !errors.Is(err, giterror.ErrTryingToUnsetAnOptionWhichDoesNotExist) {
+			return Failed{Reason: fmt.Errorf("failed to unset core.hooksPath: %s", err)}
+		}
 	}
 
 	commitTemplatePath, err := deps.GitConfigReader.Get(gitConfigScope, "commit.template")
@@ -70,10 +86,10 @@ func (policy Policy) Apply() events.Event {
 
 	if commitTemplatePath != "" {
 		var templatePathToDelete string
-		if activationScope == activationscope.Global {
-			templatePathToDelete = commitTemplatePath
-		} else {
+		if activationScope == activationscope.RepoLocal {
 			templatePathToDelete = filepath.Dir(commitTemplatePath)
+		} else {
+			templatePathToDelete = commitTemplatePath
 		}
 
 		if _, err := deps.StatFile(templatePathToDelete); err == nil {
@@ -81,11 +97,76 @@ func (policy Policy) Apply() events.Event {
 				return Failed{Reason: fmt.Errorf("failed to remove commit template: %s", err)}
 			}
 		}
+
+		if activationScope == activationscope.RepoLocal {
+			if err := deps.TemplateIndexWriter.Remove(templatePathToDelete); err != nil {
+				return Failed{Reason: fmt.Errorf("failed to remove commit template from index: %s", err)}
+			}
+		}
+	}
+
+	if err := restoreDisplacedSettings(gitConfigScope, deps, keepHooks); err != nil {
+		return Failed{Reason: fmt.Errorf("failed to restore displaced settings: %s", err)}
+	}
+
+	if activationScope == activationscope.Directory {
+		if err := gitConfigWriter.UnsetAll(gitconfigscope.Global, gitconfigscope.DirectoryIncludeKey(cfg.ActivationDirectory)); err != nil && !errors.Is(err, giterror.ErrTryingToUnsetAnOptionWhichDoesNotExist) {
+			return Failed{Reason: fmt.Errorf("failed to remove include for activation-directory %s: %s", cfg.ActivationDirectory, err)}
+		}
 	}
 
 	if err := deps.StateWriter.PersistDisabled(activationScope); err != nil {
 		return Failed{Reason: fmt.Errorf("failed to write current state: %s", err)}
 	}
 
+	sessionEvent := sessionlogentity.Event{
+		Time:      deps.Now(),
+		Action:    sessionlogentity.Disabled,
+		Scope:     activationScope,
+		Coauthors: []string{},
+	}
+	if err := sessionlog.Record(deps.SessionLogWriter, deps.GetGitCommonDir, sessionEvent); err != nil {
+		return Failed{Reason: fmt.Errorf("failed to record session: %s", err)}
+	}
+
 	return Succeeded{}
 }
+
+// restoreDisplacedSettings put back the values git-team has overridden when it was enabled
+func restoreDisplacedSettings(gitConfigScope gitconfigscope.Scope, deps Dependencies, keepHooks bool) error {
+	backups, err := deps.GitConfigReader.GetRegexp(gitConfigScope, displaced.BackupKeyPattern)
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return fmt.Errorf("failed to get displaced settings: %s", err)
+	}
+
+	for _, setting := range displaced.Settings {
+		value, ok := backups[setting.BackupKey]
+		if !ok || (keepHooks && setting == displaced.HooksPath) {
+			continue
+		}
+
+		if err := deps.GitConfigWriter.ReplaceAll(gitConfigScope, setting.Key, value); err != nil {
+			return fmt.Errorf("failed to set %s: %s", setting.Key, err)
+		}
+
+		if err := deps.GitConfigWriter.UnsetAll(gitConfigScope, setting.BackupKey); err != nil && !errors.Is(err, giterror.ErrTryingToUnsetAnOptionWhichDoesNotExist) {
+			return fmt.Errorf("failed to unset %s: %s", setting.BackupKey, err)
+		}
+	}
+
+	return nil
+}
+
+// isRequiringCoauthors whether the repository requires co-authors, in which case git-team's hooks are kept so that the commit-msg check keeps running
+func isRequiringCoauthors(gitConfigScope gitconfigscope.Scope, deps Dependencies) (bool, error) {
+	if gitConfigScope != gitconfigscope.Local {
+		return false, nil
+	}
+
+	value, err := deps.GitConfigReader.Get(gitconfigscope.Local, "team.config.require-coauthors")
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return false, err
+	}
+
+	return value == "true", nil
+}
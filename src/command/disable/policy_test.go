@@ -6,15 +6,21 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/hekmekk/git-team/src/core/events"
 	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
 	config "github.com/hekmekk/git-team/src/shared/config/entity/config"
 	gitconfigerror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
 	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+	"github.com/hekmekk/git-team/src/shared/role"
+	sessionlog "github.com/hekmekk/git-team/src/shared/sessionlog/entity"
+	templateindex "github.com/hekmekk/git-team/src/shared/templateindex/entity"
 )
 
 type gitConfigReaderMock struct {
-	get func(gitconfigscope.Scope, string) (string, error)
+	get       func(gitconfigscope.Scope, string) (string, error)
+	getRegexp func(gitconfigscope.Scope, string) (map[string]string, error)
 }
 
 func (mock gitConfigReaderMock) Get(scope gitconfigscope.Scope, key string) (string, error) {
@@ -26,7 +32,10 @@ func (mock gitConfigReaderMock) GetAll(scope gitconfigscope.Scope, key string) (
 }
 
 func (mock gitConfigReaderMock) GetRegexp(scope gitconfigscope.Scope, pattern string) (map[string]string, error) {
-	return nil, nil
+	if mock.getRegexp == nil {
+		return nil, nil
+	}
+	return mock.getRegexp(scope, pattern)
 }
 
 func (mock gitConfigReaderMock) List(scope gitconfigscope.Scope) (map[string]string, error) {
@@ -34,7 +43,8 @@ func (mock gitConfigReaderMock) List(scope gitconfigscope.Scope) (map[string]str
 }
 
 type gitConfigWriterMock struct {
-	unsetAll func(gitconfigscope.Scope, string) error
+	unsetAll   func(gitconfigscope.Scope, string) error
+	replaceAll func(gitconfigscope.Scope, string, string) error
 }
 
 func (mock gitConfigWriterMock) UnsetAll(scope gitconfigscope.Scope, key string) error {
@@ -42,7 +52,10 @@ func (mock gitConfigWriterMock) UnsetAll(scope gitconfigscope.Scope, key string)
 }
 
 func (mock gitConfigWriterMock) ReplaceAll(scope gitconfigscope.Scope, key string, value string) error {
-	return nil
+	if mock.replaceAll == nil {
+		return nil
+	}
+	return mock.replaceAll(scope, key, value)
 }
 
 func (mock gitConfigWriterMock) Add(scope gitconfigscope.Scope, key string, value string) error {
@@ -53,7 +66,7 @@ type stateWriterMock struct {
 	persistDisabled func(activationscope.Scope) error
 }
 
-func (mock stateWriterMock) PersistEnabled(scope activationscope.Scope, coauthors []string) error {
+func (mock stateWriterMock) PersistEnabled(scope activationscope.Scope, coauthors []string, roles []role.Assignment) error {
 	return nil
 }
 func (mock stateWriterMock) PersistDisabled(scope activationscope.Scope) error {
@@ -68,6 +81,26 @@ func (mock configReaderMock) Read() (config.Config, error) {
 	return mock.read()
 }
 
+type templateIndexWriterMock struct {
+	remove func(string) error
+}
+
+func (mock templateIndexWriterMock) Add(entry templateindex.Entry) error {
+	return nil
+}
+
+func (mock templateIndexWriterMock) Remove(templateDir string) error {
+	return mock.remove(templateDir)
+}
+
+type sessionLogWriterMock struct {
+	append func(sessionlog.Event) error
+}
+
+func (mock sessionLogWriterMock) Append(event sessionlog.Event) error {
+	return mock.append(event)
+}
+
 type activationValidatorMock struct {
 	isInsideAGitRepository func() bool
 }
@@ -77,10 +110,13 @@ func (mock activationValidatorMock) IsInsideAGitRepository() bool {
 }
 
 var (
-	fileInfo        os.FileInfo
-	statFile        = func(string) (os.FileInfo, error) { return fileInfo, nil }
-	removeFile      = func(string) error { return nil }
-	persistDisabled = func() error { return nil }
+	fileInfo         os.FileInfo
+	statFile         = func(string) (os.FileInfo, error) { return fileInfo, nil }
+	removeFile       = func(string) error { return nil }
+	persistDisabled  = func() error { return nil }
+	sessionLogWriter = &sessionLogWriterMock{append: func(sessionlog.Event) error { return nil }}
+	now              = func() time.Time { return time.Date(2021, 6, 7, 11, 30, 0, 0, time.UTC) }
+	getGitCommonDir  = func() (string, error) { return "/path/to/repo/.git", nil }
 )
 
 func TestDisableSucceeds(t *testing.T) {
@@ -89,7 +125,10 @@ func TestDisableSucceeds(t *testing.T) {
 	templatePath := "/path/to/template"
 
 	deps := Dependencies{
-		StatFile: statFile,
+		SessionLogWriter: sessionLogWriter,
+		Now:              now,
+		GetGitCommonDir:  getGitCommonDir,
+		StatFile:         statFile,
 		ActivationValidator: &activationValidatorMock{
 			isInsideAGitRepository: func() bool {
 				return true
@@ -164,6 +203,20 @@ func TestDisableSucceeds(t *testing.T) {
 				return nil
 			}
 
+			deps.TemplateIndexWriter = &templateIndexWriterMock{
+				remove: func(templateDir string) error {
+					if activationScope != activationscope.RepoLocal {
+						t.Errorf("unexpected removal from the template index with activation-scope=%s", activationScope)
+						t.Fail()
+					}
+					if templateDir != expectedPathToDelete {
+						t.Errorf("trying to remove the wrong template dir from the index, expected: %s, got: %s", expectedPathToDelete, templateDir)
+						t.Fail()
+					}
+					return nil
+				},
+			}
+
 			event := Policy{deps}.Apply()
 
 			if !reflect.DeepEqual(expectedEvent, event) {
@@ -208,12 +261,15 @@ func TestDisableShouldSucceedWhenUnsetHooksPathFailsBecauseTheOptionDoesntExist(
 	}
 
 	deps := Dependencies{
-		GitConfigReader: gitConfigReader,
-		GitConfigWriter: gitConfigWriter,
-		StatFile:        statFile,
-		RemoveFile:      removeFile,
-		StateWriter:     stateWriter,
-		ConfigReader:    configReader,
+		SessionLogWriter: sessionLogWriter,
+		Now:              now,
+		GetGitCommonDir:  getGitCommonDir,
+		GitConfigReader:  gitConfigReader,
+		GitConfigWriter:  gitConfigWriter,
+		StatFile:         statFile,
+		RemoveFile:       removeFile,
+		StateWriter:      stateWriter,
+		ConfigReader:     configReader,
 		ActivationValidator: &activationValidatorMock{
 			isInsideAGitRepository: func() bool {
 				return true
@@ -387,12 +443,15 @@ func TestDisableShouldSucceedWhenReadingCommitTemplateFailsBecauseItHasBeenUnset
 	}
 
 	deps := Dependencies{
-		GitConfigReader: gitConfigReader,
-		GitConfigWriter: gitConfigWriter,
-		StatFile:        statFile,
-		RemoveFile:      removeFile,
-		StateWriter:     stateWriter,
-		ConfigReader:    configReader,
+		SessionLogWriter: sessionLogWriter,
+		Now:              now,
+		GetGitCommonDir:  getGitCommonDir,
+		GitConfigReader:  gitConfigReader,
+		GitConfigWriter:  gitConfigWriter,
+		StatFile:         statFile,
+		RemoveFile:       removeFile,
+		StateWriter:      stateWriter,
+		ConfigReader:     configReader,
 		ActivationValidator: &activationValidatorMock{
 			isInsideAGitRepository: func() bool {
 				return true
@@ -491,12 +550,15 @@ func TestDisableShouldSucceedWhenUnsetCommitTemplateFailsBecauseItWasUnsetAlread
 	}
 
 	deps := Dependencies{
-		ConfigReader:    configReader,
-		GitConfigReader: gitConfigReader,
-		GitConfigWriter: gitConfigWriter,
-		StatFile:        statFile,
-		RemoveFile:      removeFile,
-		StateWriter:     stateWriter,
+		SessionLogWriter: sessionLogWriter,
+		Now:              now,
+		GetGitCommonDir:  getGitCommonDir,
+		ConfigReader:     configReader,
+		GitConfigReader:  gitConfigReader,
+		GitConfigWriter:  gitConfigWriter,
+		StatFile:         statFile,
+		RemoveFile:       removeFile,
+		StateWriter:      stateWriter,
 		ActivationValidator: &activationValidatorMock{
 			isInsideAGitRepository: func() bool {
 				return true
@@ -645,11 +707,14 @@ func TestDisableShouldSucceedButNotTryToRemoveTheCommitTemplateFileWhenTheRespec
 	}
 
 	deps := Dependencies{
-		ConfigReader:    configReader,
-		GitConfigReader: gitConfigReader,
-		GitConfigWriter: gitConfigWriter,
-		StatFile:        func(string) (os.FileInfo, error) { return fileInfo, nil },
-		StateWriter:     stateWriter,
+		SessionLogWriter: sessionLogWriter,
+		Now:              now,
+		GetGitCommonDir:  getGitCommonDir,
+		ConfigReader:     configReader,
+		GitConfigReader:  gitConfigReader,
+		GitConfigWriter:  gitConfigWriter,
+		StatFile:         func(string) (os.FileInfo, error) { return fileInfo, nil },
+		StateWriter:      stateWriter,
 		ActivationValidator: &activationValidatorMock{
 			isInsideAGitRepository: func() bool {
 				return true
@@ -700,11 +765,14 @@ func TestDisableShouldSucceedButNotTryToRemoveTheCommitTemplateFileWhenStatFileF
 	}
 
 	deps := Dependencies{
-		ConfigReader:    configReader,
-		GitConfigReader: gitConfigReader,
-		GitConfigWriter: gitConfigWriter,
-		StatFile:        func(string) (os.FileInfo, error) { return fileInfo, errors.New("failed to stat file") },
-		StateWriter:     stateWriter,
+		SessionLogWriter: sessionLogWriter,
+		Now:              now,
+		GetGitCommonDir:  getGitCommonDir,
+		ConfigReader:     configReader,
+		GitConfigReader:  gitConfigReader,
+		GitConfigWriter:  gitConfigWriter,
+		StatFile:         func(string) (os.FileInfo, error) { return fileInfo, errors.New("failed to stat file") },
+		StateWriter:      stateWriter,
 		ActivationValidator: &activationValidatorMock{
 			isInsideAGitRepository: func() bool {
 				return true
@@ -779,3 +847,438 @@ func TestDisableShouldFailWhenpersistDisabledFails(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestDisableShouldRecordTheSession(t *testing.T) {
+	sessionLogErr := errors.New("failed to write session log")
+
+	cases := []struct {
+		activationScope    activationscope.Scope
+		appendErr          error
+		expectedRepository string
+		expectedEvent      events.Event
+	}{
+		{activationscope.Global, nil, "", Succeeded{}},
+		{activationscope.RepoLocal, nil, "/path/to/repo/.git", Succeeded{}},
+		{activationscope.Global, sessionLogErr, "", Failed{Reason: fmt.Errorf("failed to record session: %s", sessionLogErr)}},
+	}
+
+	for _, caseLoopVar := range cases {
+		activationScope := caseLoopVar.activationScope
+		appendErr := caseLoopVar.appendErr
+		expectedEvent := caseLoopVar.expectedEvent
+
+		expectedSessionEvent := sessionlog.Event{
+			Time:       time.Date(2021, 6, 7, 11, 30, 0, 0, time.UTC),
+			Action:     sessionlog.Disabled,
+			Scope:      activationScope,
+			Repository: caseLoopVar.expectedRepository,
+			Coauthors:  []string{},
+		}
+
+		var sessionEvent sessionlog.Event
+		deps := Dependencies{
+			SessionLogWriter: &sessionLogWriterMock{
+				append: func(e sessionlog.Event) error {
+					sessionEvent = e
+					return appendErr
+				},
+			},
+			Now:             now,
+			GetGitCommonDir: getGitCommonDir,
+			ActivationValidator: &activationValidatorMock{
+				isInsideAGitRepository: func() bool {
+					return true
+				},
+			},
+			ConfigReader: &configReaderMock{
+				read: func() (config.Config, error) {
+					return config.Config{ActivationScope: activationScope}, nil
+				},
+			},
+			GitConfigReader: &gitConfigReaderMock{
+				get: func(_ gitconfigscope.Scope, key string) (string, error) {
+					return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+				},
+			},
+			GitConfigWriter: &gitConfigWriterMock{
+				unsetAll: func(gitconfigscope.Scope, string) error {
+					return nil
+				},
+			},
+			StatFile:   statFile,
+			RemoveFile: removeFile,
+			StateWriter: &stateWriterMock{
+				persistDisabled: func(activationscope.Scope) error {
+					return nil
+				},
+			},
+		}
+
+		event := Policy{deps}.Apply()
+
+		if !reflect.DeepEqual(expectedEvent, event) {
+			t.Errorf("expected: %s, got: %s", expectedEvent, event)
+			t.Fail()
+		}
+
+		if !reflect.DeepEqual(expectedSessionEvent, sessionEvent) {
+			t.Errorf("expected: %v, got: %v", expectedSessionEvent, sessionEvent)
+			t.Fail()
+		}
+	}
+}
+
+func TestDisableSucceedsWithActivationScopeDirectory(t *testing.T) {
+	templatePath := "/path/to/template"
+	expectedIncludeKey := "includeIf.gitdir:~/work/.path"
+
+	includeRemoved := false
+
+	deps := Dependencies{
+		SessionLogWriter: sessionLogWriter,
+		Now:              now,
+		GetGitCommonDir:  getGitCommonDir,
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Directory, ActivationDirectory: "~/work/"}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			get: func(scope gitconfigscope.Scope, key string) (string, error) {
+				if scope != gitconfigscope.Directory {
+					t.Errorf("wrong scope, expected: %s, got: %s", gitconfigscope.Directory, scope)
+					t.Fail()
+				}
+				return templatePath, nil
+			},
+		},
+		GitConfigWriter: &gitConfigWriterMock{
+			unsetAll: func(scope gitconfigscope.Scope, key string) error {
+				switch key {
+				case "commit.template", "core.hooksPath":
+					if scope != gitconfigscope.Directory {
+						t.Errorf("wrong scope, expected: %s, got: %s", gitconfigscope.Directory, scope)
+						t.Fail()
+					}
+				case expectedIncludeKey:
+					if scope != gitconfigscope.Global {
+						t.Errorf("wrong scope, expected: %s, got: %s", gitconfigscope.Global, scope)
+						t.Fail()
+					}
+					includeRemoved = true
+				default:
+					t.Errorf("wrong key: %s", key)
+					t.Fail()
+				}
+				return nil
+			},
+		},
+		StatFile: statFile,
+		RemoveFile: func(path string) error {
+			if path != templatePath {
+				t.Errorf("trying to delete the wrong commit template file, expected: %s, got: %s", templatePath, path)
+				t.Fail()
+			}
+			return nil
+		},
+		StateWriter: &stateWriterMock{
+			persistDisabled: func(scope activationscope.Scope) error {
+				return nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool {
+				return false
+			},
+		},
+	}
+
+	expectedEvent := Succeeded{}
+
+	event := Policy{deps}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !includeRemoved {
+		t.Error("expected the include for the activation-directory to be removed")
+		t.Fail()
+	}
+}
+
+func TestDisableShouldFailWhenActivationDirectoryIsNotConfigured(t *testing.T) {
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Directory}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool {
+				return true
+			},
+		},
+	}
+
+	expectedEvent := Failed{Reason: errors.New("failed to disable with activation-scope=directory: activation-directory is not configured")}
+
+	event := Policy{deps}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestDisableShouldRestoreDisplacedSettings(t *testing.T) {
+	gitConfigReader := &gitConfigReaderMock{
+		get: func(_ gitconfigscope.Scope, key string) (string, error) {
+			return "/path/to/template", nil
+		},
+		getRegexp: func(scope gitconfigscope.Scope, pattern string) (map[string]string, error) {
+			if pattern != "^team\\.displaced\\." {
+				return nil, fmt.Errorf("wrong pattern: %s", pattern)
+			}
+			return map[string]string{
+				"team.displaced.hooks-path":      "/path/to/foreign/hooks",
+				"team.displaced.commit-template": "/path/to/foreign/template",
+			}, nil
+		},
+	}
+
+	unsetKeys := []string{}
+	restoredSettings := make(map[string]string)
+	gitConfigWriter := &gitConfigWriterMock{
+		unsetAll: func(_ gitconfigscope.Scope, key string) error {
+			unsetKeys = append(unsetKeys, key)
+			return nil
+		},
+		replaceAll: func(_ gitconfigscope.Scope, key string, value string) error {
+			restoredSettings[key] = value
+			return nil
+		},
+	}
+
+	deps := Dependencies{
+		SessionLogWriter: sessionLogWriter,
+		Now:              now,
+		GetGitCommonDir:  getGitCommonDir,
+		GitConfigReader:  gitConfigReader,
+		GitConfigWriter:  gitConfigWriter,
+		StatFile:         statFile,
+		RemoveFile: func(path string) error {
+			if path != "/path/to/template" {
+				t.Errorf("trying to delete the wrong commit template file: %s", path)
+				t.Fail()
+			}
+			return nil
+		},
+		StateWriter: &stateWriterMock{
+			persistDisabled: func(scope activationscope.Scope) error {
+				return nil
+			},
+		},
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+	}
+
+	expectedRestoredSettings := map[string]string{
+		"core.hooksPath":  "/path/to/foreign/hooks",
+		"commit.template": "/path/to/foreign/template",
+	}
+	expectedUnsetKeys := []string{"core.hooksPath", "commit.template", "team.displaced.hooks-path", "team.displaced.commit-template"}
+
+	expectedEvent := Succeeded{}
+
+	event := Policy{deps}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedRestoredSettings, restoredSettings) {
+		t.Errorf("expected: %s, got: %s", expectedRestoredSettings, restoredSettings)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedUnsetKeys, unsetKeys) {
+		t.Errorf("expected: %s, got: %s", expectedUnsetKeys, unsetKeys)
+		t.Fail()
+	}
+}
+
+func TestDisableShouldFailWhenRestoringDisplacedSettingFails(t *testing.T) {
+	gitConfigReader := &gitConfigReaderMock{
+		get: func(_ gitconfigscope.Scope, key string) (string, error) {
+			return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+		getRegexp: func(scope gitconfigscope.Scope, pattern string) (map[string]string, error) {
+			return map[string]string{"team.displaced.hooks-path": "/path/to/foreign/hooks"}, nil
+		},
+	}
+
+	gitConfigWriter := &gitConfigWriterMock{
+		unsetAll: func(_ gitconfigscope.Scope, key string) error {
+			return nil
+		},
+		replaceAll: func(_ gitconfigscope.Scope, key string, value string) error {
+			return gitconfigerror.ErrConfigFileCannotBeWritten
+		},
+	}
+
+	deps := Dependencies{
+		GitConfigReader: gitConfigReader,
+		GitConfigWriter: gitConfigWriter,
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+	}
+
+	expectedEvent := Failed{Reason: fmt.Errorf("failed to restore displaced settings: failed to set core.hooksPath: %s", gitconfigerror.ErrConfigFileCannotBeWritten)}
+
+	event := Policy{deps}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestDisableShouldFailWhenRemovingTheTemplateFromTheIndexFails(t *testing.T) {
+	gitConfigReader := &gitConfigReaderMock{
+		get: func(_ gitconfigscope.Scope, key string) (string, error) {
+			return "/path/to/template/COMMIT_TEMPLATE", nil
+		},
+	}
+
+	gitConfigWriter := &gitConfigWriterMock{
+		unsetAll: func(_ gitconfigscope.Scope, key string) error {
+			return nil
+		},
+	}
+
+	indexErr := errors.New("failed to write index")
+
+	deps := Dependencies{
+		GitConfigReader: gitConfigReader,
+		GitConfigWriter: gitConfigWriter,
+		StatFile:        statFile,
+		RemoveFile:      removeFile,
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.RepoLocal}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool {
+				return true
+			},
+		},
+		TemplateIndexWriter: &templateIndexWriterMock{
+			remove: func(string) error {
+				return indexErr
+			},
+		},
+	}
+
+	expectedEvent := Failed{Reason: fmt.Errorf("failed to remove commit template from index: %s", indexErr)}
+
+	event := Policy{deps}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestDisableShouldKeepTheHooksOfARepositoryWhichRequiresCoauthors(t *testing.T) {
+	t.Parallel()
+
+	gitConfigReader := &gitConfigReaderMock{
+		get: func(_ gitconfigscope.Scope, key string) (string, error) {
+			if key == "team.config.require-coauthors" {
+				return "true", nil
+			}
+			return "/path/to/template", nil
+		},
+		getRegexp: func(scope gitconfigscope.Scope, pattern string) (map[string]string, error) {
+			return map[string]string{
+				"team.displaced.hooks-path":      "/path/to/foreign/hooks",
+				"team.displaced.commit-template": "/path/to/foreign/template",
+			}, nil
+		},
+	}
+
+	unsetKeys := []string{}
+	restoredSettings := make(map[string]string)
+	gitConfigWriter := &gitConfigWriterMock{
+		unsetAll: func(_ gitconfigscope.Scope, key string) error {
+			unsetKeys = append(unsetKeys, key)
+			return nil
+		},
+		replaceAll: func(_ gitconfigscope.Scope, key string, value string) error {
+			restoredSettings[key] = value
+			return nil
+		},
+	}
+
+	deps := Dependencies{
+		SessionLogWriter: sessionLogWriter,
+		Now:              now,
+		GetGitCommonDir:  getGitCommonDir,
+		GitConfigReader:  gitConfigReader,
+		GitConfigWriter:  gitConfigWriter,
+		StatFile:         statFile,
+		RemoveFile:       removeFile,
+		TemplateIndexWriter: &templateIndexWriterMock{
+			remove: func(string) error { return nil },
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool {
+				return true
+			},
+		},
+		StateWriter: &stateWriterMock{
+			persistDisabled: func(scope activationscope.Scope) error {
+				return nil
+			},
+		},
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.RepoLocal}, nil
+			},
+		},
+	}
+
+	expectedRestoredSettings := map[string]string{
+		"commit.template": "/path/to/foreign/template",
+	}
+	expectedUnsetKeys := []string{"commit.template", "team.displaced.commit-template"}
+
+	expectedEvent := Succeeded{}
+
+	event := Policy{deps}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedRestoredSettings, restoredSettings) {
+		t.Errorf("expected: %s, got: %s", expectedRestoredSettings, restoredSettings)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedUnsetKeys, unsetKeys) {
+		t.Errorf("expected: %s, got: %s", expectedUnsetKeys, unsetKeys)
+		t.Fail()
+	}
+}
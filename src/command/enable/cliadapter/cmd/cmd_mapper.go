@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/urfave/cli/v2"
 
@@ -11,13 +12,20 @@ import (
 	enableeventadapter "github.com/hekmekk/git-team/src/command/enable/cliadapter/event"
 	commitsettingsds "github.com/hekmekk/git-team/src/command/enable/commitsettings/datasource"
 	statuscmdmapper "github.com/hekmekk/git-team/src/command/status/cliadapter/cmd"
+	"github.com/hekmekk/git-team/src/core/events"
 	"github.com/hekmekk/git-team/src/core/validation"
 	activation "github.com/hekmekk/git-team/src/shared/activation/impl"
 	commandadapter "github.com/hekmekk/git-team/src/shared/cli/commandadapter"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
 	aliascompletion "github.com/hekmekk/git-team/src/shared/completion"
 	configds "github.com/hekmekk/git-team/src/shared/config/datasource"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
 	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/impl"
+	"github.com/hekmekk/git-team/src/shared/gitrepo"
+	"github.com/hekmekk/git-team/src/shared/role"
+	sessionlog "github.com/hekmekk/git-team/src/shared/sessionlog/impl"
 	state "github.com/hekmekk/git-team/src/shared/state/impl"
+	templateindex "github.com/hekmekk/git-team/src/shared/templateindex/impl"
 )
 
 // Command the enable command
@@ -28,11 +36,22 @@ func Command() *cli.Command {
 		ArgsUsage: "<co-authors> (A co-author must either be an alias or of the shape \"Name <email>\")",
 		Flags: []cli.Flag{
 			&cli.BoolFlag{Name: "all", Value: false, Aliases: []string{"A"}, Usage: "Use all known co-authors"},
+			&cli.StringSliceFlag{Name: string(role.Reviewer), Usage: "Record a reviewer (alias or \"Name <email>\") with a \"Reviewed-by\" trailer, may be repeated"},
+			&cli.StringSliceFlag{Name: string(role.Navigator), Usage: "Record a pair navigator (alias or \"Name <email>\") with a \"Navigated-by\" trailer, may be repeated"},
+			&cli.StringSliceFlag{Name: "role", Usage: "Record somebody in a role, given as <role>=<identity>, may be repeated"},
+			dryrun.Flag(),
 		},
 		Action: func(c *cli.Context) error {
 			coauthors := c.Args().Slice()
 			useAll := c.Bool("all")
-			return commandadapter.Run(policy(&coauthors, &useAll), enableeventadapter.MapEventToEffectFactory(statuscmdmapper.Policy()))
+			roles := rolesFromFlags(c)
+
+			if dryrun.IsRequested(c) {
+				recorder := dryrun.NewRecorder()
+				return commandadapter.Run(dryRunPolicy(&coauthors, &useAll, &roles, recorder), enableeventadapter.MapDryRunEventToEffectFactory(recorder))
+			}
+
+			return commandadapter.Run(policy(&coauthors, &useAll, &roles), enableeventadapter.MapEventToEffectFactory(statuscmdmapper.Policy()))
 		},
 		BashComplete: func(c *cli.Context) {
 			remainingAliases := aliascompletion.NewAliasShellCompletion(gitconfig.NewDataSource()).Complete(c.Args().Slice())
@@ -43,11 +62,40 @@ func Command() *cli.Command {
 	}
 }
 
-func policy(coauthors *[]string, useAll *bool) enable.Policy {
+// rolesFromFlags the role assignments in the shape <role>=<identity>, as given via --role or the flags named after a role
+func rolesFromFlags(c *cli.Context) []string {
+	roles := []string{}
+	for _, theRole := range role.All() {
+		for _, identity := range c.StringSlice(string(theRole)) {
+			roles = append(roles, role.Assignment{Role: theRole, Identity: identity}.String())
+		}
+	}
+	return append(roles, c.StringSlice("role")...)
+}
+
+// PolicyFactory the enable policy constructor along with the matching event mapper, for enabling the given co-authors from another command while honoring --dry-run
+func PolicyFactory(c *cli.Context) (func(coauthors *[]string) enable.Policy, func(events.Event) effects.Effect) {
+	useAll := false
+	roles := []string{}
+
+	if dryrun.IsRequested(c) {
+		recorder := dryrun.NewRecorder()
+		return func(coauthors *[]string) enable.Policy {
+			return dryRunPolicy(coauthors, &useAll, &roles, recorder)
+		}, enableeventadapter.MapDryRunEventToEffectFactory(recorder)
+	}
+
+	return func(coauthors *[]string) enable.Policy {
+		return policy(coauthors, &useAll, &roles)
+	}, enableeventadapter.MapEventToEffectFactory(statuscmdmapper.Policy())
+}
+
+func policy(coauthors *[]string, useAll *bool, roles *[]string) enable.Policy {
 	return enable.Policy{
 		Req: enable.Request{
 			AliasesAndCoauthors: coauthors,
 			UseAll:              useAll,
+			Roles:               roles,
 		},
 		Deps: enable.Dependencies{
 			SanityCheckCoauthors: validation.SanityCheckCoauthors,
@@ -55,6 +103,7 @@ func policy(coauthors *[]string, useAll *bool) enable.Policy {
 			WriteTemplateFile:    ioutil.WriteFile,
 			CreateHooksDir:       os.MkdirAll,
 			WriteHookFile:        ioutil.WriteFile,
+			ReadFile:             ioutil.ReadFile,
 			Lstat:                os.Lstat,
 			Remove:               os.Remove,
 			Symlink:              os.Symlink,
@@ -65,8 +114,34 @@ func policy(coauthors *[]string, useAll *bool) enable.Policy {
 			ConfigReader:         configds.NewGitconfigDataSource(gitconfig.NewDataSource()),
 			StateWriter:          state.NewGitConfigDataSink(gitconfig.NewDataSink()),
 			GetEnv:               os.Getenv,
-			GetWd:                os.Getwd,
+			GetGitCommonDir:      gitrepo.CommonDir,
 			ActivationValidator:  activation.NewGitConfigDataSource(gitconfig.NewDataSource()),
+			TemplateIndexWriter:  templateindex.NewFileDataSink(templateIndexPath(), ioutil.ReadFile, ioutil.WriteFile),
+			SessionLogWriter:     sessionlog.NewFileDataSink(sessionlog.Path(os.Getenv("HOME")), sessionlog.AppendFile),
+			Now:                  time.Now,
 		},
 	}
 }
+
+func dryRunPolicy(coauthors *[]string, useAll *bool, roles *[]string, recorder dryrun.Recorder) enable.Policy {
+	fs := dryrun.NewFileSystem(recorder)
+	gitConfigWriter := dryrun.NewGitConfigWriter(recorder, os.Getenv("HOME"))
+
+	enablePolicy := policy(coauthors, useAll, roles)
+	enablePolicy.Deps.CreateTemplateDir = fs.MkdirAll
+	enablePolicy.Deps.WriteTemplateFile = fs.WriteFile
+	enablePolicy.Deps.CreateHooksDir = fs.MkdirAll
+	enablePolicy.Deps.WriteHookFile = fs.WriteFile
+	enablePolicy.Deps.Remove = fs.Remove
+	enablePolicy.Deps.Symlink = fs.Symlink
+	enablePolicy.Deps.GitConfigWriter = gitConfigWriter
+	enablePolicy.Deps.StateWriter = state.NewGitConfigDataSink(gitConfigWriter)
+	enablePolicy.Deps.TemplateIndexWriter = templateindex.NewFileDataSink(templateIndexPath(), ioutil.ReadFile, fs.WriteFile)
+	enablePolicy.Deps.SessionLogWriter = sessionlog.NewFileDataSink(sessionlog.Path(os.Getenv("HOME")), fs.AppendFile)
+
+	return enablePolicy
+}
+
+func templateIndexPath() string {
+	return templateindex.Path(commitsettingsds.NewStaticValueDataSource().Read().TemplatesBaseDir)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hekmekk/git-team/src/core/events"
 	"github.com/hekmekk/git-team/src/core/policy"
 	"github.com/hekmekk/git-team/src/shared/cli/effects"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
 )
 
 // MapEventToEffectFactory convert enable events to effects for the cli
@@ -26,6 +27,20 @@ func MapEventToEffectFactory(statusPolicy policy.Policy) func(events.Event) effe
 	}
 }
 
+// MapDryRunEventToEffectFactory convert enable events to effects for the cli when running with --dry-run
+func MapDryRunEventToEffectFactory(recorder dryrun.Recorder) func(events.Event) effects.Effect {
+	return func(event events.Event) effects.Effect {
+		switch evt := event.(type) {
+		case enable.Succeeded, enable.Aborted:
+			return dryrun.MapOperationsToEffect(recorder.Operations())
+		case enable.Failed:
+			return effects.NewExitErrMsg(foldErrors(evt.Reason))
+		default:
+			return effects.NewExitOk()
+		}
+	}
+}
+
 func foldErrors(validationErrors []error) error {
 	var buffer bytes.Buffer
 	for _, err := range validationErrors {
@@ -12,6 +12,8 @@ import (
 	status "github.com/hekmekk/git-team/src/command/status"
 	"github.com/hekmekk/git-team/src/core/events"
 	"github.com/hekmekk/git-team/src/shared/cli/effects"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
+	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
 	state "github.com/hekmekk/git-team/src/shared/state/entity"
 )
 
@@ -124,3 +126,30 @@ func TestMapEventToEffectUnknownEvent(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestMapDryRunEventToEffectSucceeded(t *testing.T) {
+	recorder := dryrun.NewRecorder()
+	dryrun.NewGitConfigWriter(recorder, "/home/some-user").ReplaceAll(gitconfigscope.Global, "core.hooksPath", "/path/to/hooks")
+
+	expectedEffect := dryrun.MapOperationsToEffect([]string{"git config --global --replace-all core.hooksPath /path/to/hooks"})
+
+	effect := MapDryRunEventToEffectFactory(recorder)(enable.Succeeded{})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapDryRunEventToEffectFailed(t *testing.T) {
+	err := errors.New("failure")
+
+	expectedEffect := effects.NewExitErrMsg(err)
+
+	effect := MapDryRunEventToEffectFactory(dryrun.NewRecorder())(enable.Failed{Reason: []error{err}})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
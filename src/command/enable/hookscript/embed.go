@@ -13,4 +13,10 @@ var (
 
 	//go:embed prepare-commit-msg-git-team.sh
 	PrepareCommitMsgGitTeam string
+
+	//go:embed commit-msg.sh
+	CommitMsg string
+
+	//go:embed run-hook-chain.sh
+	RunHookChain string
 )
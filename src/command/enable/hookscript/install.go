@@ -0,0 +1,73 @@
+package hookscript
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// FileSystem the file system operations needed to install the hooks
+type FileSystem struct {
+	CreateDir func(path string, perm os.FileMode) error
+	WriteFile func(path string, data []byte, mode os.FileMode) error
+	Lstat     func(path string) (os.FileInfo, error)
+	Remove    func(path string) error
+	Symlink   func(realPath string, linkPath string) error
+}
+
+// proxiedGitHooks the git hooks which git-team merely forwards to the hooks chain
+var proxiedGitHooks = []string{"applypatch-msg", "fsmonitor-watchman", "p4-pre-submit", "post-applypatch", "post-checkout", "post-commit", "post-index-change", "post-merge", "post-receive", "post-rewrite", "post-update", "pre-applypatch", "pre-auto-gc", "pre-commit", "pre-push", "pre-rebase", "pre-receive", "push-to-checkout", "sendemail-validate", "update"}
+
+// Install write the hooks of git-team along with the proxies for all other git hooks to hooksDir
+func Install(fs FileSystem, hooksDir string) error {
+	if err := fs.CreateDir(hooksDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	if err := fs.WriteFile(filepath.Join(hooksDir, "proxy.sh"), []byte(Proxy), 0755); err != nil {
+		return err
+	}
+
+	if err := fs.WriteFile(filepath.Join(hooksDir, "prepare-commit-msg"), []byte(PrepareCommitMsg), 0755); err != nil {
+		return err
+	}
+
+	if err := fs.WriteFile(filepath.Join(hooksDir, "prepare-commit-msg-git-team.sh"), []byte(PrepareCommitMsgGitTeam), 0755); err != nil {
+		return err
+	}
+
+	if err := fs.WriteFile(filepath.Join(hooksDir, "run-hook-chain.sh"), []byte(RunHookChain), 0755); err != nil {
+		return err
+	}
+
+	// commit-msg used to be a symlink to proxy.sh, which must not be written through
+	if err := forceWriteFile(fs, filepath.Join(hooksDir, "commit-msg"), []byte(CommitMsg)); err != nil {
+		return err
+	}
+
+	for _, hook := range proxiedGitHooks {
+		err := forceCreateSymlink(fs, "proxy.sh", filepath.Join(hooksDir, hook))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func forceWriteFile(fs FileSystem, path string, data []byte) error {
+	if _, err := fs.Lstat(path); err == nil {
+		if err := fs.Remove(path); err != nil {
+			return err
+		}
+	}
+	return fs.WriteFile(path, data, 0755)
+}
+
+func forceCreateSymlink(fs FileSystem, realPath string, linkPath string) error {
+	if _, err := fs.Lstat(linkPath); err == nil {
+		if err := fs.Remove(linkPath); err != nil {
+			return err
+		}
+	}
+	return fs.Symlink(realPath, linkPath)
+}
@@ -7,7 +7,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/hekmekk/git-team/src/command/enable/commitsettings/entity"
 	commitsettings "github.com/hekmekk/git-team/src/command/enable/commitsettings/interface"
 	hookscript "github.com/hekmekk/git-team/src/command/enable/hookscript"
 	utils "github.com/hekmekk/git-team/src/command/enable/utils"
@@ -15,10 +19,18 @@ import (
 	activation "github.com/hekmekk/git-team/src/shared/activation/interface"
 	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
 	config "github.com/hekmekk/git-team/src/shared/config/interface"
+	"github.com/hekmekk/git-team/src/shared/displaced"
 	giterror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
 	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/interface"
 	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+	"github.com/hekmekk/git-team/src/shared/role"
+	"github.com/hekmekk/git-team/src/shared/sessionlog"
+	sessionlogentity "github.com/hekmekk/git-team/src/shared/sessionlog/entity"
+	sessionloginterface "github.com/hekmekk/git-team/src/shared/sessionlog/interface"
 	state "github.com/hekmekk/git-team/src/shared/state/interface"
+	templateindexentity "github.com/hekmekk/git-team/src/shared/templateindex/entity"
+	templateindex "github.com/hekmekk/git-team/src/shared/templateindex/interface"
+	"github.com/hekmekk/git-team/src/shared/trailer"
 )
 
 // Dependencies the dependencies of the enable Policy module
@@ -29,6 +41,7 @@ type Dependencies struct {
 	WriteTemplateFile    func(path string, data []byte, mode os.FileMode) error
 	CreateHooksDir       func(path string, perm os.FileMode) error
 	WriteHookFile        func(path string, data []byte, mode os.FileMode) error
+	ReadFile             func(path string) ([]byte, error)
 	Lstat                func(path string) (os.FileInfo, error)
 	Remove               func(path string) error
 	Symlink              func(realPath string, linkPath string) error
@@ -38,14 +51,18 @@ type Dependencies struct {
 	GitConfigReader      gitconfig.Reader
 	StateWriter          state.Writer
 	GetEnv               func(string) string
-	GetWd                func() (string, error)
+	GetGitCommonDir      func() (string, error)
 	ActivationValidator  activation.Validator
+	TemplateIndexWriter  templateindex.Writer
+	SessionLogWriter     sessionloginterface.Writer
+	Now                  func() time.Time
 }
 
 // Request the coauthors with which to enable git-team
 type Request struct {
 	AliasesAndCoauthors *[]string
 	UseAll              *bool
+	Roles               *[]string
 }
 
 // Policy add a <Coauthor> under "team.alias.<Alias>"
@@ -59,6 +76,11 @@ func (policy Policy) Apply() events.Event {
 	deps := policy.Deps
 	req := policy.Req
 
+	roles, errs := resolveRoles(deps, req.Roles)
+	if len(errs) > 0 {
+		return Failed{Reason: errs}
+	}
+
 	var coAuthors []string
 	if *req.UseAll {
 		availableCoauthors, err := lookupAllCoauthors(deps)
@@ -67,7 +89,7 @@ func (policy Policy) Apply() events.Event {
 			return Failed{Reason: []error{fmt.Errorf("failed to lookup coauthors: %s", err)}}
 		}
 
-		if len(availableCoauthors) == 0 {
+		if len(availableCoauthors) == 0 && len(roles) == 0 {
 			return Aborted{}
 		}
 
@@ -76,16 +98,18 @@ func (policy Policy) Apply() events.Event {
 	} else {
 		aliasesAndCoauthors := append(*req.AliasesAndCoauthors) // should be == *req.AliasesAndCoauthors
 
-		if len(aliasesAndCoauthors) == 0 {
+		if len(aliasesAndCoauthors) == 0 && len(roles) == 0 {
 			return Aborted{}
 		}
 
-		coauthors, errs := applyAdditionalGuards(deps, aliasesAndCoauthors)
-		if len(errs) > 0 {
-			return Failed{Reason: errs}
-		}
+		if len(aliasesAndCoauthors) > 0 {
+			coauthors, errs := applyAdditionalGuards(deps, aliasesAndCoauthors)
+			if len(errs) > 0 {
+				return Failed{Reason: errs}
+			}
 
-		coAuthors = removeDuplicates(coauthors)
+			coAuthors = removeDuplicates(coauthors)
+		}
 	}
 
 	settings := deps.CommitSettingsReader.Read()
@@ -101,14 +125,22 @@ func (policy Policy) Apply() events.Event {
 		return Failed{Reason: []error{fmt.Errorf("failed to enable with activation-scope=%s: not inside a git repository", activationScope)}}
 	}
 
-	var gitConfigScope gitconfigscope.Scope
-	if activationScope == activationscope.Global {
-		gitConfigScope = gitconfigscope.Global
-	} else {
-		gitConfigScope = gitconfigscope.Local
+	if activationScope == activationscope.Directory && cfg.ActivationDirectory == "" {
+		return Failed{Reason: []error{fmt.Errorf("failed to enable with activation-scope=%s: activation-directory is not configured", activationScope)}}
+	}
+
+	gitConfigScope := gitconfigscope.FromActivationScope(activationScope)
+
+	if err := backupDisplacedSettings(gitConfigScope, deps, settings); err != nil {
+		return Failed{Reason: []error{fmt.Errorf("failed to backup displaced settings: %s", err)}}
+	}
+
+	templateSource, err := lookupTemplateSource(gitConfigScope, deps, settings)
+	if err != nil {
+		return Failed{Reason: []error{fmt.Errorf("failed to lookup the commit template to compose with: %s", err)}}
 	}
 
-	if err := setupTemplate(gitConfigScope, deps, settings.TemplatesBaseDir, coAuthors); err != nil {
+	if err := setupTemplate(gitConfigScope, deps, settings.TemplatesBaseDir, templateSource, coAuthors, roles, cfg.Trailer()); err != nil {
 		return Failed{Reason: []error{fmt.Errorf("failed to setup commit template: %s", err)}}
 	}
 
@@ -120,10 +152,29 @@ func (policy Policy) Apply() events.Event {
 		return Failed{Reason: []error{fmt.Errorf("failed to set core.hooksPath: %s", err)}}
 	}
 
-	if err := deps.StateWriter.PersistEnabled(cfg.ActivationScope, coAuthors); err != nil {
+	if activationScope == activationscope.Directory {
+		if err := deps.GitConfigWriter.ReplaceAll(gitconfigscope.Global, gitconfigscope.DirectoryIncludeKey(cfg.ActivationDirectory), gitconfigscope.DirectoryConfigFile(deps.GetEnv("HOME"))); err != nil {
+			return Failed{Reason: []error{fmt.Errorf("failed to include config for activation-directory %s: %s", cfg.ActivationDirectory, err)}}
+		}
+	}
+
+	if err := deps.StateWriter.PersistEnabled(cfg.ActivationScope, coAuthors, roles); err != nil {
 		return Failed{Reason: []error{fmt.Errorf("failed to persist state: %s", err)}}
 	}
 
+	sortedCoauthors := append([]string{}, coAuthors...)
+	sort.Strings(sortedCoauthors)
+
+	sessionEvent := sessionlogentity.Event{
+		Time:      deps.Now(),
+		Action:    sessionlogentity.Enabled,
+		Scope:     activationScope,
+		Coauthors: sortedCoauthors,
+	}
+	if err := sessionlog.Record(deps.SessionLogWriter, deps.GetGitCommonDir, sessionEvent); err != nil {
+		return Failed{Reason: []error{fmt.Errorf("failed to record session: %s", err)}}
+	}
+
 	return Succeeded{}
 }
 
@@ -158,69 +209,129 @@ func applyAdditionalGuards(deps Dependencies, aliasesAndCoauthors []string) ([]s
 	return append(coauthorCandidates, resolvedAliases...), []error{}
 }
 
-func removeDuplicates(coauthors []string) []string {
-	var uniqueCoauthors []string
-	temp := make(map[string]bool)
-	for _, coauthor := range coauthors {
-		temp[coauthor] = true
+// resolveRoles parse the role assignments and resolve their identities just like co-authors
+func resolveRoles(deps Dependencies, rawRoles *[]string) ([]role.Assignment, []error) {
+	if rawRoles == nil || len(*rawRoles) == 0 {
+		return nil, nil
 	}
 
-	for coauthor := range temp {
-		uniqueCoauthors = append(uniqueCoauthors, coauthor)
+	assignments := []role.Assignment{}
+	for _, rawRole := range *rawRoles {
+		assignment, err := role.ParseAssignment(rawRole)
+		if err != nil {
+			return nil, []error{err}
+		}
+
+		identities, errs := applyAdditionalGuards(deps, []string{assignment.Identity})
+		if len(errs) > 0 {
+			return nil, errs
+		}
+
+		assignment.Identity = identities[0]
+		assignments = append(assignments, assignment)
 	}
 
-	return uniqueCoauthors
+	return role.Sort(assignments), nil
 }
 
-func installHooks(deps Dependencies, hooksDir string) error {
-	if err := deps.CreateHooksDir(hooksDir, os.ModePerm); err != nil {
-		return err
-	}
+// backupDisplacedSettings remember foreign values of the settings git-team is about to override, so that they can be restored on disable
+func backupDisplacedSettings(gitConfigScope gitconfigscope.Scope, deps Dependencies, settings entity.CommitSettings) error {
+	for _, setting := range displaced.Settings {
+		value, err := deps.GitConfigReader.Get(gitConfigScope, setting.Key)
+		if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+			return fmt.Errorf("failed to get %s: %s", setting.Key, err)
+		}
 
-	if err := deps.WriteHookFile(filepath.Join(hooksDir, "proxy.sh"), []byte(hookscript.Proxy), 0755); err != nil {
-		return err
-	}
+		if value == "" || isOwnedByGitTeam(setting, value, settings) {
+			continue
+		}
 
-	if err := deps.WriteHookFile(filepath.Join(hooksDir, "prepare-commit-msg"), []byte(hookscript.PrepareCommitMsg), 0755); err != nil {
-		return err
+		if err := deps.GitConfigWriter.ReplaceAll(gitConfigScope, setting.BackupKey, value); err != nil {
+			return fmt.Errorf("failed to set %s: %s", setting.BackupKey, err)
+		}
 	}
 
-	if err := deps.WriteHookFile(filepath.Join(hooksDir, "prepare-commit-msg-git-team.sh"), []byte(hookscript.PrepareCommitMsgGitTeam), 0755); err != nil {
-		return err
+	return nil
+}
+
+// lookupTemplateSource find the commit template configured by the user, which the git-team template is composed with
+func lookupTemplateSource(gitConfigScope gitconfigscope.Scope, deps Dependencies, settings entity.CommitSettings) (string, error) {
+	templateSource, err := deps.GitConfigReader.Get(gitConfigScope, displaced.CommitTemplate.BackupKey)
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return "", err
 	}
 
-	proxiedGitHooks := []string{"applypatch-msg", "commit-msg", "fsmonitor-watchman", "p4-pre-submit", "post-applypatch", "post-checkout", "post-commit", "post-index-change", "post-merge", "post-receive", "post-rewrite", "post-update", "pre-applypatch", "pre-auto-gc", "pre-commit", "pre-push", "pre-rebase", "pre-receive", "push-to-checkout", "sendemail-validate", "update"}
+	// the global commit template is shadowed by git-team in any other scope
+	if templateSource == "" && gitConfigScope != gitconfigscope.Global {
+		globalTemplate, err := deps.GitConfigReader.Get(gitconfigscope.Global, displaced.CommitTemplate.Key)
+		if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+			return "", err
+		}
 
-	for _, hook := range proxiedGitHooks {
-		err := forceCreateSymlink(deps, "proxy.sh", filepath.Join(hooksDir, hook))
-		if err != nil {
-			return err
+		if !isOwnedByGitTeam(displaced.CommitTemplate, globalTemplate, settings) {
+			templateSource = globalTemplate
 		}
 	}
 
-	return nil
+	if strings.HasPrefix(templateSource, "~/") {
+		templateSource = filepath.Join(deps.GetEnv("HOME"), strings.TrimPrefix(templateSource, "~/"))
+	}
+
+	return templateSource, nil
 }
 
-func forceCreateSymlink(deps Dependencies, realPath string, linkPath string) error {
-	if _, err := deps.Lstat(linkPath); err == nil {
-		if err := deps.Remove(linkPath); err != nil {
-			return err
-		}
+func isOwnedByGitTeam(setting displaced.Setting, value string, settings entity.CommitSettings) bool {
+	switch setting {
+	case displaced.HooksPath:
+		return value == settings.HooksDir
+	case displaced.CommitTemplate:
+		return strings.HasPrefix(value, settings.TemplatesBaseDir)
+	default:
+		return false
+	}
+}
+
+func removeDuplicates(coauthors []string) []string {
+	var uniqueCoauthors []string
+	temp := make(map[string]bool)
+	for _, coauthor := range coauthors {
+		temp[coauthor] = true
+	}
+
+	for coauthor := range temp {
+		uniqueCoauthors = append(uniqueCoauthors, coauthor)
 	}
-	return deps.Symlink(realPath, linkPath)
+
+	return uniqueCoauthors
 }
 
-func setupTemplate(gitConfigScope gitconfigscope.Scope, deps Dependencies, commitTemplateBaseDir string, uniqueCoauthors []string) error {
+func installHooks(deps Dependencies, hooksDir string) error {
+	return hookscript.Install(hookscript.FileSystem{
+		CreateDir: deps.CreateHooksDir,
+		WriteFile: deps.WriteHookFile,
+		Lstat:     deps.Lstat,
+		Remove:    deps.Remove,
+		Symlink:   deps.Symlink,
+	}, hooksDir)
+}
+
+func setupTemplate(gitConfigScope gitconfigscope.Scope, deps Dependencies, commitTemplateBaseDir string, templateSource string, uniqueCoauthors []string, roles []role.Assignment, trailerFormat trailer.Format) error {
 	var templateDir string
+	var gitCommonDir string
 
-	if gitConfigScope == gitconfigscope.Local {
+	switch gitConfigScope {
+	case gitconfigscope.Local:
 		user := deps.GetEnv("USER")
-		workingDir, err := deps.GetWd()
+		// keyed by the common git dir, so that all worktrees of a repository share the same template
+		var err error
+		gitCommonDir, err = deps.GetGitCommonDir()
 		if err != nil {
 			return err
 		}
-		templateDir = fmt.Sprintf("%s/repo-local/%s", commitTemplateBaseDir, determineRepoChecksum(user, workingDir))
-	} else {
+		templateDir = fmt.Sprintf("%s/repo-local/%s", commitTemplateBaseDir, determineRepoChecksum(user, gitCommonDir))
+	case gitconfigscope.Directory:
+		templateDir = fmt.Sprintf("%s/directory", commitTemplateBaseDir)
+	default:
 		templateDir = fmt.Sprintf("%s/global", commitTemplateBaseDir)
 	}
 
@@ -230,7 +341,22 @@ func setupTemplate(gitConfigScope gitconfigscope.Scope, deps Dependencies, commi
 
 	commitTemplatePath := fmt.Sprintf("%s/COMMIT_TEMPLATE", templateDir)
 
-	if err := deps.WriteTemplateFile(commitTemplatePath, []byte(utils.PrepareForCommitMessage(uniqueCoauthors)), 0644); err != nil {
+	template := utils.PrepareForCommitMessage(uniqueCoauthors, roles, trailerFormat)
+
+	if templateSource != "" {
+		original, err := deps.ReadFile(templateSource)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		if err != nil {
+			templateSource = ""
+		} else {
+			template = utils.ComposeWithTemplate(string(original), uniqueCoauthors, roles, trailerFormat)
+		}
+	}
+
+	if err := deps.WriteTemplateFile(commitTemplatePath, []byte(template), 0644); err != nil {
 		return err
 	}
 
@@ -238,6 +364,24 @@ func setupTemplate(gitConfigScope gitconfigscope.Scope, deps Dependencies, commi
 		return err
 	}
 
+	// the prepare-commit-msg hook regenerates the template from its source once the source changes
+	if templateSource != "" {
+		if err := deps.GitConfigWriter.ReplaceAll(gitConfigScope, "team.state.template-source", templateSource); err != nil {
+			return err
+		}
+	} else {
+		if err := deps.GitConfigWriter.UnsetAll(gitConfigScope, "team.state.template-source"); err != nil && !errors.Is(err, giterror.ErrTryingToUnsetAnOptionWhichDoesNotExist) {
+			return err
+		}
+	}
+
+	// repo-local templates are tracked, so that they can be garbage collected once their repository is gone
+	if gitConfigScope == gitconfigscope.Local {
+		if err := deps.TemplateIndexWriter.Add(templateindexentity.Entry{TemplateDir: templateDir, GitDir: gitCommonDir}); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
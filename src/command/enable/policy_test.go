@@ -7,20 +7,28 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	commitsettings "github.com/hekmekk/git-team/src/command/enable/commitsettings/entity"
 	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
 	config "github.com/hekmekk/git-team/src/shared/config/entity/config"
 	gitconfigerror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
 	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+	"github.com/hekmekk/git-team/src/shared/role"
+	sessionlog "github.com/hekmekk/git-team/src/shared/sessionlog/entity"
+	templateindex "github.com/hekmekk/git-team/src/shared/templateindex/entity"
 )
 
 type gitConfigReaderMock struct {
+	get       func(gitconfigscope.Scope, string) (string, error)
 	getRegexp func(gitconfigscope.Scope, string) (map[string]string, error)
 }
 
 func (mock gitConfigReaderMock) Get(scope gitconfigscope.Scope, key string) (string, error) {
-	return "", nil
+	if mock.get == nil {
+		return "", nil
+	}
+	return mock.get(scope, key)
 }
 
 func (mock gitConfigReaderMock) GetAll(scope gitconfigscope.Scope, key string) ([]string, error) {
@@ -69,15 +77,41 @@ func (mock gitConfigWriterMock) Add(scope gitconfigscope.Scope, key string, valu
 
 type stateWriterMock struct {
 	persistEnabled func(activationscope.Scope, []string) error
+	persistRoles   func([]role.Assignment) error
 }
 
-func (mock stateWriterMock) PersistEnabled(scope activationscope.Scope, coauthors []string) error {
+func (mock stateWriterMock) PersistEnabled(scope activationscope.Scope, coauthors []string, roles []role.Assignment) error {
+	if mock.persistRoles != nil {
+		if err := mock.persistRoles(roles); err != nil {
+			return err
+		}
+	}
 	return mock.persistEnabled(scope, coauthors)
 }
 func (mock stateWriterMock) PersistDisabled(scope activationscope.Scope) error {
 	return nil
 }
 
+type templateIndexWriterMock struct {
+	add func(templateindex.Entry) error
+}
+
+func (mock templateIndexWriterMock) Add(entry templateindex.Entry) error {
+	return mock.add(entry)
+}
+
+func (mock templateIndexWriterMock) Remove(templateDir string) error {
+	return nil
+}
+
+type sessionLogWriterMock struct {
+	append func(sessionlog.Event) error
+}
+
+func (mock sessionLogWriterMock) Append(event sessionlog.Event) error {
+	return mock.append(event)
+}
+
 type activationValidatorMock struct {
 	isInsideAGitRepository func() bool
 }
@@ -135,6 +169,7 @@ func defaultDeps() Dependencies {
 		WriteTemplateFile:    func(string, []byte, os.FileMode) error { return nil },
 		CreateHooksDir:       func(string, os.FileMode) error { return nil },
 		WriteHookFile:        func(string, []byte, os.FileMode) error { return nil },
+		ReadFile:             func(string) ([]byte, error) { return nil, os.ErrNotExist },
 		Lstat:                func(string) (os.FileInfo, error) { return nil, nil },
 		Remove:               func(string) error { return nil },
 		Symlink:              func(string, string) error { return nil },
@@ -144,8 +179,11 @@ func defaultDeps() Dependencies {
 		GitConfigReader:      gitConfigReader,
 		StateWriter:          stateWriter,
 		GetEnv:               func(string) string { return "someone" },
-		GetWd:                func() (string, error) { return "/path/to/repo", nil },
+		GetGitCommonDir:      func() (string, error) { return "/path/to/repo/.git", nil },
 		ActivationValidator:  activationValidator,
+		TemplateIndexWriter:  &templateIndexWriterMock{add: func(templateindex.Entry) error { return nil }},
+		SessionLogWriter:     &sessionLogWriterMock{append: func(sessionlog.Event) error { return nil }},
+		Now:                  func() time.Time { return time.Date(2021, 6, 7, 9, 0, 0, 0, time.UTC) },
 	}
 
 	return deps
@@ -173,8 +211,8 @@ func TestEnableSucceeds(t *testing.T) {
 	expectedCommitTemplateCoauthors := "\n\nCo-authored-by: Mr. Noujz <noujz@mr.se>\nCo-authored-by: Mrs. Noujz <noujz@mrs.se>"
 
 	user := "someone"
-	pathToRepo := "/path/to/repo"
-	repoChecksum := "a05b508ef212dc640aced4037a66021d" // echo -n <user>:<pathToRepo> | md5sum | awk '{ print $1 }'
+	pathToRepo := "/path/to/repo/.git"
+	repoChecksum := "67c3f25dedf9754c5d9026abd08e6482" // echo -n <user>:<pathToRepo> | md5sum | awk '{ print $1 }'
 
 	commitSettings := commitsettings.CommitSettings{TemplatesBaseDir: "/path/to/commit-templates", HooksDir: "/path/to/hooks"}
 
@@ -201,7 +239,7 @@ func TestEnableSucceeds(t *testing.T) {
 		return user
 	}
 
-	deps.GetWd = func() (string, error) {
+	deps.GetGitCommonDir = func() (string, error) {
 		return pathToRepo, nil
 	}
 
@@ -389,7 +427,7 @@ func TestEnableKeepsSeparateCommitTemplatesPerUserAndRepository(t *testing.T) {
 			deps.GetEnv = func(string) string {
 				return user
 			}
-			deps.GetWd = func() (string, error) {
+			deps.GetGitCommonDir = func() (string, error) {
 				return pathToRepo, nil
 			}
 
@@ -543,10 +581,10 @@ func TestEnableFailsWhenNotInsideAGitRepository(t *testing.T) {
 	}
 }
 
-func TestEnableFailsDueToGetWdErr(t *testing.T) {
+func TestEnableFailsDueToGetGitCommonDirErr(t *testing.T) {
 	coauthors := []string{"Mr. Noujz <noujz@mr.se>"}
 
-	getWdErr := errors.New("failed to get working dir")
+	getGitCommonDirErr := errors.New("failed to get common git dir")
 
 	deps := defaultDeps()
 
@@ -556,13 +594,13 @@ func TestEnableFailsDueToGetWdErr(t *testing.T) {
 		},
 	}
 
-	deps.GetWd = func() (string, error) {
-		return "", getWdErr
+	deps.GetGitCommonDir = func() (string, error) {
+		return "", getGitCommonDirErr
 	}
 
 	req := Request{AliasesAndCoauthors: &coauthors, UseAll: &[]bool{false}[0]}
 
-	expectedEvent := Failed{Reason: []error{fmt.Errorf("failed to setup commit template: %s", getWdErr)}}
+	expectedEvent := Failed{Reason: []error{fmt.Errorf("failed to setup commit template: %s", getGitCommonDirErr)}}
 
 	event := Policy{deps, req}.Apply()
 
@@ -672,7 +710,7 @@ func TestEnableFailsDueToCreateHookFileErr(t *testing.T) {
 
 	expectedEvent := Failed{Reason: []error{fmt.Errorf("failed to install hooks: %s", createHooksFileErr)}}
 
-	for _, hookFileNameLoopVar := range []string{"proxy.sh", "prepare-commit-msg", "prepare-commit-msg-git-team.sh"} {
+	for _, hookFileNameLoopVar := range []string{"proxy.sh", "prepare-commit-msg", "prepare-commit-msg-git-team.sh", "run-hook-chain.sh", "commit-msg"} {
 		hookFileName := hookFileNameLoopVar
 		t.Run(hookFileName, func(t *testing.T) {
 			t.Parallel()
@@ -785,3 +823,657 @@ func TestEnableFailsDueToSaveStatusErr(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestEnableSucceedsWithActivationScopeDirectory(t *testing.T) {
+	coauthors := &[]string{"Mr. Noujz <noujz@mr.se>"}
+
+	home := "/home/someone"
+	expectedTemplateDir := "/path/to/commit-templates/directory"
+	expectedIncludeKey := "includeIf.gitdir:~/work/.path"
+	expectedIncludePath := "/home/someone/.git-team/directory.gitconfig"
+
+	deps := defaultDeps()
+
+	deps.ConfigReader = &configReaderMock{
+		read: func() (config.Config, error) {
+			return config.Config{ActivationScope: activationscope.Directory, ActivationDirectory: "~/work/"}, nil
+		},
+	}
+
+	deps.GetEnv = func(variable string) string {
+		if variable != "HOME" {
+			t.Errorf("unexpected env lookup: %s", variable)
+			t.Fail()
+		}
+		return home
+	}
+
+	deps.CreateTemplateDir = func(path string, _ os.FileMode) error {
+		if path != expectedTemplateDir {
+			t.Errorf("wrong path to template dir, expected: %s, got: %s", expectedTemplateDir, path)
+			t.Fail()
+		}
+		return nil
+	}
+
+	includeWritten := false
+	deps.GitConfigWriter = &gitConfigWriterMock{
+		replaceAll: func(scope gitconfigscope.Scope, key string, value string) error {
+			switch key {
+			case "commit.template", "core.hooksPath":
+				if scope != gitconfigscope.Directory {
+					t.Errorf("wrong scope, expected: %s, got: %s", gitconfigscope.Directory, scope)
+					t.Fail()
+				}
+			case expectedIncludeKey:
+				if scope != gitconfigscope.Global {
+					t.Errorf("wrong scope, expected: %s, got: %s", gitconfigscope.Global, scope)
+					t.Fail()
+				}
+				if value != expectedIncludePath {
+					t.Errorf("wrong include path, expected: %s, got: %s", expectedIncludePath, value)
+					t.Fail()
+				}
+				includeWritten = true
+			default:
+				t.Errorf("wrong key: %s", key)
+				t.Fail()
+			}
+			return nil
+		},
+	}
+
+	deps.StateWriter = &stateWriterMock{
+		persistEnabled: func(scope activationscope.Scope, _ []string) error {
+			if scope != activationscope.Directory {
+				t.Errorf("wrong scope, expected: %s, got: %s", activationscope.Directory, scope)
+				t.Fail()
+			}
+			return nil
+		},
+	}
+
+	req := Request{AliasesAndCoauthors: coauthors, UseAll: &[]bool{false}[0]}
+
+	expectedEvent := Succeeded{}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !includeWritten {
+		t.Error("expected the activation-directory to be included")
+		t.Fail()
+	}
+}
+
+func TestEnableFailsWhenActivationDirectoryIsNotConfigured(t *testing.T) {
+	coauthors := &[]string{"Mr. Noujz <noujz@mr.se>"}
+
+	deps := defaultDeps()
+
+	deps.ConfigReader = &configReaderMock{
+		read: func() (config.Config, error) {
+			return config.Config{ActivationScope: activationscope.Directory}, nil
+		},
+	}
+
+	req := Request{AliasesAndCoauthors: coauthors, UseAll: &[]bool{false}[0]}
+
+	expectedEvent := Failed{Reason: []error{errors.New("failed to enable with activation-scope=directory: activation-directory is not configured")}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestEnableShouldBackupDisplacedSettings(t *testing.T) {
+	deps := defaultDeps()
+
+	deps.GitConfigReader = &gitConfigReaderMock{
+		get: func(_ gitconfigscope.Scope, key string) (string, error) {
+			switch key {
+			case "core.hooksPath":
+				return "/path/to/foreign/hooks", nil
+			case "commit.template":
+				return "/path/to/foreign/template", nil
+			case "team.displaced.commit-template":
+				return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+			default:
+				return "", fmt.Errorf("wrong key: %s", key)
+			}
+		},
+	}
+
+	backups := make(map[string]string)
+	deps.GitConfigWriter = &gitConfigWriterMock{
+		replaceAll: func(_ gitconfigscope.Scope, key string, value string) error {
+			if strings.HasPrefix(key, "team.displaced.") {
+				backups[key] = value
+			}
+			return nil
+		},
+	}
+
+	req := Request{AliasesAndCoauthors: &[]string{"mrs"}, UseAll: &[]bool{false}[0]}
+
+	expectedBackups := map[string]string{
+		"team.displaced.hooks-path":      "/path/to/foreign/hooks",
+		"team.displaced.commit-template": "/path/to/foreign/template",
+	}
+
+	expectedEvent := Succeeded{}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedBackups, backups) {
+		t.Errorf("expected: %s, got: %s", expectedBackups, backups)
+		t.Fail()
+	}
+}
+
+func TestEnableShouldNotBackupSettingsOwnedByGitTeam(t *testing.T) {
+	deps := defaultDeps()
+
+	deps.GitConfigReader = &gitConfigReaderMock{
+		get: func(_ gitconfigscope.Scope, key string) (string, error) {
+			switch key {
+			case "core.hooksPath":
+				return "/path/to/hooks", nil
+			case "commit.template":
+				return "/path/to/commit-templates/global/COMMIT_TEMPLATE", nil
+			case "team.displaced.commit-template":
+				return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+			default:
+				return "", fmt.Errorf("wrong key: %s", key)
+			}
+		},
+	}
+
+	deps.GitConfigWriter = &gitConfigWriterMock{
+		replaceAll: func(_ gitconfigscope.Scope, key string, _ string) error {
+			if strings.HasPrefix(key, "team.displaced.") {
+				t.Errorf("unexpected backup: %s", key)
+				t.Fail()
+			}
+			return nil
+		},
+	}
+
+	req := Request{AliasesAndCoauthors: &[]string{"mrs"}, UseAll: &[]bool{false}[0]}
+
+	expectedEvent := Succeeded{}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestEnableShouldFailWhenReadingDisplacedSettingFails(t *testing.T) {
+	deps := defaultDeps()
+
+	deps.GitConfigReader = &gitConfigReaderMock{
+		get: func(_ gitconfigscope.Scope, key string) (string, error) {
+			return "", gitconfigerror.ErrConfigFileIsInvalid
+		},
+	}
+
+	req := Request{AliasesAndCoauthors: &[]string{"mrs"}, UseAll: &[]bool{false}[0]}
+
+	expectedEvent := Failed{Reason: []error{fmt.Errorf("failed to backup displaced settings: failed to get core.hooksPath: %s", gitconfigerror.ErrConfigFileIsInvalid)}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestEnableShouldAddRepoLocalTemplatesToTheIndex(t *testing.T) {
+	deps := defaultDeps()
+
+	deps.ConfigReader = &configReaderMock{
+		read: func() (config.Config, error) {
+			return config.Config{ActivationScope: activationscope.RepoLocal}, nil
+		},
+	}
+
+	expectedEntry := templateindex.Entry{
+		TemplateDir: "/path/to/commit-templates/repo-local/67c3f25dedf9754c5d9026abd08e6482",
+		GitDir:      "/path/to/repo/.git",
+	}
+
+	var entry templateindex.Entry
+	deps.TemplateIndexWriter = &templateIndexWriterMock{
+		add: func(e templateindex.Entry) error {
+			entry = e
+			return nil
+		},
+	}
+
+	req := Request{AliasesAndCoauthors: &[]string{"mrs"}, UseAll: &[]bool{false}[0]}
+
+	expectedEvent := Succeeded{}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedEntry, entry) {
+		t.Errorf("expected: %s, got: %s", expectedEntry, entry)
+		t.Fail()
+	}
+}
+
+func TestEnableFailsWhenAddingTheTemplateToTheIndexFails(t *testing.T) {
+	deps := defaultDeps()
+
+	deps.ConfigReader = &configReaderMock{
+		read: func() (config.Config, error) {
+			return config.Config{ActivationScope: activationscope.RepoLocal}, nil
+		},
+	}
+
+	indexErr := errors.New("failed to write index")
+	deps.TemplateIndexWriter = &templateIndexWriterMock{
+		add: func(templateindex.Entry) error {
+			return indexErr
+		},
+	}
+
+	req := Request{AliasesAndCoauthors: &[]string{"mrs"}, UseAll: &[]bool{false}[0]}
+
+	expectedEvent := Failed{Reason: []error{fmt.Errorf("failed to setup commit template: %s", indexErr)}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestEnableShouldRecordTheSession(t *testing.T) {
+	deps := defaultDeps()
+
+	var event sessionlog.Event
+	deps.SessionLogWriter = &sessionLogWriterMock{
+		append: func(e sessionlog.Event) error {
+			event = e
+			return nil
+		},
+	}
+
+	req := Request{AliasesAndCoauthors: &[]string{"mrs", "Mr. Noujz <noujz@mr.se>"}, UseAll: &[]bool{false}[0]}
+
+	expectedSessionEvent := sessionlog.Event{
+		Time:       time.Date(2021, 6, 7, 9, 0, 0, 0, time.UTC),
+		Action:     sessionlog.Enabled,
+		Scope:      activationscope.Global,
+		Repository: "",
+		Coauthors:  []string{"Mr. Noujz <noujz@mr.se>", "Mrs. Noujz <noujz@mrs.se>"},
+	}
+
+	Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedSessionEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedSessionEvent, event)
+		t.Fail()
+	}
+}
+
+func TestEnableShouldRecordARepoLocalSessionWithTheCommonGitDir(t *testing.T) {
+	deps := defaultDeps()
+
+	deps.ConfigReader = &configReaderMock{
+		read: func() (config.Config, error) {
+			return config.Config{ActivationScope: activationscope.RepoLocal}, nil
+		},
+	}
+
+	var event sessionlog.Event
+	deps.SessionLogWriter = &sessionLogWriterMock{
+		append: func(e sessionlog.Event) error {
+			event = e
+			return nil
+		},
+	}
+
+	req := Request{AliasesAndCoauthors: &[]string{"mrs"}, UseAll: &[]bool{false}[0]}
+
+	Policy{deps, req}.Apply()
+
+	if event.Repository != "/path/to/repo/.git" {
+		t.Errorf("expected: %s, got: %s", "/path/to/repo/.git", event.Repository)
+		t.Fail()
+	}
+}
+
+func TestEnableFailsWhenRecordingTheSessionFails(t *testing.T) {
+	deps := defaultDeps()
+
+	sessionLogErr := errors.New("failed to write session log")
+	deps.SessionLogWriter = &sessionLogWriterMock{
+		append: func(sessionlog.Event) error {
+			return sessionLogErr
+		},
+	}
+
+	req := Request{AliasesAndCoauthors: &[]string{"mrs"}, UseAll: &[]bool{false}[0]}
+
+	expectedEvent := Failed{Reason: []error{fmt.Errorf("failed to record session: %s", sessionLogErr)}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestEnableShouldComposeTheTemplateWithTheDisplacedTemplate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		activationScope activationscope.Scope
+		gitConfigScope  gitconfigscope.Scope
+		templateKey     string
+		templateSource  string
+		expectedSource  string
+	}{
+		{activationscope.Global, gitconfigscope.Global, "team.displaced.commit-template", "/path/to/company/template", "/path/to/company/template"},
+		{activationscope.RepoLocal, gitconfigscope.Local, "team.displaced.commit-template", "/path/to/company/template", "/path/to/company/template"},
+		{activationscope.RepoLocal, gitconfigscope.Global, "commit.template", "~/company/template", "/home/someone/company/template"},
+	}
+
+	for _, caseLoopVar := range cases {
+		activationScope := caseLoopVar.activationScope
+		sourceGitConfigScope := caseLoopVar.gitConfigScope
+		templateKey := caseLoopVar.templateKey
+		templateSource := caseLoopVar.templateSource
+		expectedSource := caseLoopVar.expectedSource
+
+		t.Run(fmt.Sprintf("%s:%s", activationScope, templateKey), func(t *testing.T) {
+			t.Parallel()
+
+			deps := defaultDeps()
+
+			deps.ConfigReader = &configReaderMock{
+				read: func() (config.Config, error) {
+					return config.Config{ActivationScope: activationScope}, nil
+				},
+			}
+
+			deps.GetEnv = func(key string) string {
+				if key == "HOME" {
+					return "/home/someone"
+				}
+				return "someone"
+			}
+
+			deps.GitConfigReader = &gitConfigReaderMock{
+				get: func(scope gitconfigscope.Scope, key string) (string, error) {
+					if scope == sourceGitConfigScope && key == templateKey {
+						return templateSource, nil
+					}
+					return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+				},
+			}
+
+			deps.ReadFile = func(path string) ([]byte, error) {
+				if path != expectedSource {
+					return nil, fmt.Errorf("wrong path: %s", path)
+				}
+				return []byte("Ticket: \n\n# checklist\n"), nil
+			}
+
+			expectedTemplate := "Ticket: \n\n# checklist\n\nCo-authored-by: Mrs. Noujz <noujz@mrs.se>"
+
+			var template string
+			deps.WriteTemplateFile = func(_ string, data []byte, _ os.FileMode) error {
+				template = string(data)
+				return nil
+			}
+
+			var recordedSource string
+			deps.GitConfigWriter = &gitConfigWriterMock{
+				replaceAll: func(_ gitconfigscope.Scope, key string, value string) error {
+					if key == "team.state.template-source" {
+						recordedSource = value
+					}
+					return nil
+				},
+			}
+
+			req := Request{AliasesAndCoauthors: &[]string{"mrs"}, UseAll: &[]bool{false}[0]}
+
+			expectedEvent := Succeeded{}
+
+			event := Policy{deps, req}.Apply()
+
+			if !reflect.DeepEqual(expectedEvent, event) {
+				t.Errorf("expected: %s, got: %s", expectedEvent, event)
+				t.Fail()
+			}
+
+			if expectedTemplate != template {
+				t.Errorf("expected: %s, got: %s", expectedTemplate, template)
+				t.Fail()
+			}
+
+			if expectedSource != recordedSource {
+				t.Errorf("expected: %s, got: %s", expectedSource, recordedSource)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestEnableShouldNotComposeTheTemplateWhenTheDisplacedTemplateDoesNotExist(t *testing.T) {
+	deps := defaultDeps()
+
+	deps.GitConfigReader = &gitConfigReaderMock{
+		get: func(_ gitconfigscope.Scope, key string) (string, error) {
+			if key == "team.displaced.commit-template" {
+				return "/path/to/missing/template", nil
+			}
+			return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+	}
+
+	expectedTemplate := "\n\nCo-authored-by: Mrs. Noujz <noujz@mrs.se>"
+
+	var template string
+	deps.WriteTemplateFile = func(_ string, data []byte, _ os.FileMode) error {
+		template = string(data)
+		return nil
+	}
+
+	deps.GitConfigWriter = &gitConfigWriterMock{
+		replaceAll: func(_ gitconfigscope.Scope, key string, value string) error {
+			if key == "team.state.template-source" {
+				t.Errorf("unexpected template source: %s", value)
+				t.Fail()
+			}
+			return nil
+		},
+	}
+
+	req := Request{AliasesAndCoauthors: &[]string{"mrs"}, UseAll: &[]bool{false}[0]}
+
+	expectedEvent := Succeeded{}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if expectedTemplate != template {
+		t.Errorf("expected: %s, got: %s", expectedTemplate, template)
+		t.Fail()
+	}
+}
+
+func TestEnableFailsWhenReadingTheDisplacedTemplateFails(t *testing.T) {
+	deps := defaultDeps()
+
+	deps.GitConfigReader = &gitConfigReaderMock{
+		get: func(_ gitconfigscope.Scope, key string) (string, error) {
+			if key == "team.displaced.commit-template" {
+				return "/path/to/company/template", nil
+			}
+			return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+	}
+
+	readErr := errors.New("permission denied")
+	deps.ReadFile = func(string) ([]byte, error) {
+		return nil, readErr
+	}
+
+	req := Request{AliasesAndCoauthors: &[]string{"mrs"}, UseAll: &[]bool{false}[0]}
+
+	expectedEvent := Failed{Reason: []error{fmt.Errorf("failed to setup commit template: %s", readErr)}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestEnableShouldUseTheConfiguredTrailerFormat(t *testing.T) {
+	deps := defaultDeps()
+
+	deps.ConfigReader = &configReaderMock{
+		read: func() (config.Config, error) {
+			return config.Config{ActivationScope: activationscope.Global, TrailerKey: "Pair", TrailerFormat: "{key} → {value}"}, nil
+		},
+	}
+
+	expectedTemplate := "\n\nPair → Mrs. Noujz <noujz@mrs.se>"
+
+	var template string
+	deps.WriteTemplateFile = func(_ string, data []byte, _ os.FileMode) error {
+		template = string(data)
+		return nil
+	}
+
+	req := Request{AliasesAndCoauthors: &[]string{"mrs"}, UseAll: &[]bool{false}[0]}
+
+	expectedEvent := Succeeded{}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if expectedTemplate != template {
+		t.Errorf("expected: %s, got: %s", expectedTemplate, template)
+		t.Fail()
+	}
+}
+
+func TestEnableShouldPersistAndRenderRoles(t *testing.T) {
+	deps := defaultDeps()
+
+	expectedTemplate := "\n\nCo-authored-by: Mr. Noujz <noujz@mr.se>\nReviewed-by: Mrs. Noujz <noujz@mrs.se>"
+	expectedRoles := []role.Assignment{{Role: role.Reviewer, Identity: "Mrs. Noujz <noujz@mrs.se>"}}
+
+	var template string
+	deps.WriteTemplateFile = func(_ string, data []byte, _ os.FileMode) error {
+		template = string(data)
+		return nil
+	}
+
+	deps.GitResolveAliases = func(aliases []string) ([]string, []error) {
+		resolved := []string{}
+		for range aliases {
+			resolved = append(resolved, "Mrs. Noujz <noujz@mrs.se>")
+		}
+		return resolved, []error{}
+	}
+
+	var roles []role.Assignment
+	deps.StateWriter = &stateWriterMock{
+		persistEnabled: func(activationscope.Scope, []string) error { return nil },
+		persistRoles: func(persistedRoles []role.Assignment) error {
+			roles = persistedRoles
+			return nil
+		},
+	}
+
+	req := Request{AliasesAndCoauthors: &[]string{"Mr. Noujz <noujz@mr.se>"}, UseAll: &[]bool{false}[0], Roles: &[]string{"reviewer=mrs"}}
+
+	expectedEvent := Succeeded{}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if expectedTemplate != template {
+		t.Errorf("expected: %s, got: %s", expectedTemplate, template)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedRoles, roles) {
+		t.Errorf("expected: %s, got: %s", expectedRoles, roles)
+		t.Fail()
+	}
+}
+
+func TestEnableSucceedsWithRolesOnly(t *testing.T) {
+	deps := defaultDeps()
+
+	req := Request{AliasesAndCoauthors: &[]string{}, UseAll: &[]bool{false}[0], Roles: &[]string{"navigator=Mr. Noujz <noujz@mr.se>"}}
+
+	expectedEvent := Succeeded{}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestEnableFailsDueToUnknownRole(t *testing.T) {
+	deps := defaultDeps()
+
+	req := Request{AliasesAndCoauthors: &[]string{"mrs"}, UseAll: &[]bool{false}[0], Roles: &[]string{"tester=mrs"}}
+
+	expectedEvent := Failed{Reason: []error{errors.New("unknown role 'tester', expected one of: navigator, reviewer")}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
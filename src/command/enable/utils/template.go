@@ -2,14 +2,16 @@ package enableutils
 
 import (
 	"bytes"
-	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/hekmekk/git-team/src/shared/role"
+	"github.com/hekmekk/git-team/src/shared/trailer"
 )
 
-// PrepareForCommitMessage create string from coauthors to write to a commit template
-func PrepareForCommitMessage(coauthors []string) string {
-	if len(coauthors) == 0 {
+// PrepareForCommitMessage create string from coauthors and roles to write to a commit template
+func PrepareForCommitMessage(coauthors []string, roles []role.Assignment, format trailer.Format) string {
+	if len(coauthors) == 0 && len(roles) == 0 {
 		return ""
 	}
 
@@ -18,11 +20,19 @@ func PrepareForCommitMessage(coauthors []string) string {
 	var buffer bytes.Buffer
 	buffer.WriteString("\n\n")
 	for _, coauthor := range coauthors {
-		buffer.WriteString(toLine(coauthor))
+		buffer.WriteString(toLine(coauthor, format))
+	}
+	for _, assignment := range role.Sort(roles) {
+		buffer.WriteString(toLine(assignment.Identity, format.WithKey(assignment.Role.TrailerKey())))
 	}
 	return strings.TrimRight(buffer.String(), "\n")
 }
 
-func toLine(coauthor string) string {
-	return fmt.Sprintf("Co-authored-by: %s\n", coauthor)
+// ComposeWithTemplate append the co-authors and roles to the content of an existing commit template
+func ComposeWithTemplate(template string, coauthors []string, roles []role.Assignment, format trailer.Format) string {
+	return strings.TrimRight(template, "\n") + PrepareForCommitMessage(coauthors, roles, format)
+}
+
+func toLine(coauthor string, format trailer.Format) string {
+	return format.Line(coauthor) + "\n"
 }
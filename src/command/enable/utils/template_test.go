@@ -4,12 +4,15 @@ import (
 	"strings"
 	"testing"
 	"testing/quick"
+
+	"github.com/hekmekk/git-team/src/shared/role"
+	"github.com/hekmekk/git-team/src/shared/trailer"
 )
 
 func TestToLine(t *testing.T) {
 	t.SkipNow()
 	toLineGen := func(coauthor string) bool {
-		if coAuthorLine := toLine(coauthor); strings.HasPrefix(coAuthorLine, "Co-authored-by: ") && strings.HasSuffix(coAuthorLine, "\n") {
+		if coAuthorLine := toLine(coauthor, trailer.Default()); strings.HasPrefix(coAuthorLine, "Co-authored-by: ") && strings.HasSuffix(coAuthorLine, "\n") {
 			return true
 		}
 		return false
@@ -22,7 +25,7 @@ func TestToLine(t *testing.T) {
 func TestPrepareForCommitMessageNoAuthors(t *testing.T) {
 	coAuthors := []string{}
 
-	coauthorsString := PrepareForCommitMessage(coAuthors)
+	coauthorsString := PrepareForCommitMessage(coAuthors, nil, trailer.Default())
 
 	if coauthorsString != "" {
 		t.Fail()
@@ -34,7 +37,7 @@ func TestPrepareForCommitMessageOneAuthor(t *testing.T) {
 
 	expectedCoauthorsString := "\n\nCo-authored-by: Mr. Noujz <noujz@mr.se>"
 
-	coauthorsString := PrepareForCommitMessage(coAuthors)
+	coauthorsString := PrepareForCommitMessage(coAuthors, nil, trailer.Default())
 
 	if expectedCoauthorsString != coauthorsString {
 		t.Errorf("expected: [%s], got: [%s]", expectedCoauthorsString, coauthorsString)
@@ -47,10 +50,80 @@ func TestPrepareForCommitMessageMultipleAuthors(t *testing.T) {
 
 	expectedCoauthorsString := "\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>"
 
-	coauthorsString := PrepareForCommitMessage(coAuthors)
+	coauthorsString := PrepareForCommitMessage(coAuthors, nil, trailer.Default())
+
+	if expectedCoauthorsString != coauthorsString {
+		t.Errorf("expected: [%s], got: [%s]", expectedCoauthorsString, coauthorsString)
+		t.Fail()
+	}
+}
+
+func TestPrepareForCommitMessageWithCustomTrailerFormat(t *testing.T) {
+	coAuthors := []string{"B <b@x.y>", "A <a@x.y>"}
+
+	expectedCoauthorsString := "\n\nPair → A <a@x.y>\nPair → B <b@x.y>"
+
+	coauthorsString := PrepareForCommitMessage(coAuthors, nil, trailer.NewFormat("Pair", "{key} → {value}"))
+
+	if expectedCoauthorsString != coauthorsString {
+		t.Errorf("expected: [%s], got: [%s]", expectedCoauthorsString, coauthorsString)
+		t.Fail()
+	}
+}
+
+func TestPrepareForCommitMessageWithRoles(t *testing.T) {
+	coAuthors := []string{"A <a@x.y>"}
+	roles := []role.Assignment{
+		{Role: role.Reviewer, Identity: "C <c@x.y>"},
+		{Role: role.Navigator, Identity: "B <b@x.y>"},
+	}
+
+	expectedCoauthorsString := "\n\nCo-authored-by: A <a@x.y>\nNavigated-by: B <b@x.y>\nReviewed-by: C <c@x.y>"
+
+	coauthorsString := PrepareForCommitMessage(coAuthors, roles, trailer.Default())
 
 	if expectedCoauthorsString != coauthorsString {
 		t.Errorf("expected: [%s], got: [%s]", expectedCoauthorsString, coauthorsString)
 		t.Fail()
 	}
 }
+
+func TestPrepareForCommitMessageWithRolesOnly(t *testing.T) {
+	roles := []role.Assignment{{Role: role.Reviewer, Identity: "C <c@x.y>"}}
+
+	expectedCoauthorsString := "\n\nReviewed-by: C <c@x.y>"
+
+	coauthorsString := PrepareForCommitMessage([]string{}, roles, trailer.Default())
+
+	if expectedCoauthorsString != coauthorsString {
+		t.Errorf("expected: [%s], got: [%s]", expectedCoauthorsString, coauthorsString)
+		t.Fail()
+	}
+}
+
+func TestComposeWithTemplate(t *testing.T) {
+	template := "Ticket: \n\n# - [ ] tests\n# - [ ] docs\n\n"
+	coAuthors := []string{"B <b@x.y>", "A <a@x.y>"}
+
+	expectedTemplate := "Ticket: \n\n# - [ ] tests\n# - [ ] docs\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>"
+
+	composedTemplate := ComposeWithTemplate(template, coAuthors, nil, trailer.Default())
+
+	if expectedTemplate != composedTemplate {
+		t.Errorf("expected: [%s], got: [%s]", expectedTemplate, composedTemplate)
+		t.Fail()
+	}
+}
+
+func TestComposeWithEmptyTemplate(t *testing.T) {
+	coAuthors := []string{"A <a@x.y>"}
+
+	expectedTemplate := "\n\nCo-authored-by: A <a@x.y>"
+
+	composedTemplate := ComposeWithTemplate("", coAuthors, nil, trailer.Default())
+
+	if expectedTemplate != composedTemplate {
+		t.Errorf("expected: [%s], got: [%s]", expectedTemplate, composedTemplate)
+		t.Fail()
+	}
+}
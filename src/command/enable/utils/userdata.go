@@ -19,3 +19,28 @@ func Partition(userProvidedData []string) ([]string, []string) {
 
 	return coauthorCandidates, aliasCandidates
 }
+
+// ResolveCoauthors resolve the aliases and check the co-authors, which are given as "Name <email>", dropping duplicates
+func ResolveCoauthors(sanityCheckCoauthors func([]string) []error, resolveAliases func([]string) ([]string, []error), aliasesAndCoauthors []string) ([]string, []error) {
+	coauthorCandidates, aliases := Partition(aliasesAndCoauthors)
+
+	if errs := sanityCheckCoauthors(coauthorCandidates); len(errs) > 0 {
+		return nil, errs
+	}
+
+	resolvedAliases, errs := resolveAliases(aliases)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	seen := map[string]bool{}
+	coauthors := []string{}
+	for _, coauthor := range append(coauthorCandidates, resolvedAliases...) {
+		if !seen[coauthor] {
+			seen[coauthor] = true
+			coauthors = append(coauthors, coauthor)
+		}
+	}
+
+	return coauthors, nil
+}
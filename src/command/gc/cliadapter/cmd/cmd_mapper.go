@@ -0,0 +1,70 @@
+package gccmdadapter
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	commitsettingsds "github.com/hekmekk/git-team/src/command/enable/commitsettings/datasource"
+	"github.com/hekmekk/git-team/src/command/gc"
+	gceventadapter "github.com/hekmekk/git-team/src/command/gc/cliadapter/event"
+	commandadapter "github.com/hekmekk/git-team/src/shared/cli/commandadapter"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
+	"github.com/hekmekk/git-team/src/shared/gitrepo"
+	templateindex "github.com/hekmekk/git-team/src/shared/templateindex/impl"
+)
+
+// Command the gc command
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "gc",
+		Usage: "Remove repo-local commit templates whose repository no longer exists or no longer has git-team enabled and report those which are not tracked",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "remove-untracked", Value: false, Usage: "Remove the commit templates which are not tracked as well, unless a known commit.template still refers to them"},
+			dryrun.Flag(),
+		},
+		Action: func(c *cli.Context) error {
+			removeUntracked := c.Bool("remove-untracked")
+
+			if dryrun.IsRequested(c) {
+				recorder := dryrun.NewRecorder()
+				return commandadapter.Run(dryRunPolicy(&removeUntracked, recorder), gceventadapter.MapDryRunEventToEffectFactory(recorder))
+			}
+
+			return commandadapter.Run(policy(&removeUntracked), gceventadapter.MapEventToEffect)
+		},
+	}
+}
+
+func policy(removeUntracked *bool) gc.Policy {
+	templatesBaseDir := commitsettingsds.NewStaticValueDataSource().Read().TemplatesBaseDir
+	indexPath := templateindex.Path(templatesBaseDir)
+
+	return gc.Policy{
+		Req: gc.Request{
+			RemoveUntracked: removeUntracked,
+		},
+		Deps: gc.Dependencies{
+			TemplateIndexReader:     templateindex.NewFileDataSource(indexPath, ioutil.ReadFile),
+			TemplateIndexWriter:     templateindex.NewFileDataSink(indexPath, ioutil.ReadFile, ioutil.WriteFile),
+			TemplatesBaseDir:        templatesBaseDir,
+			ReadDir:                 ioutil.ReadDir,
+			StatFile:                os.Stat,
+			RemoveFile:              os.RemoveAll,
+			GetRepoConfigValue:      gitrepo.ConfigValue,
+			GetEffectiveConfigValue: gitrepo.EffectiveConfigValue,
+		},
+	}
+}
+
+func dryRunPolicy(removeUntracked *bool, recorder dryrun.Recorder) gc.Policy {
+	fs := dryrun.NewFileSystem(recorder)
+	indexPath := templateindex.Path(commitsettingsds.NewStaticValueDataSource().Read().TemplatesBaseDir)
+
+	gcPolicy := policy(removeUntracked)
+	gcPolicy.Deps.TemplateIndexWriter = templateindex.NewFileDataSink(indexPath, ioutil.ReadFile, fs.WriteFile)
+	gcPolicy.Deps.RemoveFile = fs.RemoveAll
+
+	return gcPolicy
+}
@@ -0,0 +1,67 @@
+package gceventadapter
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/hekmekk/git-team/src/command/gc"
+	"github.com/hekmekk/git-team/src/core/events"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
+)
+
+// MapEventToEffect convert gc events to effects for the cli
+func MapEventToEffect(event events.Event) effects.Effect {
+	switch evt := event.(type) {
+	case gc.Succeeded:
+		return effects.NewExitOkMsg(toString(evt.Removed, evt.Kept))
+	case gc.Failed:
+		return effects.NewExitErrMsg(evt.Reason)
+	default:
+		return effects.NewExitOk()
+	}
+}
+
+// MapDryRunEventToEffectFactory convert gc events to effects for the cli when running with --dry-run
+func MapDryRunEventToEffectFactory(recorder dryrun.Recorder) func(events.Event) effects.Effect {
+	return func(event events.Event) effects.Effect {
+		switch evt := event.(type) {
+		case gc.Succeeded:
+			return effects.NewExitOkMsg(toString(evt.Removed, evt.Kept) + "\n\n" + dryrun.FormatOperations(recorder.Operations()))
+		default:
+			return MapEventToEffect(event)
+		}
+	}
+}
+
+func toString(removed []gc.StaleTemplate, kept []gc.StaleTemplate) string {
+	if len(removed) == 0 && len(kept) == 0 {
+		return color.CyanString("no stale commit templates found")
+	}
+
+	sections := []string{}
+	if len(removed) > 0 {
+		sections = append(sections, section("stale commit templates", removed))
+	}
+	if len(kept) > 0 {
+		sections = append(sections, section("untracked commit templates", kept))
+	}
+
+	return strings.Join(sections, "\n")
+}
+
+func section(title string, templates []gc.StaleTemplate) string {
+	var buffer bytes.Buffer
+	buffer.WriteString(color.New(color.FgBlue).Add(color.Bold).Sprint(title))
+	for _, template := range templates {
+		if template.Entry.GitDir == "" {
+			buffer.WriteString(color.WhiteString("\n─ %s (%s)", template.Entry.TemplateDir, template.Reason))
+			continue
+		}
+		buffer.WriteString(color.WhiteString("\n─ %s (%s: %s)", template.Entry.TemplateDir, template.Reason, template.Entry.GitDir))
+	}
+
+	return buffer.String()
+}
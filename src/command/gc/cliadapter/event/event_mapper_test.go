@@ -0,0 +1,100 @@
+package gceventadapter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hekmekk/git-team/src/command/gc"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
+	templateindex "github.com/hekmekk/git-team/src/shared/templateindex/entity"
+)
+
+var removed = []gc.StaleTemplate{
+	{Entry: templateindex.Entry{TemplateDir: "/path/to/templates/a", GitDir: "/path/to/a/.git"}, Reason: "repository no longer exists"},
+	{Entry: templateindex.Entry{TemplateDir: "/path/to/templates/b"}, Reason: "not tracked in the index"},
+}
+
+var kept = []gc.StaleTemplate{
+	{Entry: templateindex.Entry{TemplateDir: "/path/to/templates/c"}, Reason: "not tracked in the index, remove it with --remove-untracked"},
+}
+
+func TestMapEventToEffectSucceeded(t *testing.T) {
+	msg := "stale commit templates\n─ /path/to/templates/a (repository no longer exists: /path/to/a/.git)\n─ /path/to/templates/b (not tracked in the index)"
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffect(gc.Succeeded{Removed: removed, Kept: []gc.StaleTemplate{}})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectSucceededWithUntrackedTemplates(t *testing.T) {
+	msg := "untracked commit templates\n─ /path/to/templates/c (not tracked in the index, remove it with --remove-untracked)"
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffect(gc.Succeeded{Removed: []gc.StaleTemplate{}, Kept: kept})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectSucceededWithoutRemovals(t *testing.T) {
+	msg := "no stale commit templates found"
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffect(gc.Succeeded{Removed: []gc.StaleTemplate{}, Kept: []gc.StaleTemplate{}})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectFailed(t *testing.T) {
+	err := errors.New("failure")
+
+	expectedEffect := effects.NewExitErrMsg(err)
+
+	effect := MapEventToEffect(gc.Failed{Reason: err})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectUnknownEvent(t *testing.T) {
+	expectedEffect := effects.NewExitOk()
+
+	effect := MapEventToEffect("UNKNOWN_EVENT")
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapDryRunEventToEffectSucceeded(t *testing.T) {
+	recorder := dryrun.NewRecorder()
+	dryrun.NewFileSystem(recorder).RemoveAll("/path/to/templates/a")
+
+	msg := "stale commit templates\n─ /path/to/templates/a (repository no longer exists: /path/to/a/.git)\n─ /path/to/templates/b (not tracked in the index)\n\ndry-run: operations\n─ rm -r /path/to/templates/a"
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapDryRunEventToEffectFactory(recorder)(gc.Succeeded{Removed: removed, Kept: []gc.StaleTemplate{}})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
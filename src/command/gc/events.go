@@ -0,0 +1,22 @@
+package gc
+
+import (
+	templateindex "github.com/hekmekk/git-team/src/shared/templateindex/entity"
+)
+
+// StaleTemplate a repo-local commit template which is no longer in use
+type StaleTemplate struct {
+	Entry  templateindex.Entry
+	Reason string
+}
+
+// Succeeded stale commit templates have been removed, Kept being the templates which are not tracked but have not been removed
+type Succeeded struct {
+	Removed []StaleTemplate
+	Kept    []StaleTemplate
+}
+
+// Failed failed to remove stale commit templates
+type Failed struct {
+	Reason error
+}
@@ -0,0 +1,174 @@
+package gc
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hekmekk/git-team/src/core/events"
+	giterror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
+	templateindexentity "github.com/hekmekk/git-team/src/shared/templateindex/entity"
+	templateindex "github.com/hekmekk/git-team/src/shared/templateindex/interface"
+)
+
+// Dependencies the dependencies of the gc Policy module
+type Dependencies struct {
+	TemplateIndexReader     templateindex.Reader
+	TemplateIndexWriter     templateindex.Writer
+	TemplatesBaseDir        string
+	ReadDir                 func(string) ([]os.FileInfo, error)
+	StatFile                func(string) (os.FileInfo, error)
+	RemoveFile              func(string) error
+	GetRepoConfigValue      func(gitDir string, key string) (string, error)
+	GetEffectiveConfigValue func(key string) (string, error)
+}
+
+// Request whether to remove the templates which are not tracked in the index as well
+type Request struct {
+	RemoveUntracked *bool
+}
+
+// Policy the policy to apply
+type Policy struct {
+	Deps Dependencies
+	Req  Request
+}
+
+const repositoryIsGone = "repository no longer exists"
+
+// Apply remove repo-local commit templates whose repository no longer exists or no longer has git-team enabled. Templates which are not tracked in the index are reported, and only removed on request if no known commit.template refers to them.
+func (policy Policy) Apply() events.Event {
+	deps := policy.Deps
+	req := policy.Req
+
+	entries, err := deps.TemplateIndexReader.Read()
+	if err != nil {
+		return Failed{Reason: fmt.Errorf("failed to read commit template index: %s", err)}
+	}
+
+	removed := []StaleTemplate{}
+	kept := []StaleTemplate{}
+	indexed := map[string]bool{}
+	existingGitDirs := []string{}
+
+	for _, entry := range entries {
+		indexed[filepath.Clean(entry.TemplateDir)] = true
+
+		reason, err := determineStaleness(deps, entry)
+		if err != nil {
+			return Failed{Reason: fmt.Errorf("failed to inspect repository %s: %s", entry.GitDir, err)}
+		}
+
+		if reason != repositoryIsGone {
+			existingGitDirs = append(existingGitDirs, entry.GitDir)
+		}
+
+		if reason == "" {
+			continue
+		}
+
+		if err := deps.RemoveFile(entry.TemplateDir); err != nil {
+			return Failed{Reason: fmt.Errorf("failed to remove commit template %s: %s", entry.TemplateDir, err)}
+		}
+
+		if err := deps.TemplateIndexWriter.Remove(entry.TemplateDir); err != nil {
+			return Failed{Reason: fmt.Errorf("failed to remove commit template %s from index: %s", entry.TemplateDir, err)}
+		}
+
+		removed = append(removed, StaleTemplate{Entry: entry, Reason: reason})
+	}
+
+	// templates created before the index existed, or orphaned by a change of their path, are not tracked
+	repoLocalDir := filepath.Join(deps.TemplatesBaseDir, "repo-local")
+	files, err := deps.ReadDir(repoLocalDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Failed{Reason: fmt.Errorf("failed to list commit templates in %s: %s", repoLocalDir, err)}
+	}
+
+	untracked := []string{}
+	for _, file := range files {
+		templateDir := filepath.Join(repoLocalDir, file.Name())
+		if file.IsDir() && !indexed[templateDir] {
+			untracked = append(untracked, templateDir)
+		}
+	}
+
+	if len(untracked) == 0 {
+		return Succeeded{Removed: removed, Kept: kept}
+	}
+
+	if !*req.RemoveUntracked {
+		for _, templateDir := range untracked {
+			kept = append(kept, StaleTemplate{Entry: templateindexentity.Entry{TemplateDir: templateDir}, Reason: "not tracked in the index, remove it with --remove-untracked"})
+		}
+		return Succeeded{Removed: removed, Kept: kept}
+	}
+
+	// the repository of an untracked template is unknown, so it is checked against every commit.template git-team knows of
+	users, err := findTemplateUsers(deps, existingGitDirs)
+	if err != nil {
+		return Failed{Reason: err}
+	}
+
+	for _, templateDir := range untracked {
+		if gitDir, isInUse := users[templateDir]; isInUse {
+			kept = append(kept, StaleTemplate{Entry: templateindexentity.Entry{TemplateDir: templateDir, GitDir: gitDir}, Reason: "not tracked in the index, but still referred to by commit.template"})
+			continue
+		}
+
+		if err := deps.RemoveFile(templateDir); err != nil {
+			return Failed{Reason: fmt.Errorf("failed to remove commit template %s: %s", templateDir, err)}
+		}
+
+		removed = append(removed, StaleTemplate{Entry: templateindexentity.Entry{TemplateDir: templateDir}, Reason: "not tracked in the index"})
+	}
+
+	return Succeeded{Removed: removed, Kept: kept}
+}
+
+// the template dirs referred to by commit.template, mapped to the git dir of the referring repository, or to "" for the config in effect in the current working directory
+func findTemplateUsers(deps Dependencies, gitDirs []string) (map[string]string, error) {
+	users := map[string]string{}
+
+	template, err := deps.GetEffectiveConfigValue("commit.template")
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return nil, fmt.Errorf("failed to read commit.template: %s", err)
+	}
+	if template != "" {
+		users[filepath.Dir(filepath.Clean(template))] = ""
+	}
+
+	for _, gitDir := range gitDirs {
+		template, err := deps.GetRepoConfigValue(gitDir, "commit.template")
+		if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+			return nil, fmt.Errorf("failed to read commit.template of repository %s: %s", gitDir, err)
+		}
+		if template != "" {
+			users[filepath.Dir(filepath.Clean(template))] = gitDir
+		}
+	}
+
+	return users, nil
+}
+
+// an empty reason means that the template is still in use
+func determineStaleness(deps Dependencies, entry templateindexentity.Entry) (string, error) {
+	if _, err := deps.StatFile(entry.GitDir); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return repositoryIsGone, nil
+		}
+		return "", err
+	}
+
+	status, err := deps.GetRepoConfigValue(entry.GitDir, "team.state.status")
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return "", err
+	}
+
+	if status != "enabled" {
+		return "git-team is no longer enabled", nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,461 @@
+package gc
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	gitconfigerror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
+	templateindex "github.com/hekmekk/git-team/src/shared/templateindex/entity"
+)
+
+type templateIndexReaderMock struct {
+	read func() ([]templateindex.Entry, error)
+}
+
+func (mock templateIndexReaderMock) Read() ([]templateindex.Entry, error) {
+	return mock.read()
+}
+
+type templateIndexWriterMock struct {
+	remove func(string) error
+}
+
+func (mock templateIndexWriterMock) Add(entry templateindex.Entry) error {
+	return nil
+}
+
+func (mock templateIndexWriterMock) Remove(templateDir string) error {
+	return mock.remove(templateDir)
+}
+
+type fileInfoMock struct {
+	name  string
+	isDir bool
+}
+
+func (mock fileInfoMock) Name() string       { return mock.name }
+func (mock fileInfoMock) Size() int64        { return 0 }
+func (mock fileInfoMock) Mode() os.FileMode  { return 0 }
+func (mock fileInfoMock) ModTime() time.Time { return time.Time{} }
+func (mock fileInfoMock) IsDir() bool        { return mock.isDir }
+func (mock fileInfoMock) Sys() interface{}   { return nil }
+
+var (
+	fileInfo os.FileInfo
+
+	templatesBaseDir = "/path/to/commit-templates"
+
+	keepUntracked   = false
+	removeUntracked = true
+
+	goneEntry     = templateindex.Entry{TemplateDir: "/path/to/commit-templates/repo-local/gone", GitDir: "/path/to/gone/.git"}
+	disabledEntry = templateindex.Entry{TemplateDir: "/path/to/commit-templates/repo-local/disabled", GitDir: "/path/to/disabled/.git"}
+	unsetEntry    = templateindex.Entry{TemplateDir: "/path/to/commit-templates/repo-local/unset", GitDir: "/path/to/unset/.git"}
+	enabledEntry  = templateindex.Entry{TemplateDir: "/path/to/commit-templates/repo-local/enabled", GitDir: "/path/to/enabled/.git"}
+)
+
+func defaultDeps() Dependencies {
+	return Dependencies{
+		TemplateIndexReader: &templateIndexReaderMock{
+			read: func() ([]templateindex.Entry, error) {
+				return []templateindex.Entry{goneEntry, disabledEntry, unsetEntry, enabledEntry}, nil
+			},
+		},
+		TemplateIndexWriter: &templateIndexWriterMock{
+			remove: func(string) error { return nil },
+		},
+		TemplatesBaseDir: templatesBaseDir,
+		ReadDir: func(dir string) ([]os.FileInfo, error) {
+			if dir != templatesBaseDir+"/repo-local" {
+				return nil, fmt.Errorf("wrong dir: %s", dir)
+			}
+			return []os.FileInfo{
+				fileInfoMock{name: "index"},
+				fileInfoMock{name: "gone", isDir: true},
+				fileInfoMock{name: "disabled", isDir: true},
+				fileInfoMock{name: "unset", isDir: true},
+				fileInfoMock{name: "enabled", isDir: true},
+			}, nil
+		},
+		StatFile: func(path string) (os.FileInfo, error) {
+			if path == goneEntry.GitDir {
+				return nil, os.ErrNotExist
+			}
+			return fileInfo, nil
+		},
+		RemoveFile: func(string) error { return nil },
+		GetRepoConfigValue: func(gitDir string, key string) (string, error) {
+			if key == "commit.template" {
+				return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+			}
+			if key != "team.state.status" {
+				return "", fmt.Errorf("wrong key: %s", key)
+			}
+			switch gitDir {
+			case disabledEntry.GitDir:
+				return "disabled", nil
+			case enabledEntry.GitDir:
+				return "enabled", nil
+			default:
+				return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+			}
+		},
+		GetEffectiveConfigValue: func(key string) (string, error) {
+			return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+	}
+}
+
+func TestGcShouldRemoveStaleTemplates(t *testing.T) {
+	deps := defaultDeps()
+
+	removedFiles := []string{}
+	deps.RemoveFile = func(path string) error {
+		removedFiles = append(removedFiles, path)
+		return nil
+	}
+
+	removedEntries := []string{}
+	deps.TemplateIndexWriter = &templateIndexWriterMock{
+		remove: func(templateDir string) error {
+			removedEntries = append(removedEntries, templateDir)
+			return nil
+		},
+	}
+
+	expectedRemovals := []string{goneEntry.TemplateDir, disabledEntry.TemplateDir, unsetEntry.TemplateDir}
+
+	expectedEvent := Succeeded{Removed: []StaleTemplate{
+		{Entry: goneEntry, Reason: "repository no longer exists"},
+		{Entry: disabledEntry, Reason: "git-team is no longer enabled"},
+		{Entry: unsetEntry, Reason: "git-team is no longer enabled"},
+	}, Kept: []StaleTemplate{}}
+
+	event := Policy{Deps: deps, Req: Request{RemoveUntracked: &keepUntracked}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedRemovals, removedFiles) {
+		t.Errorf("expected: %s, got: %s", expectedRemovals, removedFiles)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedRemovals, removedEntries) {
+		t.Errorf("expected: %s, got: %s", expectedRemovals, removedEntries)
+		t.Fail()
+	}
+}
+
+func TestGcShouldOnlyReportTemplatesWhichAreNotTrackedInTheIndex(t *testing.T) {
+	deps := defaultDeps()
+
+	deps.TemplateIndexReader = &templateIndexReaderMock{
+		read: func() ([]templateindex.Entry, error) {
+			return []templateindex.Entry{enabledEntry}, nil
+		},
+	}
+
+	deps.ReadDir = func(string) ([]os.FileInfo, error) {
+		return []os.FileInfo{
+			fileInfoMock{name: "index"},
+			fileInfoMock{name: "enabled", isDir: true},
+			fileInfoMock{name: "orphan", isDir: true},
+		}, nil
+	}
+
+	removedFiles := []string{}
+	deps.RemoveFile = func(path string) error {
+		removedFiles = append(removedFiles, path)
+		return nil
+	}
+
+	expectedEvent := Succeeded{
+		Removed: []StaleTemplate{},
+		Kept: []StaleTemplate{
+			{Entry: templateindex.Entry{TemplateDir: "/path/to/commit-templates/repo-local/orphan"}, Reason: "not tracked in the index, remove it with --remove-untracked"},
+		},
+	}
+
+	event := Policy{Deps: deps, Req: Request{RemoveUntracked: &keepUntracked}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if len(removedFiles) != 0 {
+		t.Errorf("expected no removals, got: %s", removedFiles)
+		t.Fail()
+	}
+}
+
+func TestGcShouldRemoveTemplatesWhichAreNotTrackedInTheIndexOnRequestUnlessTheyAreStillReferredTo(t *testing.T) {
+	deps := defaultDeps()
+
+	deps.TemplateIndexReader = &templateIndexReaderMock{
+		read: func() ([]templateindex.Entry, error) {
+			return []templateindex.Entry{goneEntry, enabledEntry}, nil
+		},
+	}
+
+	deps.ReadDir = func(string) ([]os.FileInfo, error) {
+		return []os.FileInfo{
+			fileInfoMock{name: "index"},
+			fileInfoMock{name: "enabled", isDir: true},
+			fileInfoMock{name: "orphan", isDir: true},
+			fileInfoMock{name: "used-by-enabled", isDir: true},
+			fileInfoMock{name: "used-here", isDir: true},
+		}, nil
+	}
+
+	deps.GetRepoConfigValue = func(gitDir string, key string) (string, error) {
+		if gitDir != enabledEntry.GitDir {
+			return "", fmt.Errorf("unexpected repository: %s", gitDir)
+		}
+		switch key {
+		case "team.state.status":
+			return "enabled", nil
+		case "commit.template":
+			return "/path/to/commit-templates/repo-local/used-by-enabled/COMMIT_TEMPLATE", nil
+		default:
+			return "", fmt.Errorf("wrong key: %s", key)
+		}
+	}
+
+	deps.GetEffectiveConfigValue = func(key string) (string, error) {
+		if key != "commit.template" {
+			return "", fmt.Errorf("wrong key: %s", key)
+		}
+		return "/path/to/commit-templates/repo-local/used-here/COMMIT_TEMPLATE", nil
+	}
+
+	removedFiles := []string{}
+	deps.RemoveFile = func(path string) error {
+		removedFiles = append(removedFiles, path)
+		return nil
+	}
+
+	removedEntries := []string{}
+	deps.TemplateIndexWriter = &templateIndexWriterMock{
+		remove: func(templateDir string) error {
+			removedEntries = append(removedEntries, templateDir)
+			return nil
+		},
+	}
+
+	orphan := "/path/to/commit-templates/repo-local/orphan"
+
+	expectedEvent := Succeeded{
+		Removed: []StaleTemplate{
+			{Entry: goneEntry, Reason: "repository no longer exists"},
+			{Entry: templateindex.Entry{TemplateDir: orphan}, Reason: "not tracked in the index"},
+		},
+		Kept: []StaleTemplate{
+			{Entry: templateindex.Entry{TemplateDir: "/path/to/commit-templates/repo-local/used-by-enabled", GitDir: enabledEntry.GitDir}, Reason: "not tracked in the index, but still referred to by commit.template"},
+			{Entry: templateindex.Entry{TemplateDir: "/path/to/commit-templates/repo-local/used-here"}, Reason: "not tracked in the index, but still referred to by commit.template"},
+		},
+	}
+
+	event := Policy{Deps: deps, Req: Request{RemoveUntracked: &removeUntracked}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	expectedRemovals := []string{goneEntry.TemplateDir, orphan}
+	if !reflect.DeepEqual(expectedRemovals, removedFiles) {
+		t.Errorf("expected: %s, got: %s", expectedRemovals, removedFiles)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual([]string{goneEntry.TemplateDir}, removedEntries) {
+		t.Errorf("expected: %s, got: %s", []string{goneEntry.TemplateDir}, removedEntries)
+		t.Fail()
+	}
+}
+
+func TestGcShouldFailWhenReadingTheCommitTemplateFails(t *testing.T) {
+	deps := defaultDeps()
+
+	deps.TemplateIndexReader = &templateIndexReaderMock{
+		read: func() ([]templateindex.Entry, error) {
+			return []templateindex.Entry{}, nil
+		},
+	}
+
+	deps.ReadDir = func(string) ([]os.FileInfo, error) {
+		return []os.FileInfo{fileInfoMock{name: "orphan", isDir: true}}, nil
+	}
+
+	deps.GetEffectiveConfigValue = func(string) (string, error) {
+		return "", gitconfigerror.ErrConfigFileIsInvalid
+	}
+
+	expectedEvent := Failed{Reason: fmt.Errorf("failed to read commit.template: %s", gitconfigerror.ErrConfigFileIsInvalid)}
+
+	event := Policy{Deps: deps, Req: Request{RemoveUntracked: &removeUntracked}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestGcShouldSucceedWhenThereAreNoRepoLocalTemplates(t *testing.T) {
+	deps := defaultDeps()
+
+	deps.TemplateIndexReader = &templateIndexReaderMock{
+		read: func() ([]templateindex.Entry, error) {
+			return []templateindex.Entry{}, nil
+		},
+	}
+
+	deps.ReadDir = func(string) ([]os.FileInfo, error) {
+		return nil, os.ErrNotExist
+	}
+
+	expectedEvent := Succeeded{Removed: []StaleTemplate{}, Kept: []StaleTemplate{}}
+
+	event := Policy{Deps: deps, Req: Request{RemoveUntracked: &keepUntracked}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestGcShouldFailWhenListingTheTemplatesFails(t *testing.T) {
+	deps := defaultDeps()
+
+	deps.TemplateIndexReader = &templateIndexReaderMock{
+		read: func() ([]templateindex.Entry, error) {
+			return []templateindex.Entry{enabledEntry}, nil
+		},
+	}
+
+	err := errors.New("permission denied")
+	deps.ReadDir = func(string) ([]os.FileInfo, error) {
+		return nil, err
+	}
+
+	expectedEvent := Failed{Reason: fmt.Errorf("failed to list commit templates in /path/to/commit-templates/repo-local: %s", err)}
+
+	event := Policy{Deps: deps, Req: Request{RemoveUntracked: &keepUntracked}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestGcShouldSucceedWhenThereIsNothingToRemove(t *testing.T) {
+	deps := defaultDeps()
+
+	deps.TemplateIndexReader = &templateIndexReaderMock{
+		read: func() ([]templateindex.Entry, error) {
+			return []templateindex.Entry{enabledEntry}, nil
+		},
+	}
+
+	deps.ReadDir = func(string) ([]os.FileInfo, error) {
+		return []os.FileInfo{fileInfoMock{name: "index"}, fileInfoMock{name: "enabled", isDir: true}}, nil
+	}
+
+	expectedEvent := Succeeded{Removed: []StaleTemplate{}, Kept: []StaleTemplate{}}
+
+	event := Policy{Deps: deps, Req: Request{RemoveUntracked: &keepUntracked}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestGcShouldFailWhenReadingTheIndexFails(t *testing.T) {
+	deps := defaultDeps()
+
+	err := errors.New("failed to read index")
+	deps.TemplateIndexReader = &templateIndexReaderMock{
+		read: func() ([]templateindex.Entry, error) {
+			return nil, err
+		},
+	}
+
+	expectedEvent := Failed{Reason: fmt.Errorf("failed to read commit template index: %s", err)}
+
+	event := Policy{Deps: deps, Req: Request{RemoveUntracked: &keepUntracked}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestGcShouldFailWhenReadingTheRepoConfigFails(t *testing.T) {
+	deps := defaultDeps()
+
+	deps.TemplateIndexReader = &templateIndexReaderMock{
+		read: func() ([]templateindex.Entry, error) {
+			return []templateindex.Entry{enabledEntry}, nil
+		},
+	}
+
+	deps.GetRepoConfigValue = func(string, string) (string, error) {
+		return "", gitconfigerror.ErrConfigFileIsInvalid
+	}
+
+	expectedEvent := Failed{Reason: fmt.Errorf("failed to inspect repository %s: %s", enabledEntry.GitDir, gitconfigerror.ErrConfigFileIsInvalid)}
+
+	event := Policy{Deps: deps, Req: Request{RemoveUntracked: &keepUntracked}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestGcShouldFailWhenRemovingATemplateFails(t *testing.T) {
+	deps := defaultDeps()
+
+	err := errors.New("permission denied")
+	deps.RemoveFile = func(string) error {
+		return err
+	}
+
+	expectedEvent := Failed{Reason: fmt.Errorf("failed to remove commit template %s: %s", goneEntry.TemplateDir, err)}
+
+	event := Policy{Deps: deps, Req: Request{RemoveUntracked: &keepUntracked}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestGcShouldFailWhenUpdatingTheIndexFails(t *testing.T) {
+	deps := defaultDeps()
+
+	err := errors.New("failed to write index")
+	deps.TemplateIndexWriter = &templateIndexWriterMock{
+		remove: func(string) error {
+			return err
+		},
+	}
+
+	expectedEvent := Failed{Reason: fmt.Errorf("failed to remove commit template %s from index: %s", goneEntry.TemplateDir, err)}
+
+	event := Policy{Deps: deps, Req: Request{RemoveUntracked: &keepUntracked}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
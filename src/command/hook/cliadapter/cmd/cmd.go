@@ -0,0 +1,61 @@
+package hookcmdadapter
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/hekmekk/git-team/src/command/hook"
+	hookeventadapter "github.com/hekmekk/git-team/src/command/hook/cliadapter/event"
+	commandadapter "github.com/hekmekk/git-team/src/shared/cli/commandadapter"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+	config "github.com/hekmekk/git-team/src/shared/config/datasource"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/impl"
+	"github.com/hekmekk/git-team/src/shared/gitrepo"
+	state "github.com/hekmekk/git-team/src/shared/state/impl"
+)
+
+// Command the hook command, which is invoked by the git hooks installed by git-team
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:            "hook",
+		Usage:           "Run a git hook of git-team",
+		ArgsUsage:       "<name> [<hook args>...]",
+		Hidden:          true,
+		SkipFlagParsing: true,
+		Action: func(c *cli.Context) error {
+			name := c.Args().First()
+			args := c.Args().Tail()
+
+			// the hooks run on every commit, so all the settings they need are read with a single git config call
+			snapshot, err := gitconfig.NewSnapshot(hook.ConfigPattern, os.Getenv("HOME"))
+			if err != nil {
+				return effects.NewExitErrMsg(fmt.Errorf("%s: failed to read config: %s", name, err)).Run()
+			}
+
+			return commandadapter.Run(policy(&name, &args, snapshot), hookeventadapter.MapEventToEffect)
+		},
+	}
+}
+
+func policy(name *string, args *[]string, snapshot gitconfig.Snapshot) hook.Policy {
+	return hook.Policy{
+		Req: hook.Request{
+			Name: name,
+			Args: args,
+		},
+		Deps: hook.Dependencies{
+			ConfigReader:            config.NewGitconfigDataSource(snapshot),
+			StateReader:             state.NewGitConfigDataSource(snapshot),
+			GitConfigReader:         snapshot,
+			GetEffectiveConfigValue: snapshot.Effective,
+			GitPath:                 gitrepo.GitPath,
+			ReadFile:                ioutil.ReadFile,
+			WriteFile:               ioutil.WriteFile,
+			StatFile:                os.Stat,
+			GetEnv:                  os.Getenv,
+		},
+	}
+}
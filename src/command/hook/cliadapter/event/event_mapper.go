@@ -0,0 +1,19 @@
+package hookeventadapter
+
+import (
+	"github.com/hekmekk/git-team/src/command/hook"
+	"github.com/hekmekk/git-team/src/core/events"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+// MapEventToEffect convert hook events to effects for the cli
+func MapEventToEffect(event events.Event) effects.Effect {
+	switch evt := event.(type) {
+	case hook.Succeeded:
+		return effects.NewExitOk()
+	case hook.Failed:
+		return effects.NewExitErrMsg(evt.Reason)
+	default:
+		return effects.NewExitOk()
+	}
+}
@@ -0,0 +1,45 @@
+package hookeventadapter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hekmekk/git-team/src/command/hook"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+func TestMapEventToEffectSucceeded(t *testing.T) {
+	expectedEffect := effects.NewExitOk()
+
+	effect := MapEventToEffect(hook.Succeeded{})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectFailed(t *testing.T) {
+	err := errors.New("failure")
+
+	expectedEffect := effects.NewExitErrMsg(err)
+
+	effect := MapEventToEffect(hook.Failed{Reason: err})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectUnknownEvent(t *testing.T) {
+	expectedEffect := effects.NewExitOk()
+
+	effect := MapEventToEffect("UNKNOWN_EVENT")
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
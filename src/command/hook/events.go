@@ -0,0 +1,9 @@
+package hook
+
+// Succeeded the hook has run successfully
+type Succeeded struct{}
+
+// Failed the hook has failed
+type Failed struct {
+	Reason error
+}
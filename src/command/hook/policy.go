@@ -0,0 +1,418 @@
+package hook
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	utils "github.com/hekmekk/git-team/src/command/enable/utils"
+	"github.com/hekmekk/git-team/src/core/events"
+	"github.com/hekmekk/git-team/src/core/validation"
+	config "github.com/hekmekk/git-team/src/shared/config/interface"
+	giterror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/interface"
+	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+	"github.com/hekmekk/git-team/src/shared/role"
+	state "github.com/hekmekk/git-team/src/shared/state/interface"
+	"github.com/hekmekk/git-team/src/shared/trailer"
+)
+
+// PrepareCommitMsg the name of the prepare-commit-msg hook
+const PrepareCommitMsg = "prepare-commit-msg"
+
+// CommitMsg the name of the commit-msg hook
+const CommitMsg = "commit-msg"
+
+// SkipEnvVar set this environment variable (to anything but 0 or false) to commit without the co-authors once
+const SkipEnvVar = "GIT_TEAM_SKIP"
+
+// RequireCoauthorsKey the repo-level setting which makes the commit-msg hook reject commits without co-authors
+const RequireCoauthorsKey = "team.config.require-coauthors"
+
+// ConfigPattern the settings the hooks depend on, i.e. those of git-team along with the ones affecting the commit message
+const ConfigPattern = "^(team\\.|core\\.commentchar$|commit\\.(cleanup|template)$)"
+
+// SoloToken a line consisting of this token marks a commit as solo work, the line itself is removed from the commit message
+const SoloToken = "[solo]"
+
+// Dependencies the dependencies of the hook Policy module
+type Dependencies struct {
+	ConfigReader            config.Reader
+	StateReader             state.Reader
+	GitConfigReader         gitconfig.Reader
+	GetEffectiveConfigValue func(key string) (string, error)
+	GitPath                 func(name string) (string, error)
+	ReadFile                func(path string) ([]byte, error)
+	WriteFile               func(path string, data []byte, mode os.FileMode) error
+	StatFile                func(path string) (os.FileInfo, error)
+	GetEnv                  func(string) string
+}
+
+// Request the hook to run along with the arguments git has passed to it
+type Request struct {
+	Name *string
+	Args *[]string
+}
+
+// Policy the policy to apply
+type Policy struct {
+	Deps Dependencies
+	Req  Request
+}
+
+// Apply run the requested git hook
+func (policy Policy) Apply() events.Event {
+	deps := policy.Deps
+	req := policy.Req
+
+	switch *req.Name {
+	case PrepareCommitMsg:
+		if err := prepareCommitMsg(deps, *req.Args); err != nil {
+			return Failed{Reason: fmt.Errorf("%s: %s", PrepareCommitMsg, err)}
+		}
+		return Succeeded{}
+	case CommitMsg:
+		if err := commitMsg(deps, *req.Args); err != nil {
+			return Failed{Reason: fmt.Errorf("%s: %s", CommitMsg, err)}
+		}
+		return Succeeded{}
+	default:
+		return Failed{Reason: fmt.Errorf("unknown hook '%s', expected one of: %s, %s", *req.Name, CommitMsg, PrepareCommitMsg)}
+	}
+}
+
+// prepareCommitMsg add the active co-authors and roles to the commit message, see: https://git-scm.com/docs/githooks#_prepare_commit_msg
+func prepareCommitMsg(deps Dependencies, args []string) error {
+	if len(args) == 0 || args[0] == "" {
+		return errors.New("the path to the commit message file is missing")
+	}
+
+	messageFile := args[0]
+
+	// message  - git commit -m|-F
+	// merge    - git merge (unless ff)
+	// squash   - git merge --squash
+	// none     - git commit
+	// commit   - git commit -c|-C|--amend
+	// template - git commit -t or if commit.template is set
+	commitSource := "none"
+	if len(args) > 1 && args[1] != "" {
+		commitSource = args[1]
+	}
+
+	cfg, err := deps.ConfigReader.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read config: %s", err)
+	}
+
+	currState, err := deps.StateReader.Query(cfg.ActivationScope)
+	if err != nil {
+		return fmt.Errorf("failed to query current state: %s", err)
+	}
+
+	if !currState.IsEnabled() {
+		return nil
+	}
+
+	coauthors := append([]string{}, currState.Coauthors...)
+	sort.Strings(coauthors)
+	roles := role.Sort(currState.Roles)
+	format := cfg.Trailer()
+
+	gitConfigScope := gitconfigscope.FromActivationScope(cfg.ActivationScope)
+
+	if err := regenerateTemplate(deps, gitConfigScope, messageFile, commitSource, coauthors, roles, format); err != nil {
+		return fmt.Errorf("failed to regenerate the commit template: %s", err)
+	}
+
+	message, err := deps.ReadFile(messageFile)
+	if err != nil {
+		return err
+	}
+
+	trailerLines := role.TrailerLines(coauthors, roles, format)
+
+	// the commit template has put the co-authors into the message already
+	if isSkipRequested(deps) {
+		if commitSource == "template" {
+			return deps.WriteFile(messageFile, []byte(stripLines(string(message), isOneOf(trailerLines))), 0644)
+		}
+		return nil
+	}
+
+	if hasSoloToken(string(message)) {
+		return deps.WriteFile(messageFile, []byte(stripLines(string(message), isSoloToken)), 0644)
+	}
+
+	squashing, err := isSquashing(deps, messageFile, commitSource)
+	if err != nil {
+		return err
+	}
+
+	// git rebase passes the messages it combines for squash and fixup as a plain message, whose comments are stripped nonetheless
+	if squashing {
+		commitSource = "squash"
+	}
+
+	if !squashing && !cfg.Injects(commitSource) {
+		return nil
+	}
+
+	cleanup, err := lookupCleanup(deps, commitSource)
+	if err != nil {
+		return err
+	}
+
+	prefixes := role.TrailerPrefixes(format)
+
+	updated := string(message)
+	if squashing {
+		updated = trailer.Collapse(updated, prefixes, cleanup)
+	}
+
+	if cfg.Injects(commitSource) {
+		updated = trailer.Inject(updated, trailerLines, prefixes, cleanup)
+	}
+
+	return deps.WriteFile(messageFile, []byte(updated), 0644)
+}
+
+// isSquashing whether the message combines the messages of several commits, i.e. it stems from git merge --squash or from a squash or fixup during git rebase
+func isSquashing(deps Dependencies, messageFile string, commitSource string) (bool, error) {
+	if commitSource == "squash" {
+		return true, nil
+	}
+
+	if commitSource != "message" {
+		return false, nil
+	}
+
+	// git rebase keeps the combined message in this file for as long as it squashes commits
+	squashMessageFile, err := locateInGitDir(deps, messageFile, "rebase-merge/message-squash")
+	if err != nil {
+		return false, fmt.Errorf("failed to locate the squash message of git rebase: %s", err)
+	}
+
+	if _, err := deps.StatFile(squashMessageFile); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to stat %s: %s", squashMessageFile, err)
+	}
+
+	return true, nil
+}
+
+// locateInGitDir git keeps the message in the git dir of the worktree, which saves asking git for the path
+func locateInGitDir(deps Dependencies, messageFile string, name string) (string, error) {
+	if filepath.Base(messageFile) == "COMMIT_EDITMSG" {
+		return filepath.Join(filepath.Dir(messageFile), name), nil
+	}
+
+	return deps.GitPath(name)
+}
+
+// commitMsg handle a solo token which has been added in the editor, i.e. after the prepare-commit-msg hook has run, and reject commits without co-authors if the repository requires them
+func commitMsg(deps Dependencies, args []string) error {
+	if len(args) == 0 || args[0] == "" {
+		return errors.New("the path to the commit message file is missing")
+	}
+
+	messageFile := args[0]
+
+	message, err := deps.ReadFile(messageFile)
+	if err != nil {
+		return err
+	}
+
+	if !hasSoloToken(string(message)) {
+		return checkCoauthors(deps, string(message))
+	}
+
+	cfg, err := deps.ConfigReader.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read config: %s", err)
+	}
+
+	currState, err := deps.StateReader.Query(cfg.ActivationScope)
+	if err != nil {
+		return fmt.Errorf("failed to query current state: %s", err)
+	}
+
+	// the co-authors stem from the commit template or the prepare-commit-msg hook
+	trailerLines := role.TrailerLines(currState.Coauthors, currState.Roles, cfg.Trailer())
+
+	return deps.WriteFile(messageFile, []byte(stripLines(string(message), func(line string) bool {
+		return isSoloToken(line) || isOneOf(trailerLines)(line)
+	})), 0644)
+}
+
+// checkCoauthors reject a message without a valid co-author if the repository requires co-authors
+func checkCoauthors(deps Dependencies, message string) error {
+	required, err := deps.GetEffectiveConfigValue(RequireCoauthorsKey)
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return fmt.Errorf("failed to get %s: %s", RequireCoauthorsKey, err)
+	}
+
+	if required != "true" || isSkipRequested(deps) {
+		return nil
+	}
+
+	cfg, err := deps.ConfigReader.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read config: %s", err)
+	}
+
+	cleanup, err := lookupCleanup(deps, "")
+	if err != nil {
+		return err
+	}
+
+	format := cfg.Trailer()
+	suffix := strings.TrimSpace(format.Suffix())
+	for _, value := range trailer.Values(message, format.Prefix(), cleanup) {
+		if validation.SanityCheckCoauthor(strings.TrimSpace(strings.TrimSuffix(value, suffix))) == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("this repository requires co-authors, but the commit message does not contain any '%s' trailer. Use 'git team enable' to add them, or commit solo with a '%s' line in the message, %s=1 or 'git commit --no-verify'", strings.TrimSpace(format.Prefix()), SoloToken, SkipEnvVar)
+}
+
+func isSkipRequested(deps Dependencies) bool {
+	value := strings.TrimSpace(deps.GetEnv(SkipEnvVar))
+	return value != "" && value != "0" && !strings.EqualFold(value, "false")
+}
+
+func isSoloToken(line string) bool {
+	return strings.EqualFold(strings.TrimSpace(line), SoloToken)
+}
+
+func hasSoloToken(message string) bool {
+	for _, line := range strings.Split(message, "\n") {
+		if isSoloToken(line) {
+			return true
+		}
+	}
+	return false
+}
+
+func isOneOf(lines []string) func(string) bool {
+	return func(line string) bool {
+		for _, candidate := range lines {
+			if line == candidate {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// stripLines remove the matching lines from the message
+func stripLines(message string, matches func(string) bool) string {
+	kept := []string{}
+	for _, line := range strings.Split(message, "\n") {
+		if !matches(line) {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// regenerateTemplate regenerate the composed commit template once the template it has been composed with changes
+func regenerateTemplate(deps Dependencies, gitConfigScope gitconfigscope.Scope, messageFile string, commitSource string, coauthors []string, roles []role.Assignment, format trailer.Format) error {
+	templateSource, err := deps.GitConfigReader.Get(gitConfigScope, "team.state.template-source")
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return err
+	}
+
+	composedTemplate, err := deps.GitConfigReader.Get(gitConfigScope, "commit.template")
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return err
+	}
+
+	if templateSource == "" || composedTemplate == "" {
+		return nil
+	}
+
+	isStale, err := isNewer(deps, templateSource, composedTemplate)
+	if err != nil || !isStale {
+		return err
+	}
+
+	staleTemplate, err := deps.ReadFile(composedTemplate)
+	if err != nil {
+		return err
+	}
+
+	original, err := deps.ReadFile(templateSource)
+	if err != nil {
+		return err
+	}
+
+	composed := utils.ComposeWithTemplate(string(original), coauthors, roles, format)
+
+	if err := deps.WriteFile(composedTemplate, []byte(composed), 0644); err != nil {
+		return err
+	}
+
+	if commitSource != "template" {
+		return nil
+	}
+
+	// git has already used the stale template for the current message, so replace it there as well
+	message, err := deps.ReadFile(messageFile)
+	if err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(string(message), string(staleTemplate)) {
+		return nil
+	}
+
+	return deps.WriteFile(messageFile, []byte(composed+strings.TrimPrefix(string(message), string(staleTemplate))), 0644)
+}
+
+// isNewer whether both files exist and the first one has been modified after the second one
+func isNewer(deps Dependencies, path string, otherPath string) (bool, error) {
+	info, err := deps.StatFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	otherInfo, err := deps.StatFile(otherPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return info.Mode().IsRegular() && otherInfo.Mode().IsRegular() && info.ModTime().After(otherInfo.ModTime()), nil
+}
+
+// lookupCleanup determine how git is going to clean up the commit message
+func lookupCleanup(deps Dependencies, commitSource string) (trailer.Cleanup, error) {
+	commentChar, err := deps.GetEffectiveConfigValue("core.commentChar")
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return trailer.Cleanup{}, fmt.Errorf("failed to get core.commentChar: %s", err)
+	}
+
+	mode, err := deps.GetEffectiveConfigValue("commit.cleanup")
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return trailer.Cleanup{}, fmt.Errorf("failed to get commit.cleanup: %s", err)
+	}
+
+	// git only strips comments by default if an editor is used, which is not the case for messages given via -m or -F
+	if (mode == "" || mode == "default") && commitSource == "message" {
+		mode = "whitespace"
+	}
+
+	return trailer.NewCleanup(commentChar, mode), nil
+}
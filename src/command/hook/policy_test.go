@@ -0,0 +1,624 @@
+package hook
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hekmekk/git-team/src/core/events"
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	config "github.com/hekmekk/git-team/src/shared/config/entity/config"
+	gitconfigerror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
+	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+	"github.com/hekmekk/git-team/src/shared/role"
+	state "github.com/hekmekk/git-team/src/shared/state/entity"
+)
+
+type configReaderMock struct {
+	read func() (config.Config, error)
+}
+
+func (mock configReaderMock) Read() (config.Config, error) {
+	return mock.read()
+}
+
+type stateReaderMock struct {
+	query func(activationscope.Scope) (state.State, error)
+}
+
+func (mock stateReaderMock) Query(scope activationscope.Scope) (state.State, error) {
+	return mock.query(scope)
+}
+
+type gitConfigReaderMock struct {
+	get func(gitconfigscope.Scope, string) (string, error)
+}
+
+func (mock gitConfigReaderMock) Get(scope gitconfigscope.Scope, key string) (string, error) {
+	return mock.get(scope, key)
+}
+
+func (mock gitConfigReaderMock) GetAll(scope gitconfigscope.Scope, key string) ([]string, error) {
+	return nil, nil
+}
+
+func (mock gitConfigReaderMock) GetRegexp(scope gitconfigscope.Scope, pattern string) (map[string]string, error) {
+	return nil, nil
+}
+
+func (mock gitConfigReaderMock) List(scope gitconfigscope.Scope) (map[string]string, error) {
+	return nil, nil
+}
+
+type fileInfoMock struct {
+	modTime time.Time
+}
+
+func (mock fileInfoMock) Name() string       { return "" }
+func (mock fileInfoMock) Size() int64        { return 0 }
+func (mock fileInfoMock) Mode() os.FileMode  { return 0644 }
+func (mock fileInfoMock) ModTime() time.Time { return mock.modTime }
+func (mock fileInfoMock) IsDir() bool        { return false }
+func (mock fileInfoMock) Sys() interface{}   { return nil }
+
+const messageFile = "/tmp/COMMIT_MSG"
+
+var (
+	coauthors = []string{"C <c@x.y>", "A <a@x.y>", "B <b@x.y>"}
+
+	injected = "\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n"
+)
+
+type fileSystem struct {
+	files    map[string]string
+	modTimes map[string]time.Time
+}
+
+func newFileSystem(message string) *fileSystem {
+	return &fileSystem{files: map[string]string{messageFile: message}, modTimes: map[string]time.Time{}}
+}
+
+func deps(fs *fileSystem, cfg config.Config, currState state.State) Dependencies {
+	return Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return cfg, nil
+			},
+		},
+		StateReader: &stateReaderMock{
+			query: func(scope activationscope.Scope) (state.State, error) {
+				if scope != cfg.ActivationScope {
+					return state.State{}, fmt.Errorf("wrong scope: %s", scope)
+				}
+				return currState, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			get: func(scope gitconfigscope.Scope, key string) (string, error) {
+				return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+			},
+		},
+		GetEffectiveConfigValue: func(key string) (string, error) {
+			return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+		GitPath: func(name string) (string, error) {
+			return "/tmp/.git/" + name, nil
+		},
+		ReadFile: func(path string) ([]byte, error) {
+			content, ok := fs.files[path]
+			if !ok {
+				return nil, os.ErrNotExist
+			}
+			return []byte(content), nil
+		},
+		WriteFile: func(path string, data []byte, mode os.FileMode) error {
+			fs.files[path] = string(data)
+			return nil
+		},
+		StatFile: func(path string) (os.FileInfo, error) {
+			if _, ok := fs.files[path]; !ok {
+				return nil, os.ErrNotExist
+			}
+			return fileInfoMock{modTime: fs.modTimes[path]}, nil
+		},
+		GetEnv: func(string) string { return "" },
+	}
+}
+
+func apply(deps Dependencies, args ...string) events.Event {
+	return applyHook(deps, PrepareCommitMsg, args...)
+}
+
+func applyHook(deps Dependencies, name string, args ...string) events.Event {
+	return Policy{Deps: deps, Req: Request{Name: &name, Args: &args}}.Apply()
+}
+
+func expectMessage(t *testing.T, fs *fileSystem, event events.Event, expectedMessage string) {
+	if !reflect.DeepEqual(Succeeded{}, event) {
+		t.Errorf("expected: %s, got: %s", Succeeded{}, event)
+		t.Fail()
+	}
+
+	if expectedMessage != fs.files[messageFile] {
+		t.Errorf("expected: %q, got: %q", expectedMessage, fs.files[messageFile])
+		t.Fail()
+	}
+}
+
+func TestPrepareCommitMsgShouldNotModifyTheMessageWhenDisabled(t *testing.T) {
+	t.Parallel()
+
+	for _, commitSource := range []string{"message", "", "commit", "template", "merge", "squash"} {
+		commitSource := commitSource
+
+		t.Run(commitSource, func(t *testing.T) {
+			t.Parallel()
+
+			fs := newFileSystem("")
+
+			event := apply(deps(fs, config.Config{ActivationScope: activationscope.Global}, state.NewStateDisabled()), messageFile, commitSource)
+
+			expectMessage(t, fs, event, "")
+		})
+	}
+}
+
+func TestPrepareCommitMsgShouldInjectTheCoauthorsForTheDefaultCommitSources(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		activationScope activationscope.Scope
+		commitSource    string
+		expectedMessage string
+	}{
+		{activationscope.Global, "message", injected},
+		{activationscope.Global, "", ""},
+		{activationscope.Global, "commit", ""},
+		{activationscope.Global, "template", ""},
+		{activationscope.Global, "merge", injected},
+		{activationscope.Global, "squash", injected},
+		{activationscope.RepoLocal, "message", injected},
+		{activationscope.RepoLocal, "", ""},
+		{activationscope.RepoLocal, "commit", ""},
+		{activationscope.RepoLocal, "template", ""},
+		{activationscope.RepoLocal, "merge", injected},
+		{activationscope.RepoLocal, "squash", injected},
+	}
+
+	for _, caseLoopVar := range cases {
+		activationScope := caseLoopVar.activationScope
+		commitSource := caseLoopVar.commitSource
+		expectedMessage := caseLoopVar.expectedMessage
+
+		t.Run(fmt.Sprintf("%s/%s", activationScope, commitSource), func(t *testing.T) {
+			t.Parallel()
+
+			fs := newFileSystem("")
+
+			event := apply(deps(fs, config.Config{ActivationScope: activationScope}, state.NewStateEnabled(coauthors)), messageFile, commitSource)
+
+			expectMessage(t, fs, event, expectedMessage)
+		})
+	}
+}
+
+func TestPrepareCommitMsgShouldInjectTheCoauthorsWithTheConfiguredTrailer(t *testing.T) {
+	t.Parallel()
+
+	fs := newFileSystem("")
+	cfg := config.Config{ActivationScope: activationscope.Global, TrailerKey: "Pair", TrailerFormat: "{key} → {value}"}
+
+	event := apply(deps(fs, cfg, state.NewStateEnabled(coauthors)), messageFile, "message")
+
+	expectMessage(t, fs, event, "\nPair → A <a@x.y>\nPair → B <b@x.y>\nPair → C <c@x.y>\n")
+}
+
+func TestPrepareCommitMsgShouldSkipCoauthorsWhichAreAlreadyPresent(t *testing.T) {
+	t.Parallel()
+
+	fs := newFileSystem("subject\n\nSigned-off-by: S <s@x.y>\nCo-authored-by: b <B@X.Y>\n")
+
+	event := apply(deps(fs, config.Config{ActivationScope: activationscope.Global}, state.NewStateEnabled(coauthors)), messageFile, "message")
+
+	expectMessage(t, fs, event, "subject\n\nSigned-off-by: S <s@x.y>\nCo-authored-by: b <B@X.Y>\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>\n")
+}
+
+func TestPrepareCommitMsgShouldInjectAboveTheComments(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name            string
+		commentChar     string
+		cleanup         string
+		message         string
+		expectedMessage string
+	}{
+		{"default", "", "", "Merge branch x\n\n# Please enter a commit message\n", "Merge branch x\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n\n# Please enter a commit message\n"},
+		{"custom core.commentChar", ";", "", "Merge branch x\n\n; Please enter a commit message\n", "Merge branch x\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n\n; Please enter a commit message\n"},
+		{"commit.cleanup verbatim", "", "verbatim", "Merge branch x\n\n# kept\n", "Merge branch x\n\n# kept\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n"},
+	}
+
+	for _, caseLoopVar := range cases {
+		commentChar := caseLoopVar.commentChar
+		cleanup := caseLoopVar.cleanup
+		message := caseLoopVar.message
+		expectedMessage := caseLoopVar.expectedMessage
+
+		t.Run(caseLoopVar.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := newFileSystem(message)
+			hookDeps := deps(fs, config.Config{ActivationScope: activationscope.Global}, state.NewStateEnabled(coauthors))
+			hookDeps.GetEffectiveConfigValue = func(key string) (string, error) {
+				switch key {
+				case "core.commentChar":
+					return commentChar, nil
+				case "commit.cleanup":
+					return cleanup, nil
+				default:
+					return "", fmt.Errorf("wrong key: %s", key)
+				}
+			}
+
+			event := apply(hookDeps, messageFile, "merge")
+
+			expectMessage(t, fs, event, expectedMessage)
+		})
+	}
+}
+
+func TestPrepareCommitMsgShouldInjectTheRoles(t *testing.T) {
+	t.Parallel()
+
+	fs := newFileSystem("")
+	currState := state.NewStateEnabled([]string{"A <a@x.y>"}).WithRoles([]role.Assignment{{Role: role.Reviewer, Identity: "R <r@x.y>"}})
+
+	event := apply(deps(fs, config.Config{ActivationScope: activationscope.Global}, currState), messageFile, "message")
+
+	expectMessage(t, fs, event, "\nCo-authored-by: A <a@x.y>\nReviewed-by: R <r@x.y>\n")
+}
+
+func TestPrepareCommitMsgShouldInjectTheCoauthorsForTheConfiguredCommitSources(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		commitSource    string
+		message         string
+		expectedMessage string
+	}{
+		{"commit", "amended\n\nCo-authored-by: B <b@x.y>\n", "amended\n\nCo-authored-by: B <b@x.y>\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>\n"},
+		{"merge", "", ""},
+	}
+
+	for _, caseLoopVar := range cases {
+		commitSource := caseLoopVar.commitSource
+		message := caseLoopVar.message
+		expectedMessage := caseLoopVar.expectedMessage
+
+		t.Run(commitSource, func(t *testing.T) {
+			t.Parallel()
+
+			fs := newFileSystem(message)
+			cfg := config.Config{ActivationScope: activationscope.Global, InjectSources: []string{"message", "commit"}}
+
+			event := apply(deps(fs, cfg, state.NewStateEnabled(coauthors)), messageFile, commitSource)
+
+			expectMessage(t, fs, event, expectedMessage)
+		})
+	}
+}
+
+func TestPrepareCommitMsgShouldCollapseTheTrailerBlocksOfASquash(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name          string
+		injectSources []string
+		expectedBlock string
+	}{
+		{"injecting", []string{}, "Co-authored-by: B <b@x.y>\nCo-authored-by: D <d@x.y>\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>\n"},
+		{"not injecting", []string{"message"}, "Co-authored-by: B <b@x.y>\nCo-authored-by: D <d@x.y>\n"},
+	}
+
+	for _, caseLoopVar := range cases {
+		injectSources := caseLoopVar.injectSources
+		expectedBlock := caseLoopVar.expectedBlock
+
+		t.Run(caseLoopVar.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := newFileSystem("Squashed commit of the following:\n\nfirst\n\nCo-authored-by: B <b@x.y>\n\nsecond\n\nCo-authored-by: b <B@X.Y>\nCo-authored-by: D <d@x.y>\n")
+			cfg := config.Config{ActivationScope: activationscope.Global, InjectSources: injectSources}
+
+			event := apply(deps(fs, cfg, state.NewStateEnabled(coauthors)), messageFile, "squash")
+
+			expectMessage(t, fs, event, "Squashed commit of the following:\n\nfirst\n\nsecond\n\n"+expectedBlock)
+		})
+	}
+}
+
+func TestPrepareCommitMsgShouldCollapseTheTrailerBlocksOfMessagesCombinedByRebase(t *testing.T) {
+	t.Parallel()
+
+	message := "# This is a combination of 2 commits.\n# This is the 1st commit message:\n\nfirst\n\nCo-authored-by: B <b@x.y>\n\n# This is the commit message #2:\n\nsecond\n\nCo-authored-by: B <b@x.y>\n\n# Please enter the commit message for your changes.\n"
+
+	fs := newFileSystem(message)
+	fs.files["/tmp/.git/rebase-merge/message-squash"] = message
+
+	event := apply(deps(fs, config.Config{ActivationScope: activationscope.Global}, state.NewStateEnabled([]string{"A <a@x.y>"})), messageFile, "message")
+
+	expectMessage(t, fs, event, "# This is a combination of 2 commits.\n# This is the 1st commit message:\n\nfirst\n\n# This is the commit message #2:\n\nsecond\n\nCo-authored-by: B <b@x.y>\nCo-authored-by: A <a@x.y>\n\n# Please enter the commit message for your changes.\n")
+}
+
+func TestPrepareCommitMsgShouldLocateTheSquashMessageNextToTheCommitMessageWithoutAskingGit(t *testing.T) {
+	t.Parallel()
+
+	editMsgFile := "/path/to/repo/.git/worktrees/wt/COMMIT_EDITMSG"
+	message := "# This is a combination of 2 commits.\n# This is the 1st commit message:\n\nfirst\n\nCo-authored-by: B <b@x.y>\n\n# This is the commit message #2:\n\nsecond\n\nCo-authored-by: B <b@x.y>\n"
+
+	fs := newFileSystem("")
+	fs.files[editMsgFile] = message
+	fs.files["/path/to/repo/.git/worktrees/wt/rebase-merge/message-squash"] = message
+
+	hookDeps := deps(fs, config.Config{ActivationScope: activationscope.Global}, state.NewStateEnabled([]string{"A <a@x.y>"}))
+	hookDeps.GitPath = func(name string) (string, error) {
+		return "", fmt.Errorf("unexpected call for %s", name)
+	}
+
+	event := apply(hookDeps, editMsgFile, "message")
+
+	if !reflect.DeepEqual(Succeeded{}, event) {
+		t.Errorf("expected: %s, got: %s", Succeeded{}, event)
+		t.Fail()
+	}
+
+	expectedMessage := "# This is a combination of 2 commits.\n# This is the 1st commit message:\n\nfirst\n\n# This is the commit message #2:\n\nsecond\n\nCo-authored-by: B <b@x.y>\nCo-authored-by: A <a@x.y>\n"
+	if expectedMessage != fs.files[editMsgFile] {
+		t.Errorf("expected: %q, got: %q", expectedMessage, fs.files[editMsgFile])
+		t.Fail()
+	}
+}
+
+func TestPrepareCommitMsgShouldRegenerateAStaleTemplate(t *testing.T) {
+	t.Parallel()
+
+	staleTemplate := "old\n\nCo-authored-by: A <a@x.y>"
+	composedTemplate := "new\n\nCo-authored-by: A <a@x.y>"
+
+	fs := newFileSystem(staleTemplate + "\n# comment\n")
+	fs.files["/home/user/.gitmessage"] = "new\n"
+	fs.files["/home/user/.git-team/commit-templates/global/COMMIT_TEMPLATE"] = staleTemplate
+	fs.modTimes["/home/user/.gitmessage"] = time.Unix(1, 0)
+
+	hookDeps := deps(fs, config.Config{ActivationScope: activationscope.Global}, state.NewStateEnabled([]string{"A <a@x.y>"}))
+	hookDeps.GitConfigReader = &gitConfigReaderMock{
+		get: func(scope gitconfigscope.Scope, key string) (string, error) {
+			switch key {
+			case "team.state.template-source":
+				return "/home/user/.gitmessage", nil
+			case "commit.template":
+				return "/home/user/.git-team/commit-templates/global/COMMIT_TEMPLATE", nil
+			default:
+				return "", fmt.Errorf("wrong key: %s", key)
+			}
+		},
+	}
+
+	event := apply(hookDeps, messageFile, "template")
+
+	expectMessage(t, fs, event, composedTemplate+"\n# comment\n")
+
+	if composedTemplate != fs.files["/home/user/.git-team/commit-templates/global/COMMIT_TEMPLATE"] {
+		t.Errorf("expected: %q, got: %q", composedTemplate, fs.files["/home/user/.git-team/commit-templates/global/COMMIT_TEMPLATE"])
+		t.Fail()
+	}
+}
+
+func TestPrepareCommitMsgShouldNotInjectTheCoauthorsWhenSkipIsRequested(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		skip            string
+		expectedMessage string
+	}{
+		{"1", "subject\n"},
+		{"true", "subject\n"},
+		{"0", "subject\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n"},
+		{"", "subject\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n"},
+	}
+
+	for _, caseLoopVar := range cases {
+		skip := caseLoopVar.skip
+		expectedMessage := caseLoopVar.expectedMessage
+
+		t.Run(skip, func(t *testing.T) {
+			t.Parallel()
+
+			fs := newFileSystem("subject\n")
+			hookDeps := deps(fs, config.Config{ActivationScope: activationscope.Global}, state.NewStateEnabled(coauthors))
+			hookDeps.GetEnv = func(name string) string {
+				if name != "GIT_TEAM_SKIP" {
+					return ""
+				}
+				return skip
+			}
+
+			event := apply(hookDeps, messageFile, "message")
+
+			expectMessage(t, fs, event, expectedMessage)
+		})
+	}
+}
+
+func TestPrepareCommitMsgShouldRemoveTheCoauthorsOfTheTemplateWhenSkipIsRequested(t *testing.T) {
+	t.Parallel()
+
+	fs := newFileSystem("\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\n# comment\n")
+	hookDeps := deps(fs, config.Config{ActivationScope: activationscope.Global}, state.NewStateEnabled([]string{"A <a@x.y>", "B <b@x.y>"}))
+	hookDeps.GetEnv = func(string) string { return "1" }
+
+	event := apply(hookDeps, messageFile, "template")
+
+	expectMessage(t, fs, event, "\n\n# comment\n")
+}
+
+func TestPrepareCommitMsgShouldStripTheSoloTokenInsteadOfInjectingTheCoauthors(t *testing.T) {
+	t.Parallel()
+
+	fs := newFileSystem("bump version\n\n[solo]\n")
+
+	event := apply(deps(fs, config.Config{ActivationScope: activationscope.Global}, state.NewStateEnabled(coauthors)), messageFile, "message")
+
+	expectMessage(t, fs, event, "bump version\n\n")
+}
+
+func TestCommitMsgShouldStripTheSoloTokenAlongWithTheCoauthors(t *testing.T) {
+	t.Parallel()
+
+	fs := newFileSystem("bump version\n[SOLO]\n\nCo-authored-by: A <a@x.y>\nReviewed-by: R <r@x.y>\nSigned-off-by: S <s@x.y>\n")
+	currState := state.NewStateEnabled([]string{"A <a@x.y>"}).WithRoles([]role.Assignment{{Role: role.Reviewer, Identity: "R <r@x.y>"}})
+
+	event := applyHook(deps(fs, config.Config{ActivationScope: activationscope.Global}, currState), CommitMsg, messageFile)
+
+	expectMessage(t, fs, event, "bump version\n\nSigned-off-by: S <s@x.y>\n")
+}
+
+func TestCommitMsgShouldNotModifyTheMessageWithoutTheSoloToken(t *testing.T) {
+	t.Parallel()
+
+	message := "subject\n\nCo-authored-by: A <a@x.y>\n"
+	fs := newFileSystem(message)
+
+	event := applyHook(deps(fs, config.Config{ActivationScope: activationscope.Global}, state.NewStateEnabled([]string{"A <a@x.y>"})), CommitMsg, messageFile)
+
+	expectMessage(t, fs, event, message)
+}
+
+func requireCoauthors(hookDeps Dependencies) Dependencies {
+	hookDeps.GetEffectiveConfigValue = func(key string) (string, error) {
+		if key == RequireCoauthorsKey {
+			return "true", nil
+		}
+		return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+	}
+	return hookDeps
+}
+
+func TestCommitMsgShouldAcceptAMessageWithCoauthorsWhenTheRepositoryRequiresThem(t *testing.T) {
+	t.Parallel()
+
+	message := "subject\n\nCo-authored-by: A <a@x.y>\n# comment\n"
+	fs := newFileSystem(message)
+
+	event := applyHook(requireCoauthors(deps(fs, config.Config{ActivationScope: activationscope.Global}, state.NewStateDisabled())), CommitMsg, messageFile)
+
+	expectMessage(t, fs, event, message)
+}
+
+func TestCommitMsgShouldRejectAMessageWithoutCoauthorsWhenTheRepositoryRequiresThem(t *testing.T) {
+	t.Parallel()
+
+	for _, message := range []string{"subject\n", "subject\n\nCo-authored-by: nobody\n", "subject\n\nmentions Co-authored-by: A <a@x.y> in the body\nonly\n", "subject\n\n# Co-authored-by: A <a@x.y>\n"} {
+		message := message
+
+		t.Run(message, func(t *testing.T) {
+			t.Parallel()
+
+			fs := newFileSystem(message)
+
+			event := applyHook(requireCoauthors(deps(fs, config.Config{ActivationScope: activationscope.Global}, state.NewStateDisabled())), CommitMsg, messageFile)
+
+			expectedEvent := Failed{Reason: errors.New("commit-msg: this repository requires co-authors, but the commit message does not contain any 'Co-authored-by:' trailer. Use 'git team enable' to add them, or commit solo with a '[solo]' line in the message, GIT_TEAM_SKIP=1 or 'git commit --no-verify'")}
+
+			if !reflect.DeepEqual(expectedEvent, event) {
+				t.Errorf("expected: %s, got: %s", expectedEvent, event)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestCommitMsgShouldAcceptAMessageWithCoauthorsInTheConfiguredTrailerFormat(t *testing.T) {
+	t.Parallel()
+
+	message := "subject\n\nPaired-with: [A <a@x.y>]\n"
+	fs := newFileSystem(message)
+
+	event := applyHook(requireCoauthors(deps(fs, config.Config{ActivationScope: activationscope.Global, TrailerKey: "Paired-with", TrailerFormat: "{key}: [{value}]"}, state.NewStateDisabled())), CommitMsg, messageFile)
+
+	expectMessage(t, fs, event, message)
+}
+
+func TestCommitMsgShouldAcceptASoloCommitWhenTheRepositoryRequiresCoauthors(t *testing.T) {
+	t.Parallel()
+
+	fs := newFileSystem("subject\n[solo]\n")
+	hookDeps := requireCoauthors(deps(fs, config.Config{ActivationScope: activationscope.Global}, state.NewStateDisabled()))
+
+	event := applyHook(hookDeps, CommitMsg, messageFile)
+
+	expectMessage(t, fs, event, "subject\n")
+
+	fs = newFileSystem("subject\n")
+	hookDeps = requireCoauthors(deps(fs, config.Config{ActivationScope: activationscope.Global}, state.NewStateDisabled()))
+	hookDeps.GetEnv = func(string) string { return "1" }
+
+	event = applyHook(hookDeps, CommitMsg, messageFile)
+
+	expectMessage(t, fs, event, "subject\n")
+}
+
+func TestPrepareCommitMsgShouldFailWithoutTheMessageFile(t *testing.T) {
+	t.Parallel()
+
+	fs := newFileSystem("")
+
+	event := apply(deps(fs, config.Config{ActivationScope: activationscope.Global}, state.NewStateEnabled(coauthors)))
+
+	expectedEvent := Failed{Reason: errors.New("prepare-commit-msg: the path to the commit message file is missing")}
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestPrepareCommitMsgShouldFailWhenReadingTheConfigFails(t *testing.T) {
+	t.Parallel()
+
+	fs := newFileSystem("")
+	hookDeps := deps(fs, config.Config{ActivationScope: activationscope.Global}, state.NewStateEnabled(coauthors))
+	hookDeps.ConfigReader = &configReaderMock{
+		read: func() (config.Config, error) {
+			return config.Config{}, errors.New("failed to read config")
+		},
+	}
+
+	event := apply(hookDeps, messageFile, "message")
+
+	expectedEvent := Failed{Reason: errors.New("prepare-commit-msg: failed to read config: failed to read config")}
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestShouldFailForAnUnknownHook(t *testing.T) {
+	t.Parallel()
+
+	name := "pre-push"
+	args := []string{}
+
+	event := Policy{Req: Request{Name: &name, Args: &args}}.Apply()
+
+	expectedEvent := Failed{Reason: errors.New("unknown hook 'pre-push', expected one of: commit-msg, prepare-commit-msg")}
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
@@ -0,0 +1,107 @@
+package logcmdadapter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/hekmekk/git-team/src/command/log"
+	logeventadapter "github.com/hekmekk/git-team/src/command/log/cliadapter/event"
+	activation "github.com/hekmekk/git-team/src/shared/activation/impl"
+	commandadapter "github.com/hekmekk/git-team/src/shared/cli/commandadapter"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+	configds "github.com/hekmekk/git-team/src/shared/config/datasource"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/impl"
+	"github.com/hekmekk/git-team/src/shared/gitrepo"
+	"github.com/hekmekk/git-team/src/shared/history"
+)
+
+// Command the log command
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:      "log",
+		Usage:     "Show the commits of people as author or co-author, the remaining arguments are passed to git log",
+		ArgsUsage: "--with <person>[,<person>...] | --pair <person>,<person>[,<person>...] [<git log options>] [<revision range>] [[--] <path>...]",
+		// the flags of git log are passed through, so --with and --pair are parsed by hand
+		SkipFlagParsing: true,
+		Action: func(c *cli.Context) error {
+			args := c.Args().Slice()
+			if len(args) > 0 && (args[0] == "-h" || args[0] == "--help") {
+				return cli.ShowCommandHelp(c, "log")
+			}
+
+			with, pair, logArgs, err := parseArgs(args)
+			if err != nil {
+				return effects.NewExitErrMsg(err).Run()
+			}
+
+			return commandadapter.Run(policy(&with, &pair, &logArgs), logeventadapter.MapEventToEffect)
+		},
+	}
+}
+
+func policy(with *[]string, pair *[]string, logArgs *[]string) log.Policy {
+	return log.Policy{
+		Req: log.Request{
+			With:    with,
+			Pair:    pair,
+			LogArgs: logArgs,
+		},
+		Deps: log.Dependencies{
+			ConfigReader:        configds.NewGitconfigDataSource(gitconfig.NewDataSource()),
+			GitConfigReader:     gitconfig.NewDataSource(),
+			ActivationValidator: activation.NewGitConfigDataSource(gitconfig.NewDataSource()),
+			RevParse:            gitrepo.RevParse,
+			Log:                 history.Log,
+			ShowLog:             gitrepo.ShowLog,
+		},
+	}
+}
+
+// parseArgs separate --with and --pair from the arguments for git log, either flag may be repeated and takes a comma separated list
+func parseArgs(args []string) ([]string, []string, []string, error) {
+	with := []string{}
+	pair := []string{}
+	logArgs := []string{}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if arg == "--" {
+			logArgs = append(logArgs, args[i:]...)
+			break
+		}
+
+		var target *[]string
+		var value string
+		switch {
+		case arg == "--with" || arg == "--pair":
+			if i+1 >= len(args) {
+				return nil, nil, nil, fmt.Errorf("%s requires a value", arg)
+			}
+			value = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--with="):
+			value = strings.TrimPrefix(arg, "--with=")
+		case strings.HasPrefix(arg, "--pair="):
+			value = strings.TrimPrefix(arg, "--pair=")
+		default:
+			logArgs = append(logArgs, arg)
+			continue
+		}
+
+		target = &with
+		if strings.HasPrefix(arg, "--pair") {
+			target = &pair
+		}
+
+		for _, person := range strings.Split(value, ",") {
+			if person = strings.TrimSpace(person); person != "" {
+				*target = append(*target, person)
+			}
+		}
+	}
+
+	return with, pair, logArgs, nil
+}
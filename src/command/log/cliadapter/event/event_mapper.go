@@ -0,0 +1,19 @@
+package logeventadapter
+
+import (
+	"github.com/hekmekk/git-team/src/command/log"
+	"github.com/hekmekk/git-team/src/core/events"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+// MapEventToEffect convert log events to effects for the cli
+func MapEventToEffect(event events.Event) effects.Effect {
+	switch evt := event.(type) {
+	case log.Succeeded:
+		return effects.NewExitOk()
+	case log.Failed:
+		return effects.NewExitErrMsg(evt.Reason)
+	default:
+		return effects.NewExitOk()
+	}
+}
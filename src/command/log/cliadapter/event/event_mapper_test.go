@@ -0,0 +1,34 @@
+package logeventadapter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hekmekk/git-team/src/command/log"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+func TestMapEventToEffectSucceeded(t *testing.T) {
+	expectedEffect := effects.NewExitOk()
+
+	effect := MapEventToEffect(log.Succeeded{})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectFailed(t *testing.T) {
+	err := errors.New("failure")
+
+	expectedEffect := effects.NewExitErrMsg(err)
+
+	effect := MapEventToEffect(log.Failed{Reason: err})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
@@ -0,0 +1,9 @@
+package log
+
+// Succeeded the matching commits have been shown
+type Succeeded struct{}
+
+// Failed failed to show the matching commits
+type Failed struct {
+	Reason error
+}
@@ -0,0 +1,272 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hekmekk/git-team/src/core/events"
+	activation "github.com/hekmekk/git-team/src/shared/activation/interface"
+	config "github.com/hekmekk/git-team/src/shared/config/interface"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/interface"
+	"github.com/hekmekk/git-team/src/shared/history"
+	"github.com/hekmekk/git-team/src/shared/roster"
+)
+
+var countPattern = regexp.MustCompile(`^-[0-9]+$`)
+
+// the options of git log which git rev-parse only understands in the shape --<option>=<value>
+var optionsWithValue = []string{"--author", "--committer", "--grep", "--since", "--after", "--until", "--before", "--date", "--encoding"}
+
+// Dependencies the dependencies of the log Policy module
+type Dependencies struct {
+	ConfigReader        config.Reader
+	GitConfigReader     gitconfig.Reader
+	ActivationValidator activation.Validator
+	RevParse            func(args ...string) ([]string, error)
+	Log                 func(args ...string) ([]history.Commit, error)
+	ShowLog             func(commits []string, args ...string) error
+}
+
+// Request the people to look for along with the arguments for git log
+type Request struct {
+	With    *[]string
+	Pair    *[]string
+	LogArgs *[]string
+}
+
+// Policy the policy to apply
+type Policy struct {
+	Deps Dependencies
+	Req  Request
+}
+
+// Apply show the commits which involve the requested people as author or co-author
+func (policy Policy) Apply() events.Event {
+	deps := policy.Deps
+	req := policy.Req
+
+	if len(*req.With) == 0 && len(*req.Pair) == 0 {
+		return Failed{Reason: errors.New("nobody to look for, use --with <person> or --pair <person>,<person>")}
+	}
+
+	if !deps.ActivationValidator.IsInsideAGitRepository() {
+		return Failed{Reason: errors.New("failed to show the log: not inside a git repository")}
+	}
+
+	cfg, err := deps.ConfigReader.Read()
+	if err != nil {
+		return Failed{Reason: fmt.Errorf("failed to read config: %s", err)}
+	}
+
+	knownPeople, err := roster.Load(deps.GitConfigReader)
+	if err != nil {
+		return Failed{Reason: fmt.Errorf("failed to retrieve assignments: %s", err)}
+	}
+
+	with, err := toKeys(knownPeople, *req.With)
+	if err != nil {
+		return Failed{Reason: err}
+	}
+
+	pair, err := toKeys(knownPeople, *req.Pair)
+	if err != nil {
+		return Failed{Reason: err}
+	}
+
+	args, paths := splitAtDoubleDash(*req.LogArgs)
+
+	// limiting the number of commits applies to the matching ones rather than to all of them
+	args, skip, maxCount, err := extractLimits(args)
+	if err != nil {
+		return Failed{Reason: err}
+	}
+
+	args = joinOptionValues(args)
+
+	// git rev-parse tells the arguments which select commits apart from those which format them
+	revisionArgs, err := deps.RevParse(append([]string{"--revs-only"}, args...)...)
+	if err != nil {
+		return Failed{Reason: err}
+	}
+
+	displayArgs, err := deps.RevParse(append([]string{"--no-revs", "--flags"}, args...)...)
+	if err != nil {
+		return Failed{Reason: err}
+	}
+
+	// paths may be given without a preceding --, as long as they cannot be mistaken for revisions
+	implicitPaths, err := deps.RevParse(append([]string{"--no-revs", "--no-flags"}, args...)...)
+	if err != nil {
+		return Failed{Reason: err}
+	}
+	paths = append(withoutDoubleDash(implicitPaths), paths...)
+
+	commits, err := deps.Log(append(revisionArgs, append([]string{"--"}, paths...)...)...)
+	if err != nil {
+		return Failed{Reason: err}
+	}
+
+	matching := []string{}
+	for _, commit := range commits {
+		if involves(commit.People(cfg.Trailer()), with, pair) {
+			matching = append(matching, commit.Hash)
+		}
+	}
+
+	if skip >= len(matching) {
+		return Succeeded{}
+	}
+	matching = matching[skip:]
+
+	if maxCount >= 0 && maxCount < len(matching) {
+		matching = matching[:maxCount]
+	}
+
+	if len(matching) == 0 {
+		return Succeeded{}
+	}
+
+	showArgs := withoutDoubleDash(displayArgs)
+	if len(paths) > 0 {
+		showArgs = append(showArgs, append([]string{"--"}, paths...)...)
+	}
+
+	if err := deps.ShowLog(matching, showArgs...); err != nil {
+		return Failed{Reason: fmt.Errorf("git log failed: %s", err)}
+	}
+
+	return Succeeded{}
+}
+
+// toKeys identify people given as alias, "Name <email>" or email
+func toKeys(knownPeople roster.Roster, people []string) ([]string, error) {
+	keys := []string{}
+	for _, person := range people {
+		if identity, ok := knownPeople.Resolve(person); ok {
+			keys = append(keys, history.Key(identity))
+			continue
+		}
+
+		if !strings.Contains(person, "@") {
+			return nil, fmt.Errorf("unknown person '%s', expected an alias, an email or \"Name <email>\"", person)
+		}
+
+		keys = append(keys, strings.ToLower(history.Key(person)))
+	}
+	return keys, nil
+}
+
+// involves whether any of the people to be with and all of the people of the pair are involved
+func involves(people []string, with []string, pair []string) bool {
+	involved := map[string]bool{}
+	for _, person := range people {
+		involved[history.Key(person)] = true
+	}
+
+	for _, key := range pair {
+		if !involved[key] {
+			return false
+		}
+	}
+
+	if len(with) == 0 {
+		return true
+	}
+
+	for _, key := range with {
+		if involved[key] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func splitAtDoubleDash(args []string) ([]string, []string) {
+	for i, arg := range args {
+		if arg == "--" {
+			return args[:i], args[i+1:]
+		}
+	}
+	return args, []string{}
+}
+
+func withoutDoubleDash(args []string) []string {
+	result := []string{}
+	for _, arg := range args {
+		if arg != "--" {
+			result = append(result, arg)
+		}
+	}
+	return result
+}
+
+// joinOptionValues turn options given as --<option> <value> into --<option>=<value>
+func joinOptionValues(args []string) []string {
+	joined := []string{}
+	for i := 0; i < len(args); i++ {
+		if i+1 < len(args) && takesValue(args[i]) {
+			joined = append(joined, fmt.Sprintf("%s=%s", args[i], args[i+1]))
+			i++
+			continue
+		}
+		joined = append(joined, args[i])
+	}
+	return joined
+}
+
+func takesValue(arg string) bool {
+	for _, option := range optionsWithValue {
+		if arg == option {
+			return true
+		}
+	}
+	return false
+}
+
+// extractLimits remove --skip and --max-count in any of their forms from the arguments, a max count of -1 means no limit
+func extractLimits(args []string) ([]string, int, int, error) {
+	remaining := []string{}
+	skip := 0
+	maxCount := -1
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		var value string
+		var target *int
+		switch {
+		case arg == "-n" || arg == "--max-count" || arg == "--skip":
+			if i+1 >= len(args) {
+				return nil, 0, 0, fmt.Errorf("%s requires a value", arg)
+			}
+			value, target = args[i+1], &maxCount
+			if arg == "--skip" {
+				target = &skip
+			}
+			i++
+		case strings.HasPrefix(arg, "--max-count="):
+			value, target = strings.TrimPrefix(arg, "--max-count="), &maxCount
+		case strings.HasPrefix(arg, "--skip="):
+			value, target = strings.TrimPrefix(arg, "--skip="), &skip
+		case countPattern.MatchString(arg):
+			value, target = strings.TrimPrefix(arg, "-"), &maxCount
+		case strings.HasPrefix(arg, "-n") && len(arg) > 2:
+			value, target = strings.TrimPrefix(arg, "-n"), &maxCount
+		default:
+			remaining = append(remaining, arg)
+			continue
+		}
+
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("invalid number '%s' for %s", value, arg)
+		}
+		*target = number
+	}
+
+	return remaining, skip, maxCount, nil
+}
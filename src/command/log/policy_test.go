@@ -0,0 +1,636 @@
+package log
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	config "github.com/hekmekk/git-team/src/shared/config/entity/config"
+	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+	"github.com/hekmekk/git-team/src/shared/history"
+)
+
+type configReaderMock struct {
+	read func() (config.Config, error)
+}
+
+func (mock configReaderMock) Read() (config.Config, error) {
+	return mock.read()
+}
+
+type gitConfigReaderMock struct {
+	getRegexp func(gitconfigscope.Scope, string) (map[string]string, error)
+}
+
+func (mock gitConfigReaderMock) Get(scope gitconfigscope.Scope, key string) (string, error) {
+	return "", nil
+}
+
+func (mock gitConfigReaderMock) GetAll(scope gitconfigscope.Scope, key string) ([]string, error) {
+	return []string{}, nil
+}
+
+func (mock gitConfigReaderMock) GetRegexp(scope gitconfigscope.Scope, pattern string) (map[string]string, error) {
+	return mock.getRegexp(scope, pattern)
+}
+
+func (mock gitConfigReaderMock) List(scope gitconfigscope.Scope) (map[string]string, error) {
+	return nil, nil
+}
+
+type activationValidatorMock struct {
+	isInsideAGitRepository func() bool
+}
+
+func (mock activationValidatorMock) IsInsideAGitRepository() bool {
+	return mock.isInsideAGitRepository()
+}
+
+// revParse mimics git rev-parse for the arguments used in the tests
+func revParse(args ...string) ([]string, error) {
+	result := []string{}
+	for _, arg := range args[1:] {
+		switch {
+		case arg == "--revs-only" || arg == "--no-revs" || arg == "--flags" || arg == "--no-flags":
+			continue
+		case arg == "HEAD" || arg == "main..HEAD":
+			if args[0] == "--revs-only" {
+				result = append(result, arg)
+			}
+		case arg == "--no-merges":
+			if args[0] == "--revs-only" {
+				result = append(result, arg)
+			}
+		case arg[0] == '-':
+			if args[0] == "--no-revs" && args[1] == "--flags" {
+				result = append(result, arg)
+			}
+		default:
+			if args[0] == "--no-revs" && args[1] == "--no-flags" {
+				result = append(result, arg)
+			}
+		}
+	}
+	return result, nil
+}
+
+type showLogCall struct {
+	commits []string
+	args    []string
+}
+
+func TestLogShouldShowTheCommitsOfAPersonAsAuthorOrCoauthor(t *testing.T) {
+	commits := []history.Commit{
+		{Hash: "1", Author: "A <a@x.y>", Message: "one\n\nCo-authored-by: B <b@x.y>\n"},
+		{Hash: "2", Author: "B <b@x.y>", Message: "two\n\nCo-authored-by: C <C@X.Y>\n"},
+		{Hash: "3", Author: "C <c@x.y>", Message: "three\n"},
+		{Hash: "4", Author: "B <b@x.y>", Message: "four\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>\n"},
+		{Hash: "5", Author: "D <d@x.y>", Message: "five\n"},
+	}
+
+	var logArgs []string
+	var shown showLogCall
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevParse: revParse,
+		Log: func(args ...string) ([]history.Commit, error) {
+			logArgs = args
+			return commits, nil
+		},
+		ShowLog: func(commits []string, args ...string) error {
+			shown = showLogCall{commits: commits, args: args}
+			return nil
+		},
+	}
+
+	with := []string{"a"}
+	pair := []string{}
+	options := []string{"--oneline", "HEAD"}
+	req := Request{With: &with, Pair: &pair, LogArgs: &options}
+
+	expectedEvent := Succeeded{}
+	expectedShown := showLogCall{commits: []string{"1", "4"}, args: []string{"--oneline"}}
+	expectedLogArgs := []string{"HEAD", "--"}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedShown, shown) {
+		t.Errorf("expected: %s, got: %s", expectedShown, shown)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedLogArgs, logArgs) {
+		t.Errorf("expected: %s, got: %s", expectedLogArgs, logArgs)
+		t.Fail()
+	}
+}
+
+func TestLogShouldMatchEmailsCaseInsensitively(t *testing.T) {
+	commits := []history.Commit{
+		{Hash: "1", Author: "A <a@x.y>", Message: "one\n\nCo-authored-by: B <b@x.y>\n"},
+		{Hash: "2", Author: "B <b@x.y>", Message: "two\n\nCo-authored-by: C <C@X.Y>\n"},
+		{Hash: "3", Author: "C <c@x.y>", Message: "three\n"},
+		{Hash: "4", Author: "B <b@x.y>", Message: "four\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>\n"},
+		{Hash: "5", Author: "D <d@x.y>", Message: "five\n"},
+	}
+
+	var shown showLogCall
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevParse: revParse,
+		Log: func(args ...string) ([]history.Commit, error) {
+			return commits, nil
+		},
+		ShowLog: func(commits []string, args ...string) error {
+			shown = showLogCall{commits: commits, args: args}
+			return nil
+		},
+	}
+
+	with := []string{"c@X.Y"}
+	pair := []string{}
+	options := []string{}
+	req := Request{With: &with, Pair: &pair, LogArgs: &options}
+
+	expectedShown := showLogCall{commits: []string{"2", "3", "4"}, args: []string{}}
+
+	Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedShown, shown) {
+		t.Errorf("expected: %s, got: %s", expectedShown, shown)
+		t.Fail()
+	}
+}
+
+func TestLogShouldShowTheCommitsOfAnyOfThePeopleToBeWith(t *testing.T) {
+	commits := []history.Commit{
+		{Hash: "1", Author: "A <a@x.y>", Message: "one\n\nCo-authored-by: B <b@x.y>\n"},
+		{Hash: "2", Author: "B <b@x.y>", Message: "two\n\nCo-authored-by: C <C@X.Y>\n"},
+		{Hash: "3", Author: "C <c@x.y>", Message: "three\n"},
+		{Hash: "4", Author: "B <b@x.y>", Message: "four\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>\n"},
+		{Hash: "5", Author: "D <d@x.y>", Message: "five\n"},
+	}
+
+	var shown showLogCall
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevParse: revParse,
+		Log: func(args ...string) ([]history.Commit, error) {
+			return commits, nil
+		},
+		ShowLog: func(commits []string, args ...string) error {
+			shown = showLogCall{commits: commits, args: args}
+			return nil
+		},
+	}
+
+	with := []string{"a", "C <c@x.y>"}
+	pair := []string{}
+	options := []string{}
+	req := Request{With: &with, Pair: &pair, LogArgs: &options}
+
+	expectedShown := showLogCall{commits: []string{"1", "2", "3", "4"}, args: []string{}}
+
+	Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedShown, shown) {
+		t.Errorf("expected: %s, got: %s", expectedShown, shown)
+		t.Fail()
+	}
+}
+
+func TestLogShouldShowTheCommitsAPairMadeTogether(t *testing.T) {
+	commits := []history.Commit{
+		{Hash: "1", Author: "A <a@x.y>", Message: "one\n\nCo-authored-by: B <b@x.y>\n"},
+		{Hash: "2", Author: "B <b@x.y>", Message: "two\n\nCo-authored-by: C <C@X.Y>\n"},
+		{Hash: "3", Author: "C <c@x.y>", Message: "three\n"},
+		{Hash: "4", Author: "B <b@x.y>", Message: "four\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>\n"},
+		{Hash: "5", Author: "D <d@x.y>", Message: "five\n"},
+	}
+
+	var shown showLogCall
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevParse: revParse,
+		Log: func(args ...string) ([]history.Commit, error) {
+			return commits, nil
+		},
+		ShowLog: func(commits []string, args ...string) error {
+			shown = showLogCall{commits: commits, args: args}
+			return nil
+		},
+	}
+
+	with := []string{}
+	pair := []string{"b@x.y", "c@x.y"}
+	options := []string{}
+	req := Request{With: &with, Pair: &pair, LogArgs: &options}
+
+	expectedShown := showLogCall{commits: []string{"2", "4"}, args: []string{}}
+
+	Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedShown, shown) {
+		t.Errorf("expected: %s, got: %s", expectedShown, shown)
+		t.Fail()
+	}
+}
+
+func TestLogShouldApplyTheLimitsToTheMatchingCommits(t *testing.T) {
+	commits := []history.Commit{
+		{Hash: "1", Author: "A <a@x.y>", Message: "one\n\nCo-authored-by: B <b@x.y>\n"},
+		{Hash: "2", Author: "B <b@x.y>", Message: "two\n\nCo-authored-by: C <C@X.Y>\n"},
+		{Hash: "3", Author: "C <c@x.y>", Message: "three\n"},
+		{Hash: "4", Author: "B <b@x.y>", Message: "four\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>\n"},
+		{Hash: "5", Author: "D <d@x.y>", Message: "five\n"},
+	}
+
+	var logArgs []string
+	var shown showLogCall
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevParse: revParse,
+		Log: func(args ...string) ([]history.Commit, error) {
+			logArgs = args
+			return commits, nil
+		},
+		ShowLog: func(commits []string, args ...string) error {
+			shown = showLogCall{commits: commits, args: args}
+			return nil
+		},
+	}
+
+	with := []string{"c@x.y"}
+	pair := []string{}
+	options := []string{"-n", "1", "--stat", "--skip=1", "--no-merges", "main..HEAD"}
+	req := Request{With: &with, Pair: &pair, LogArgs: &options}
+
+	expectedShown := showLogCall{commits: []string{"3"}, args: []string{"--stat"}}
+	expectedLogArgs := []string{"--no-merges", "main..HEAD", "--"}
+
+	Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedShown, shown) {
+		t.Errorf("expected: %s, got: %s", expectedShown, shown)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedLogArgs, logArgs) {
+		t.Errorf("expected: %s, got: %s", expectedLogArgs, logArgs)
+		t.Fail()
+	}
+}
+
+func TestLogShouldRestrictTheCommitsToThePaths(t *testing.T) {
+	commits := []history.Commit{
+		{Hash: "1", Author: "A <a@x.y>", Message: "one\n\nCo-authored-by: B <b@x.y>\n"},
+		{Hash: "2", Author: "B <b@x.y>", Message: "two\n\nCo-authored-by: C <C@X.Y>\n"},
+		{Hash: "3", Author: "C <c@x.y>", Message: "three\n"},
+		{Hash: "4", Author: "B <b@x.y>", Message: "four\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>\n"},
+		{Hash: "5", Author: "D <d@x.y>", Message: "five\n"},
+	}
+
+	var logArgs []string
+	var shown showLogCall
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevParse: revParse,
+		Log: func(args ...string) ([]history.Commit, error) {
+			logArgs = args
+			return commits, nil
+		},
+		ShowLog: func(commits []string, args ...string) error {
+			shown = showLogCall{commits: commits, args: args}
+			return nil
+		},
+	}
+
+	with := []string{"a"}
+	pair := []string{}
+	options := []string{"--author", "B", "src", "--", "docs"}
+	req := Request{With: &with, Pair: &pair, LogArgs: &options}
+
+	expectedShown := showLogCall{commits: []string{"1", "4"}, args: []string{"--author=B", "--", "src", "docs"}}
+	expectedLogArgs := []string{"--", "src", "docs"}
+
+	Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedShown, shown) {
+		t.Errorf("expected: %s, got: %s", expectedShown, shown)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedLogArgs, logArgs) {
+		t.Errorf("expected: %s, got: %s", expectedLogArgs, logArgs)
+		t.Fail()
+	}
+}
+
+func TestLogShouldShowNothingWithoutMatchingCommits(t *testing.T) {
+	commits := []history.Commit{
+		{Hash: "1", Author: "A <a@x.y>", Message: "one\n\nCo-authored-by: B <b@x.y>\n"},
+		{Hash: "2", Author: "B <b@x.y>", Message: "two\n\nCo-authored-by: C <C@X.Y>\n"},
+		{Hash: "3", Author: "C <c@x.y>", Message: "three\n"},
+		{Hash: "4", Author: "B <b@x.y>", Message: "four\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>\n"},
+		{Hash: "5", Author: "D <d@x.y>", Message: "five\n"},
+	}
+
+	shown := showLogCall{commits: []string{"untouched"}}
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevParse: revParse,
+		Log: func(args ...string) ([]history.Commit, error) {
+			return commits, nil
+		},
+		ShowLog: func(commits []string, args ...string) error {
+			shown = showLogCall{commits: commits, args: args}
+			return nil
+		},
+	}
+
+	with := []string{"e@x.y"}
+	pair := []string{}
+	options := []string{}
+	req := Request{With: &with, Pair: &pair, LogArgs: &options}
+
+	expectedEvent := Succeeded{}
+	expectedShown := showLogCall{commits: []string{"untouched"}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedShown, shown) {
+		t.Errorf("expected: %s, got: %s", expectedShown, shown)
+		t.Fail()
+	}
+}
+
+func TestLogShouldFailWithoutPeopleToLookFor(t *testing.T) {
+	deps := Dependencies{}
+
+	with := []string{}
+	pair := []string{}
+	options := []string{}
+	req := Request{With: &with, Pair: &pair, LogArgs: &options}
+
+	expectedEvent := Failed{Reason: errors.New("nobody to look for, use --with <person> or --pair <person>,<person>")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestLogShouldFailForAnUnknownAlias(t *testing.T) {
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevParse: revParse,
+		Log: func(args ...string) ([]history.Commit, error) {
+			return []history.Commit{}, nil
+		},
+		ShowLog: func(commits []string, args ...string) error {
+			t.Errorf("unexpected call to git log with: %s", commits)
+			t.Fail()
+			return nil
+		},
+	}
+
+	with := []string{"z"}
+	pair := []string{}
+	options := []string{}
+	req := Request{With: &with, Pair: &pair, LogArgs: &options}
+
+	expectedEvent := Failed{Reason: errors.New("unknown person 'z', expected an alias, an email or \"Name <email>\"")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestLogShouldFailForAnInvalidLimit(t *testing.T) {
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevParse: revParse,
+		Log: func(args ...string) ([]history.Commit, error) {
+			return []history.Commit{}, nil
+		},
+		ShowLog: func(commits []string, args ...string) error {
+			t.Errorf("unexpected call to git log with: %s", commits)
+			t.Fail()
+			return nil
+		},
+	}
+
+	with := []string{"a"}
+	pair := []string{}
+	options := []string{"--max-count=x"}
+	req := Request{With: &with, Pair: &pair, LogArgs: &options}
+
+	expectedEvent := Failed{Reason: errors.New("invalid number 'x' for --max-count=x")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestLogShouldFailOutsideOfAGitRepository(t *testing.T) {
+	deps := Dependencies{
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return false },
+		},
+	}
+
+	with := []string{"a"}
+	pair := []string{}
+	options := []string{}
+	req := Request{With: &with, Pair: &pair, LogArgs: &options}
+
+	expectedEvent := Failed{Reason: errors.New("failed to show the log: not inside a git repository")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestLogShouldFailWhenGitLogFails(t *testing.T) {
+	commits := []history.Commit{
+		{Hash: "1", Author: "A <a@x.y>", Message: "one\n\nCo-authored-by: B <b@x.y>\n"},
+		{Hash: "2", Author: "B <b@x.y>", Message: "two\n\nCo-authored-by: C <C@X.Y>\n"},
+		{Hash: "3", Author: "C <c@x.y>", Message: "three\n"},
+		{Hash: "4", Author: "B <b@x.y>", Message: "four\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>\n"},
+		{Hash: "5", Author: "D <d@x.y>", Message: "five\n"},
+	}
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevParse: revParse,
+		Log: func(args ...string) ([]history.Commit, error) {
+			return commits, nil
+		},
+		ShowLog: func(commits []string, args ...string) error {
+			return errors.New("exit status 128")
+		},
+	}
+
+	with := []string{"a"}
+	pair := []string{}
+	options := []string{}
+	req := Request{With: &with, Pair: &pair, LogArgs: &options}
+
+	expectedEvent := Failed{Reason: errors.New("git log failed: exit status 128")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
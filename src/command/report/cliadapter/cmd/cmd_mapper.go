@@ -0,0 +1,46 @@
+package reportcmdadapter
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/hekmekk/git-team/src/command/report"
+	reporteventadapter "github.com/hekmekk/git-team/src/command/report/cliadapter/event"
+	commandadapter "github.com/hekmekk/git-team/src/shared/cli/commandadapter"
+	sessionlog "github.com/hekmekk/git-team/src/shared/sessionlog/impl"
+)
+
+// Command the report command
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "report",
+		Usage: "Sum up the time git-team has been enabled, per co-author and per repository",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "since", Usage: "Only consider the time after a date, e.g. \"monday\", \"2 weeks ago\" or \"2021-06-01\""},
+			&cli.StringFlag{Name: "format", Value: "text", Usage: fmt.Sprintf("The output format, one of: %s", strings.Join(report.Formats, ", "))},
+		},
+		Action: func(c *cli.Context) error {
+			since := c.String("since")
+			format := c.String("format")
+			return commandadapter.Run(policy(&since, &format), reporteventadapter.MapEventToEffect)
+		},
+	}
+}
+
+func policy(since *string, format *string) report.Policy {
+	return report.Policy{
+		Req: report.Request{
+			Since:  since,
+			Format: format,
+		},
+		Deps: report.Dependencies{
+			SessionLogReader: sessionlog.NewFileDataSource(sessionlog.Path(os.Getenv("HOME")), ioutil.ReadFile),
+			Now:              time.Now,
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package reporteventadapter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/hekmekk/git-team/src/command/report"
+	"github.com/hekmekk/git-team/src/core/events"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+// MapEventToEffect convert report events to effects for the cli
+func MapEventToEffect(event events.Event) effects.Effect {
+	switch evt := event.(type) {
+	case report.Succeeded:
+		switch evt.Format {
+		case "csv":
+			return effects.NewExitOkMsg(toCSV(evt))
+		default:
+			return effects.NewExitOkMsg(toText(evt))
+		}
+	case report.Failed:
+		return effects.NewExitErrMsg(evt.Reason)
+	default:
+		return effects.NewExitOk()
+	}
+}
+
+func toText(evt report.Succeeded) string {
+	if len(evt.Scopes) == 0 && len(evt.Repositories) == 0 {
+		return color.CyanString("no sessions found")
+	}
+
+	var buffer bytes.Buffer
+	writer := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(writer, "co-author\ttime\n")
+	for _, total := range evt.Coauthors {
+		fmt.Fprintf(writer, "%s\t%s\n", total.Name, toHoursAndMinutes(total.Duration))
+	}
+	if len(evt.Scopes) > 0 {
+		fmt.Fprintf(writer, "\n")
+		fmt.Fprintf(writer, "scope\ttime\n")
+		for _, total := range evt.Scopes {
+			fmt.Fprintf(writer, "%s\t%s\n", total.Name, toHoursAndMinutes(total.Duration))
+		}
+	}
+	if len(evt.Repositories) > 0 {
+		fmt.Fprintf(writer, "\n")
+		fmt.Fprintf(writer, "repository\ttime\n")
+		for _, total := range evt.Repositories {
+			fmt.Fprintf(writer, "%s\t%s\n", repositoryName(total.Name), toHoursAndMinutes(total.Duration))
+		}
+	}
+	writer.Flush()
+
+	return strings.TrimRight(buffer.String(), "\n")
+}
+
+func toCSV(evt report.Succeeded) string {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	writer.Write([]string{"type", "name", "hours"})
+	for _, total := range evt.Coauthors {
+		writer.Write([]string{"co-author", total.Name, toHours(total.Duration)})
+	}
+	for _, total := range evt.Scopes {
+		writer.Write([]string{"scope", total.Name, toHours(total.Duration)})
+	}
+	for _, total := range evt.Repositories {
+		writer.Write([]string{"repository", repositoryName(total.Name), toHours(total.Duration)})
+	}
+	writer.Flush()
+
+	return strings.TrimRight(buffer.String(), "\n")
+}
+
+func toHoursAndMinutes(duration time.Duration) string {
+	minutes := int(duration.Round(time.Minute).Minutes())
+	return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+}
+
+func toHours(duration time.Duration) string {
+	return fmt.Sprintf("%.2f", duration.Hours())
+}
+
+// repositoryName the working tree a common git dir belongs to, bare repositories are named after their git dir
+func repositoryName(gitCommonDir string) string {
+	return strings.TrimSuffix(gitCommonDir, "/.git")
+}
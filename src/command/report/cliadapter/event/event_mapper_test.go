@@ -0,0 +1,79 @@
+package reporteventadapter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/hekmekk/git-team/src/command/report"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+var totals = report.Succeeded{
+	Coauthors: []report.Total{
+		{Name: "A <a@x.y>", Duration: 3*time.Hour + 30*time.Minute},
+		{Name: "B <b@x.y>", Duration: 45 * time.Minute},
+	},
+	Scopes: []report.Total{
+		{Name: "global", Duration: 45 * time.Minute},
+	},
+	Repositories: []report.Total{
+		{Name: "/path/to/repo/.git", Duration: 3*time.Hour + 30*time.Minute},
+		{Name: "/path/to/bare.git", Duration: 15 * time.Minute},
+	},
+}
+
+func TestMapEventToEffectSucceededAsText(t *testing.T) {
+	evt := totals
+	evt.Format = "text"
+
+	expectedEffect := effects.NewExitOkMsg("co-author  time\nA <a@x.y>  3h 30m\nB <b@x.y>  0h 45m\n\nscope   time\nglobal  0h 45m\n\nrepository         time\n/path/to/repo      3h 30m\n/path/to/bare.git  0h 15m")
+
+	effect := MapEventToEffect(evt)
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectSucceededAsCSV(t *testing.T) {
+	evt := totals
+	evt.Format = "csv"
+
+	expectedEffect := effects.NewExitOkMsg("type,name,hours\nco-author,A <a@x.y>,3.50\nco-author,B <b@x.y>,0.75\nscope,global,0.75\nrepository,/path/to/repo,3.50\nrepository,/path/to/bare.git,0.25")
+
+	effect := MapEventToEffect(evt)
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectSucceededWithoutSessions(t *testing.T) {
+	expectedEffect := effects.NewExitOkMsg(color.CyanString("no sessions found"))
+
+	effect := MapEventToEffect(report.Succeeded{Coauthors: []report.Total{}, Scopes: []report.Total{}, Repositories: []report.Total{}, Format: "text"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectFailed(t *testing.T) {
+	err := errors.New("failed to read session log: permission denied")
+
+	expectedEffect := effects.NewExitErrMsg(err)
+
+	effect := MapEventToEffect(report.Failed{Reason: err})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
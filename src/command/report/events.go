@@ -0,0 +1,26 @@
+package report
+
+import (
+	"time"
+)
+
+// Total the time spent with a co-author, in an activation scope or in a repository
+type Total struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Succeeded the time spent in sessions has been summed up
+type Succeeded struct {
+	Coauthors []Total
+	// Scopes the time spent in the global and the directory scope
+	Scopes []Total
+	// Repositories the time spent in repo-local sessions, per common git dir
+	Repositories []Total
+	Format       string
+}
+
+// Failed failed to sum up the time spent in sessions
+type Failed struct {
+	Reason error
+}
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hekmekk/git-team/src/core/events"
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	sessionlog "github.com/hekmekk/git-team/src/shared/sessionlog/interface"
+)
+
+// Formats the supported output formats
+var Formats = []string{"text", "csv"}
+
+// Dependencies the dependencies of the report Policy module
+type Dependencies struct {
+	SessionLogReader sessionlog.Reader
+	Now              func() time.Time
+}
+
+// Request the period to report on and how to render the result
+type Request struct {
+	Since  *string
+	Format *string
+}
+
+// Policy the policy to apply
+type Policy struct {
+	Deps Dependencies
+	Req  Request
+}
+
+// Apply sum up the time spent in sessions per co-author and per repository, or per scope for sessions which are not repo-local
+func (policy Policy) Apply() events.Event {
+	deps := policy.Deps
+	req := policy.Req
+
+	format := *req.Format
+	if format == "" {
+		format = Formats[0]
+	}
+
+	if !isOneOf(format, Formats) {
+		return Failed{Reason: fmt.Errorf("unknown format '%s', expected one of: %s", format, strings.Join(Formats, ", "))}
+	}
+
+	now := deps.Now()
+
+	since, err := ParseSince(*req.Since, now)
+	if err != nil {
+		return Failed{Reason: err}
+	}
+
+	sessionEvents, err := deps.SessionLogReader.Read()
+	if err != nil {
+		return Failed{Reason: err}
+	}
+
+	coauthors := make(map[string]time.Duration)
+	repositories := make(map[string]time.Duration)
+	scopes := make(map[string]time.Duration)
+
+	for _, session := range Sessions(sessionEvents, now) {
+		start := session.Start
+		if start.Before(since) {
+			start = since
+		}
+
+		if !session.End.After(start) {
+			continue
+		}
+
+		duration := session.End.Sub(start)
+		for _, coauthor := range session.Coauthors {
+			coauthors[coauthor] += duration
+		}
+
+		if session.Scope == activationscope.RepoLocal {
+			repositories[session.Repository] += duration
+		} else {
+			scopes[session.Scope.String()] += duration
+		}
+	}
+
+	return Succeeded{Coauthors: toTotals(coauthors), Scopes: toTotals(scopes), Repositories: toTotals(repositories), Format: format}
+}
+
+// toTotals the totals sorted by duration, the longest first
+func toTotals(durations map[string]time.Duration) []Total {
+	totals := []Total{}
+	for name, duration := range durations {
+		totals = append(totals, Total{Name: name, Duration: duration})
+	}
+
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Duration != totals[j].Duration {
+			return totals[i].Duration > totals[j].Duration
+		}
+		return totals[i].Name < totals[j].Name
+	})
+
+	return totals
+}
+
+func isOneOf(candidate string, values []string) bool {
+	for _, value := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
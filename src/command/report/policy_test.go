@@ -0,0 +1,248 @@
+package report
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	sessionlog "github.com/hekmekk/git-team/src/shared/sessionlog/entity"
+)
+
+type sessionLogReaderMock struct {
+	read func() ([]sessionlog.Event, error)
+}
+
+func (mock sessionLogReaderMock) Read() ([]sessionlog.Event, error) {
+	return mock.read()
+}
+
+// Sunday, 2021-06-13
+var now = time.Date(2021, 6, 13, 12, 0, 0, 0, time.UTC)
+
+func at(day int, hour int, minute int) time.Time {
+	return time.Date(2021, 6, day, hour, minute, 0, 0, time.UTC)
+}
+
+func enabled(t time.Time, scope activationscope.Scope, repository string, coauthors ...string) sessionlog.Event {
+	return sessionlog.Event{Time: t, Action: sessionlog.Enabled, Scope: scope, Repository: repository, Coauthors: coauthors}
+}
+
+func disabled(t time.Time, scope activationscope.Scope, repository string) sessionlog.Event {
+	return sessionlog.Event{Time: t, Action: sessionlog.Disabled, Scope: scope, Repository: repository, Coauthors: []string{}}
+}
+
+func newDeps(sessionEvents []sessionlog.Event) Dependencies {
+	return Dependencies{
+		SessionLogReader: &sessionLogReaderMock{
+			read: func() ([]sessionlog.Event, error) {
+				return sessionEvents, nil
+			},
+		},
+		Now: func() time.Time { return now },
+	}
+}
+
+func TestReportSumsUpTheTimePerCoauthorAndScope(t *testing.T) {
+	sessionEvents := []sessionlog.Event{
+		enabled(at(7, 9, 0), activationscope.Global, "", "A <a@x.y>", "B <b@x.y>"),
+		disabled(at(7, 11, 30), activationscope.Global, ""),
+		enabled(at(8, 9, 0), activationscope.Global, "", "A <a@x.y>"),
+		enabled(at(8, 10, 0), activationscope.Directory, "", "C <c@x.y>"),
+		disabled(at(8, 10, 15), activationscope.Directory, ""),
+		disabled(at(8, 11, 0), activationscope.Global, ""),
+	}
+
+	expectedEvent := Succeeded{
+		Coauthors: []Total{
+			{Name: "A <a@x.y>", Duration: 4*time.Hour + 30*time.Minute},
+			{Name: "B <b@x.y>", Duration: 2*time.Hour + 30*time.Minute},
+			{Name: "C <c@x.y>", Duration: 15 * time.Minute},
+		},
+		Scopes: []Total{
+			{Name: "global", Duration: 4*time.Hour + 30*time.Minute},
+			{Name: "directory", Duration: 15 * time.Minute},
+		},
+		Repositories: []Total{},
+		Format:       "text",
+	}
+
+	event := Policy{Deps: newDeps(sessionEvents), Req: Request{Since: &[]string{""}[0], Format: &[]string{""}[0]}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestReportKeepsRepoLocalSessionsOfDifferentRepositoriesApart(t *testing.T) {
+	sessionEvents := []sessionlog.Event{
+		enabled(at(7, 9, 0), activationscope.RepoLocal, "/path/to/a/.git", "A <a@x.y>"),
+		enabled(at(7, 10, 0), activationscope.RepoLocal, "/path/to/b/.git", "B <b@x.y>"),
+		disabled(at(7, 11, 0), activationscope.RepoLocal, "/path/to/a/.git"),
+		disabled(at(7, 11, 30), activationscope.RepoLocal, "/path/to/b/.git"),
+	}
+
+	expectedEvent := Succeeded{
+		Coauthors: []Total{
+			{Name: "A <a@x.y>", Duration: 2 * time.Hour},
+			{Name: "B <b@x.y>", Duration: time.Hour + 30*time.Minute},
+		},
+		Scopes: []Total{},
+		Repositories: []Total{
+			{Name: "/path/to/a/.git", Duration: 2 * time.Hour},
+			{Name: "/path/to/b/.git", Duration: time.Hour + 30*time.Minute},
+		},
+		Format: "csv",
+	}
+
+	event := Policy{Deps: newDeps(sessionEvents), Req: Request{Since: &[]string{""}[0], Format: &[]string{"csv"}[0]}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestReportAddsUpTheRepoLocalSessionsOfAllWorktreesOfARepository(t *testing.T) {
+	// the common git dir is the same for all worktrees of a repository
+	sessionEvents := []sessionlog.Event{
+		enabled(at(7, 9, 0), activationscope.RepoLocal, "/path/to/a/.git", "A <a@x.y>"),
+		enabled(at(7, 10, 0), activationscope.RepoLocal, "/path/to/a/.git", "B <b@x.y>"),
+		disabled(at(7, 11, 0), activationscope.RepoLocal, "/path/to/a/.git"),
+	}
+
+	expectedEvent := Succeeded{
+		Coauthors: []Total{
+			{Name: "A <a@x.y>", Duration: time.Hour},
+			{Name: "B <b@x.y>", Duration: time.Hour},
+		},
+		Scopes: []Total{},
+		Repositories: []Total{
+			{Name: "/path/to/a/.git", Duration: 2 * time.Hour},
+		},
+		Format: "text",
+	}
+
+	event := Policy{Deps: newDeps(sessionEvents), Req: Request{Since: &[]string{""}[0], Format: &[]string{"text"}[0]}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestReportOnlyConsidersTheTimeSinceTheGivenDate(t *testing.T) {
+	sessionEvents := []sessionlog.Event{
+		enabled(at(4, 9, 0), activationscope.Global, "", "A <a@x.y>"),
+		disabled(at(4, 17, 0), activationscope.Global, ""),
+		enabled(at(6, 23, 0), activationscope.Global, "", "B <b@x.y>"),
+		disabled(at(7, 1, 0), activationscope.Global, ""),
+		enabled(at(13, 11, 0), activationscope.Global, "", "C <c@x.y>"),
+	}
+
+	expectedEvent := Succeeded{
+		Coauthors: []Total{
+			{Name: "B <b@x.y>", Duration: time.Hour},
+			{Name: "C <c@x.y>", Duration: time.Hour},
+		},
+		Scopes: []Total{
+			{Name: "global", Duration: 2 * time.Hour},
+		},
+		Repositories: []Total{},
+		Format:       "text",
+	}
+
+	event := Policy{Deps: newDeps(sessionEvents), Req: Request{Since: &[]string{"monday"}[0], Format: &[]string{"text"}[0]}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestReportFailsOnUnknownFormat(t *testing.T) {
+	expectedEvent := Failed{Reason: errors.New("unknown format 'json', expected one of: text, csv")}
+
+	event := Policy{Deps: newDeps(nil), Req: Request{Since: &[]string{""}[0], Format: &[]string{"json"}[0]}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestReportFailsOnInvalidDate(t *testing.T) {
+	expectedEvent := Failed{Reason: errors.New("invalid date 'someday', expected a weekday, today, yesterday, '<n> days ago' or YYYY-MM-DD")}
+
+	event := Policy{Deps: newDeps(nil), Req: Request{Since: &[]string{"someday"}[0], Format: &[]string{""}[0]}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestReportFailsWhenReadingTheSessionLogFails(t *testing.T) {
+	err := errors.New("failed to read session log: permission denied")
+
+	deps := newDeps(nil)
+	deps.SessionLogReader = &sessionLogReaderMock{
+		read: func() ([]sessionlog.Event, error) {
+			return nil, err
+		},
+	}
+
+	expectedEvent := Failed{Reason: err}
+
+	event := Policy{Deps: deps, Req: Request{Since: &[]string{""}[0], Format: &[]string{""}[0]}}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		value        string
+		expectedTime time.Time
+	}{
+		{"", time.Time{}},
+		{"today", at(13, 0, 0)},
+		{"yesterday", at(12, 0, 0)},
+		{"Monday", at(7, 0, 0)},
+		{"sunday", at(13, 0, 0)},
+		{"saturday", at(12, 0, 0)},
+		{"3 hours ago", at(13, 9, 0)},
+		{"2 days ago", at(11, 12, 0)},
+		{"1.week.ago", at(6, 12, 0)},
+		{"1 month ago", time.Date(2021, 5, 13, 12, 0, 0, 0, time.UTC)},
+		{"2021-06-01", at(1, 0, 0)},
+		{"2021-06-01 08:30", at(1, 8, 30)},
+	}
+
+	for _, caseLoopVar := range cases {
+		value := caseLoopVar.value
+		expectedTime := caseLoopVar.expectedTime
+
+		t.Run(value, func(t *testing.T) {
+			t.Parallel()
+
+			since, err := ParseSince(value, now)
+
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+				t.Fail()
+			}
+
+			if !expectedTime.Equal(since) {
+				t.Errorf("expected: %s, got: %s", expectedTime, since)
+				t.Fail()
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package report
+
+import (
+	"sort"
+	"time"
+
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	sessionlog "github.com/hekmekk/git-team/src/shared/sessionlog/entity"
+)
+
+// Session a period of time in which git-team has been enabled with the same co-authors, Repository being the common git dir of repo-local sessions
+type Session struct {
+	Start      time.Time
+	End        time.Time
+	Scope      activationscope.Scope
+	Repository string
+	Coauthors  []string
+}
+
+// Sessions pair up the events of the session log. A session lasts from enabling git-team until it is disabled or enabled again in the same activation scope, sessions which are still running end now.
+func Sessions(events []sessionlog.Event, now time.Time) []Session {
+	sessions := []Session{}
+	running := make(map[string]sessionlog.Event)
+
+	for _, event := range events {
+		key := activationKey(event)
+
+		if start, isRunning := running[key]; isRunning {
+			sessions = append(sessions, Session{Start: start.Time, End: event.Time, Scope: start.Scope, Repository: start.Repository, Coauthors: start.Coauthors})
+			delete(running, key)
+		}
+
+		if event.Action == sessionlog.Enabled {
+			running[key] = event
+		}
+	}
+
+	for _, start := range running {
+		sessions = append(sessions, Session{Start: start.Time, End: now, Scope: start.Scope, Repository: start.Repository, Coauthors: start.Coauthors})
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].Start.Before(sessions[j].Start)
+	})
+
+	return sessions
+}
+
+// repo-local sessions of different repositories run independently of each other
+func activationKey(event sessionlog.Event) string {
+	if event.Scope == activationscope.RepoLocal {
+		return event.Scope.String() + ":" + event.Repository
+	}
+	return event.Scope.String()
+}
@@ -0,0 +1,55 @@
+package report
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var relativeDatePattern = regexp.MustCompile(`^([0-9]+)[ .](hour|day|week|month)s?[ .]ago$`)
+
+// ParseSince the point in time a date refers to: a weekday means the most recent one, today included, at midnight
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch value {
+	case "":
+		return time.Time{}, nil
+	case "today":
+		return midnight, nil
+	case "yesterday":
+		return midnight.AddDate(0, 0, -1), nil
+	}
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if value == strings.ToLower(weekday.String()) {
+			daysAgo := (int(now.Weekday()) - int(weekday) + 7) % 7
+			return midnight.AddDate(0, 0, -daysAgo), nil
+		}
+	}
+
+	if match := relativeDatePattern.FindStringSubmatch(value); match != nil {
+		n, _ := strconv.Atoi(match[1])
+		switch match[2] {
+		case "hour":
+			return now.Add(-time.Duration(n) * time.Hour), nil
+		case "day":
+			return now.AddDate(0, 0, -n), nil
+		case "week":
+			return now.AddDate(0, 0, -7*n), nil
+		default:
+			return now.AddDate(0, -n, 0), nil
+		}
+	}
+
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02"} {
+		if date, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date '%s', expected a weekday, today, yesterday, '<n> days ago' or YYYY-MM-DD", value)
+}
@@ -0,0 +1,67 @@
+package retrocmdadapter
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/hekmekk/git-team/src/command/retro"
+	retroeventadapter "github.com/hekmekk/git-team/src/command/retro/cliadapter/event"
+	"github.com/hekmekk/git-team/src/core/validation"
+	activation "github.com/hekmekk/git-team/src/shared/activation/impl"
+	commandadapter "github.com/hekmekk/git-team/src/shared/cli/commandadapter"
+	aliascompletion "github.com/hekmekk/git-team/src/shared/completion"
+	configds "github.com/hekmekk/git-team/src/shared/config/datasource"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/impl"
+	"github.com/hekmekk/git-team/src/shared/gitrepo"
+	state "github.com/hekmekk/git-team/src/shared/state/impl"
+)
+
+// Command the retro command
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:      "retro",
+		Usage:     "Add co-authors to the unpushed commits of a range on the current branch, defaults to the active co-authors",
+		ArgsUsage: "<upstream>[..HEAD] [<co-authors>] (A co-author must either be an alias or of the shape \"Name <email>\")",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "force", Value: false, Aliases: []string{"f"}, Usage: "Rewrite the commits even if they have been pushed already"},
+		},
+		Action: func(c *cli.Context) error {
+			revisionRange := c.Args().First()
+			coauthors := c.Args().Tail()
+			force := c.Bool("force")
+			return commandadapter.Run(policy(&revisionRange, &coauthors, &force), retroeventadapter.MapEventToEffect)
+		},
+		BashComplete: func(c *cli.Context) {
+			if c.Args().Len() == 0 {
+				return
+			}
+			remainingAliases := aliascompletion.NewAliasShellCompletion(gitconfig.NewDataSource()).Complete(c.Args().Tail())
+			for _, alias := range remainingAliases {
+				fmt.Println(alias)
+			}
+		},
+	}
+}
+
+func policy(revisionRange *string, coauthors *[]string, force *bool) retro.Policy {
+	return retro.Policy{
+		Req: retro.Request{
+			Range:               revisionRange,
+			AliasesAndCoauthors: coauthors,
+			Force:               force,
+		},
+		Deps: retro.Dependencies{
+			SanityCheckCoauthors: validation.SanityCheckCoauthors,
+			GitResolveAliases:    commandadapter.ResolveAliases,
+			ConfigReader:         configds.NewGitconfigDataSource(gitconfig.NewDataSource()),
+			StateReader:          state.NewGitConfigDataSource(gitconfig.NewDataSource()),
+			ActivationValidator:  activation.NewGitConfigDataSource(gitconfig.NewDataSource()),
+			RevList:              gitrepo.RevList,
+			UpdateRef:            gitrepo.UpdateRef,
+			Rebase:               gitrepo.Rebase,
+			GetExecutable:        os.Executable,
+		},
+	}
+}
@@ -0,0 +1,33 @@
+package retroeventadapter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hekmekk/git-team/src/command/retro"
+	"github.com/hekmekk/git-team/src/core/events"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+// MapEventToEffect convert retro events to effects for the cli
+func MapEventToEffect(event events.Event) effects.Effect {
+	switch evt := event.(type) {
+	case retro.Succeeded:
+		return effects.NewExitOkMsg(fmt.Sprintf("Rewrote %d commit(s) with co-authors: %s\nThe previous state is available as ORIG_HEAD, e.g. for 'git reset --hard ORIG_HEAD'", evt.Commits, strings.Join(evt.Coauthors, ", ")))
+	case retro.Failed:
+		return effects.NewExitErrMsg(foldErrors(evt.Reason))
+	default:
+		return effects.NewExitOk()
+	}
+}
+
+func foldErrors(validationErrors []error) error {
+	var buffer bytes.Buffer
+	for _, err := range validationErrors {
+		buffer.WriteString(err.Error())
+		buffer.WriteString("; ")
+	}
+	return errors.New(strings.TrimRight(buffer.String(), "; "))
+}
@@ -0,0 +1,43 @@
+package retroeventadapter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hekmekk/git-team/src/command/retro"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+func TestMapEventToEffectSucceeded(t *testing.T) {
+	expectedEffect := effects.NewExitOkMsg("Rewrote 3 commit(s) with co-authors: A <a@x.y>\nThe previous state is available as ORIG_HEAD, e.g. for 'git reset --hard ORIG_HEAD'")
+
+	effect := MapEventToEffect(retro.Succeeded{Commits: 3, Coauthors: []string{"A <a@x.y>"}})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectFailed(t *testing.T) {
+	expectedEffect := effects.NewExitErrMsg(errors.New("failure_a; failure_b"))
+
+	effect := MapEventToEffect(retro.Failed{Reason: []error{errors.New("failure_a"), errors.New("failure_b")}})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectUnknownEvent(t *testing.T) {
+	expectedEffect := effects.NewExitOk()
+
+	effect := MapEventToEffect("UNKNOWN_EVENT")
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
@@ -0,0 +1,12 @@
+package retro
+
+// Succeeded the commits have been rewritten to include the co-authors
+type Succeeded struct {
+	Commits   int
+	Coauthors []string
+}
+
+// Failed failed to rewrite the commits
+type Failed struct {
+	Reason []error
+}
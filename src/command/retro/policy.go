@@ -0,0 +1,173 @@
+package retro
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	utils "github.com/hekmekk/git-team/src/command/enable/utils"
+	"github.com/hekmekk/git-team/src/core/events"
+	activation "github.com/hekmekk/git-team/src/shared/activation/interface"
+	config "github.com/hekmekk/git-team/src/shared/config/interface"
+	state "github.com/hekmekk/git-team/src/shared/state/interface"
+)
+
+// Dependencies the dependencies of the retro Policy module
+type Dependencies struct {
+	SanityCheckCoauthors func([]string) []error
+	GitResolveAliases    func(aliases []string) ([]string, []error)
+	ConfigReader         config.Reader
+	StateReader          state.Reader
+	ActivationValidator  activation.Validator
+	RevList              func(args ...string) ([]string, error)
+	UpdateRef            func(ref string, rev string) error
+	Rebase               func(args ...string) error
+	GetExecutable        func() (string, error)
+}
+
+// Request the range of commits to rewrite along with the co-authors to add, falling back to the active co-authors
+type Request struct {
+	Range               *string
+	AliasesAndCoauthors *[]string
+	Force               *bool
+}
+
+// Policy the policy to apply
+type Policy struct {
+	Deps Dependencies
+	Req  Request
+}
+
+// Apply rewrite the commits of the range to include the co-authors, by means of a non-interactive rebase which amends each commit
+func (policy Policy) Apply() events.Event {
+	deps := policy.Deps
+	req := policy.Req
+
+	if !deps.ActivationValidator.IsInsideAGitRepository() {
+		return Failed{Reason: []error{errors.New("failed to rewrite commits: not inside a git repository")}}
+	}
+
+	upstream, tip, err := parseRange(*req.Range)
+	if err != nil {
+		return Failed{Reason: []error{err}}
+	}
+
+	coauthors, errs := utils.ResolveCoauthors(deps.SanityCheckCoauthors, deps.GitResolveAliases, *req.AliasesAndCoauthors)
+	if len(errs) > 0 {
+		return Failed{Reason: errs}
+	}
+
+	if len(coauthors) == 0 {
+		cfg, err := deps.ConfigReader.Read()
+		if err != nil {
+			return Failed{Reason: []error{fmt.Errorf("failed to read config: %s", err)}}
+		}
+
+		currState, err := deps.StateReader.Query(cfg.ActivationScope)
+		if err != nil {
+			return Failed{Reason: []error{fmt.Errorf("failed to query current state: %s", err)}}
+		}
+
+		coauthors = append([]string{}, currState.Coauthors...)
+		sort.Strings(coauthors)
+	}
+
+	if len(coauthors) == 0 {
+		return Failed{Reason: []error{errors.New("no co-authors given and none are active")}}
+	}
+
+	head, err := resolve(deps, "HEAD")
+	if err != nil {
+		return Failed{Reason: []error{err}}
+	}
+
+	resolvedTip, err := resolve(deps, tip)
+	if err != nil {
+		return Failed{Reason: []error{err}}
+	}
+
+	if resolvedTip != head {
+		return Failed{Reason: []error{fmt.Errorf("the range '%s' must end at HEAD, check out the branch to rewrite first", *req.Range)}}
+	}
+
+	commits, err := deps.RevList(upstream + "..HEAD")
+	if err != nil {
+		return Failed{Reason: []error{err}}
+	}
+
+	if len(commits) == 0 {
+		return Failed{Reason: []error{fmt.Errorf("there are no commits in '%s'", *req.Range)}}
+	}
+
+	if !*req.Force {
+		unpublished, err := deps.RevList(upstream+"..HEAD", "--not", "--remotes")
+		if err != nil {
+			return Failed{Reason: []error{err}}
+		}
+
+		if published := len(commits) - len(unpublished); published > 0 {
+			return Failed{Reason: []error{fmt.Errorf("%d of the commits in '%s' are reachable from a remote-tracking branch, use --force to rewrite them anyway", published, *req.Range)}}
+		}
+	}
+
+	executable, err := deps.GetExecutable()
+	if err != nil {
+		return Failed{Reason: []error{fmt.Errorf("failed to locate git-team: %s", err)}}
+	}
+
+	amendCommand := []string{shellQuote(executable), "amend", "--force", "--"}
+	for _, coauthor := range coauthors {
+		amendCommand = append(amendCommand, shellQuote(coauthor))
+	}
+
+	if err := deps.Rebase("--rebase-merges", "--autostash", "--exec", strings.Join(amendCommand, " "), upstream); err != nil {
+		return Failed{Reason: []error{err}}
+	}
+
+	// each amend during the rebase has moved ORIG_HEAD, so point it at the branch as it was before the rewrite for recovery
+	if err := deps.UpdateRef("ORIG_HEAD", head); err != nil {
+		return Failed{Reason: []error{err}}
+	}
+
+	return Succeeded{Commits: len(commits), Coauthors: coauthors}
+}
+
+// parseRange split a range of the shape <upstream>..[<tip>] or <upstream> into the upstream and the tip, which defaults to HEAD
+func parseRange(revisionRange string) (string, string, error) {
+	if revisionRange == "" {
+		return "", "", errors.New("the range of commits to rewrite is missing")
+	}
+
+	if strings.Contains(revisionRange, "...") {
+		return "", "", fmt.Errorf("unsupported range '%s', expected <upstream>..[HEAD]", revisionRange)
+	}
+
+	parts := strings.SplitN(revisionRange, "..", 2)
+	if parts[0] == "" {
+		return "", "", fmt.Errorf("unsupported range '%s', expected <upstream>..[HEAD]", revisionRange)
+	}
+
+	if len(parts) == 1 || parts[1] == "" {
+		return parts[0], "HEAD", nil
+	}
+
+	return parts[0], parts[1], nil
+}
+
+func resolve(deps Dependencies, rev string) (string, error) {
+	commits, err := deps.RevList("--max-count=1", rev)
+	if err != nil {
+		return "", err
+	}
+
+	if len(commits) == 0 {
+		return "", fmt.Errorf("unknown revision '%s'", rev)
+	}
+
+	return commits[0], nil
+}
+
+func shellQuote(arg string) string {
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
@@ -0,0 +1,642 @@
+package retro
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	config "github.com/hekmekk/git-team/src/shared/config/entity/config"
+	state "github.com/hekmekk/git-team/src/shared/state/entity"
+)
+
+type configReaderMock struct {
+	read func() (config.Config, error)
+}
+
+func (mock configReaderMock) Read() (config.Config, error) {
+	return mock.read()
+}
+
+type stateReaderMock struct {
+	query func(activationscope.Scope) (state.State, error)
+}
+
+func (mock stateReaderMock) Query(scope activationscope.Scope) (state.State, error) {
+	return mock.query(scope)
+}
+
+type activationValidatorMock struct {
+	isInsideAGitRepository func() bool
+}
+
+func (mock activationValidatorMock) IsInsideAGitRepository() bool {
+	return mock.isInsideAGitRepository()
+}
+
+type repository struct {
+	revs        map[string]string
+	commits     []string
+	unpublished []string
+	rebaseArgs  []string
+	origHead    string
+}
+
+func newRepository() *repository {
+	return &repository{
+		revs:        map[string]string{"HEAD": "c3", "main": "c3", "HEAD~1": "c2"},
+		commits:     []string{"c3", "c2", "c1"},
+		unpublished: []string{"c3", "c2", "c1"},
+	}
+}
+
+func TestRetroShouldRewriteTheCommitsOfTheRange(t *testing.T) {
+	t.Parallel()
+
+	for _, revisionRange := range []string{"origin/main", "origin/main..", "origin/main..HEAD", "origin/main..main"} {
+		revisionRange := revisionRange
+
+		t.Run(revisionRange, func(t *testing.T) {
+			t.Parallel()
+
+			repo := newRepository()
+
+			deps := Dependencies{
+				SanityCheckCoauthors: func([]string) []error { return []error{} },
+				GitResolveAliases: func(aliases []string) ([]string, []error) {
+					resolved := []string{}
+					for _, alias := range aliases {
+						resolved = append(resolved, fmt.Sprintf("%s <%s@x.y>", alias, alias))
+					}
+					return resolved, []error{}
+				},
+				ConfigReader: &configReaderMock{
+					read: func() (config.Config, error) {
+						return config.Config{ActivationScope: activationscope.Global}, nil
+					},
+				},
+				StateReader: &stateReaderMock{
+					query: func(activationscope.Scope) (state.State, error) {
+						return state.NewStateDisabled(), nil
+					},
+				},
+				ActivationValidator: &activationValidatorMock{
+					isInsideAGitRepository: func() bool { return true },
+				},
+				RevList: func(args ...string) ([]string, error) {
+					if args[0] == "--max-count=1" {
+						rev, ok := repo.revs[args[1]]
+						if !ok {
+							return []string{}, nil
+						}
+						return []string{rev}, nil
+					}
+					if len(args) > 1 && args[1] == "--not" {
+						return repo.unpublished, nil
+					}
+					return repo.commits, nil
+				},
+				UpdateRef: func(ref string, rev string) error {
+					repo.origHead = ref + "=" + rev
+					return nil
+				},
+				Rebase: func(args ...string) error {
+					repo.rebaseArgs = args
+					return nil
+				},
+				GetExecutable: func() (string, error) {
+					return "/usr/bin/git-team", nil
+				},
+			}
+
+			aliasesAndCoauthors := []string{"a", "B O'Neil <b@x.y>"}
+			force := false
+			req := Request{Range: &revisionRange, AliasesAndCoauthors: &aliasesAndCoauthors, Force: &force}
+
+			expectedEvent := Succeeded{Commits: 3, Coauthors: []string{"B O'Neil <b@x.y>", "a <a@x.y>"}}
+			expectedRebaseArgs := []string{"--rebase-merges", "--autostash", "--exec", `'/usr/bin/git-team' amend --force -- 'B O'\''Neil <b@x.y>' 'a <a@x.y>'`, "origin/main"}
+
+			event := Policy{deps, req}.Apply()
+
+			if !reflect.DeepEqual(expectedEvent, event) {
+				t.Errorf("expected: %v, got: %v", expectedEvent, event)
+				t.Fail()
+			}
+
+			if !reflect.DeepEqual(expectedRebaseArgs, repo.rebaseArgs) {
+				t.Errorf("expected: %s, got: %s", expectedRebaseArgs, repo.rebaseArgs)
+				t.Fail()
+			}
+
+			if repo.origHead != "ORIG_HEAD=c3" {
+				t.Errorf("expected ORIG_HEAD to point at the previous HEAD, got: %s", repo.origHead)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestRetroShouldFallBackToTheActiveCoauthors(t *testing.T) {
+	t.Parallel()
+
+	repo := newRepository()
+
+	deps := Dependencies{
+		SanityCheckCoauthors: func([]string) []error { return []error{} },
+		GitResolveAliases: func(aliases []string) ([]string, []error) {
+			resolved := []string{}
+			for _, alias := range aliases {
+				resolved = append(resolved, fmt.Sprintf("%s <%s@x.y>", alias, alias))
+			}
+			return resolved, []error{}
+		},
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		StateReader: &stateReaderMock{
+			query: func(activationscope.Scope) (state.State, error) {
+				return state.NewStateEnabled([]string{"B <b@x.y>", "A <a@x.y>"}), nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevList: func(args ...string) ([]string, error) {
+			if args[0] == "--max-count=1" {
+				rev, ok := repo.revs[args[1]]
+				if !ok {
+					return []string{}, nil
+				}
+				return []string{rev}, nil
+			}
+			if len(args) > 1 && args[1] == "--not" {
+				return repo.unpublished, nil
+			}
+			return repo.commits, nil
+		},
+		UpdateRef: func(ref string, rev string) error {
+			repo.origHead = ref + "=" + rev
+			return nil
+		},
+		Rebase: func(args ...string) error {
+			repo.rebaseArgs = args
+			return nil
+		},
+		GetExecutable: func() (string, error) {
+			return "/usr/bin/git-team", nil
+		},
+	}
+
+	revisionRange := "origin/main"
+	aliasesAndCoauthors := []string{}
+	force := false
+	req := Request{Range: &revisionRange, AliasesAndCoauthors: &aliasesAndCoauthors, Force: &force}
+
+	expectedEvent := Succeeded{Commits: 3, Coauthors: []string{"A <a@x.y>", "B <b@x.y>"}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestRetroShouldRefuseToRewritePublishedCommits(t *testing.T) {
+	t.Parallel()
+
+	repo := newRepository()
+	repo.unpublished = []string{"c3"}
+
+	deps := Dependencies{
+		SanityCheckCoauthors: func([]string) []error { return []error{} },
+		GitResolveAliases: func(aliases []string) ([]string, []error) {
+			resolved := []string{}
+			for _, alias := range aliases {
+				resolved = append(resolved, fmt.Sprintf("%s <%s@x.y>", alias, alias))
+			}
+			return resolved, []error{}
+		},
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		StateReader: &stateReaderMock{
+			query: func(activationscope.Scope) (state.State, error) {
+				return state.NewStateDisabled(), nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevList: func(args ...string) ([]string, error) {
+			if args[0] == "--max-count=1" {
+				rev, ok := repo.revs[args[1]]
+				if !ok {
+					return []string{}, nil
+				}
+				return []string{rev}, nil
+			}
+			if len(args) > 1 && args[1] == "--not" {
+				return repo.unpublished, nil
+			}
+			return repo.commits, nil
+		},
+		UpdateRef: func(ref string, rev string) error {
+			repo.origHead = ref + "=" + rev
+			return nil
+		},
+		Rebase: func(args ...string) error {
+			repo.rebaseArgs = args
+			return nil
+		},
+		GetExecutable: func() (string, error) {
+			return "/usr/bin/git-team", nil
+		},
+	}
+
+	revisionRange := "HEAD~3"
+	aliasesAndCoauthors := []string{"a"}
+	force := false
+	req := Request{Range: &revisionRange, AliasesAndCoauthors: &aliasesAndCoauthors, Force: &force}
+
+	expectedEvent := Failed{Reason: []error{errors.New("2 of the commits in 'HEAD~3' are reachable from a remote-tracking branch, use --force to rewrite them anyway")}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+
+	if repo.rebaseArgs != nil {
+		t.Errorf("expected no rebase, got: %s", repo.rebaseArgs)
+		t.Fail()
+	}
+}
+
+func TestRetroShouldRewritePublishedCommitsWhenForced(t *testing.T) {
+	t.Parallel()
+
+	repo := newRepository()
+	repo.unpublished = []string{}
+
+	deps := Dependencies{
+		SanityCheckCoauthors: func([]string) []error { return []error{} },
+		GitResolveAliases: func(aliases []string) ([]string, []error) {
+			resolved := []string{}
+			for _, alias := range aliases {
+				resolved = append(resolved, fmt.Sprintf("%s <%s@x.y>", alias, alias))
+			}
+			return resolved, []error{}
+		},
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		StateReader: &stateReaderMock{
+			query: func(activationscope.Scope) (state.State, error) {
+				return state.NewStateDisabled(), nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevList: func(args ...string) ([]string, error) {
+			if args[0] == "--max-count=1" {
+				rev, ok := repo.revs[args[1]]
+				if !ok {
+					return []string{}, nil
+				}
+				return []string{rev}, nil
+			}
+			if len(args) > 1 && args[1] == "--not" {
+				return repo.unpublished, nil
+			}
+			return repo.commits, nil
+		},
+		UpdateRef: func(ref string, rev string) error {
+			repo.origHead = ref + "=" + rev
+			return nil
+		},
+		Rebase: func(args ...string) error {
+			repo.rebaseArgs = args
+			return nil
+		},
+		GetExecutable: func() (string, error) {
+			return "/usr/bin/git-team", nil
+		},
+	}
+
+	revisionRange := "HEAD~3"
+	aliasesAndCoauthors := []string{"a"}
+	force := true
+	req := Request{Range: &revisionRange, AliasesAndCoauthors: &aliasesAndCoauthors, Force: &force}
+
+	expectedEvent := Succeeded{Commits: 3, Coauthors: []string{"a <a@x.y>"}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestRetroShouldFailForARangeWhichDoesNotEndAtHEAD(t *testing.T) {
+	t.Parallel()
+
+	repo := newRepository()
+
+	deps := Dependencies{
+		SanityCheckCoauthors: func([]string) []error { return []error{} },
+		GitResolveAliases: func(aliases []string) ([]string, []error) {
+			resolved := []string{}
+			for _, alias := range aliases {
+				resolved = append(resolved, fmt.Sprintf("%s <%s@x.y>", alias, alias))
+			}
+			return resolved, []error{}
+		},
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		StateReader: &stateReaderMock{
+			query: func(activationscope.Scope) (state.State, error) {
+				return state.NewStateDisabled(), nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevList: func(args ...string) ([]string, error) {
+			if args[0] == "--max-count=1" {
+				rev, ok := repo.revs[args[1]]
+				if !ok {
+					return []string{}, nil
+				}
+				return []string{rev}, nil
+			}
+			if len(args) > 1 && args[1] == "--not" {
+				return repo.unpublished, nil
+			}
+			return repo.commits, nil
+		},
+		UpdateRef: func(ref string, rev string) error {
+			repo.origHead = ref + "=" + rev
+			return nil
+		},
+		Rebase: func(args ...string) error {
+			repo.rebaseArgs = args
+			return nil
+		},
+		GetExecutable: func() (string, error) {
+			return "/usr/bin/git-team", nil
+		},
+	}
+
+	revisionRange := "origin/main..HEAD~1"
+	aliasesAndCoauthors := []string{"a"}
+	force := false
+	req := Request{Range: &revisionRange, AliasesAndCoauthors: &aliasesAndCoauthors, Force: &force}
+
+	expectedEvent := Failed{Reason: []error{errors.New("the range 'origin/main..HEAD~1' must end at HEAD, check out the branch to rewrite first")}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestRetroShouldFailForUnsupportedRanges(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"":                  "the range of commits to rewrite is missing",
+		"origin/main...":    "unsupported range 'origin/main...', expected <upstream>..[HEAD]",
+		"..HEAD":            "unsupported range '..HEAD', expected <upstream>..[HEAD]",
+		"origin/main..nope": "unknown revision 'nope'",
+	}
+
+	for revisionRange, reason := range cases {
+		revisionRange := revisionRange
+		reason := reason
+
+		t.Run(revisionRange, func(t *testing.T) {
+			t.Parallel()
+
+			repo := newRepository()
+
+			deps := Dependencies{
+				SanityCheckCoauthors: func([]string) []error { return []error{} },
+				GitResolveAliases: func(aliases []string) ([]string, []error) {
+					resolved := []string{}
+					for _, alias := range aliases {
+						resolved = append(resolved, fmt.Sprintf("%s <%s@x.y>", alias, alias))
+					}
+					return resolved, []error{}
+				},
+				ConfigReader: &configReaderMock{
+					read: func() (config.Config, error) {
+						return config.Config{ActivationScope: activationscope.Global}, nil
+					},
+				},
+				StateReader: &stateReaderMock{
+					query: func(activationscope.Scope) (state.State, error) {
+						return state.NewStateDisabled(), nil
+					},
+				},
+				ActivationValidator: &activationValidatorMock{
+					isInsideAGitRepository: func() bool { return true },
+				},
+				RevList: func(args ...string) ([]string, error) {
+					if args[0] == "--max-count=1" {
+						rev, ok := repo.revs[args[1]]
+						if !ok {
+							return []string{}, nil
+						}
+						return []string{rev}, nil
+					}
+					if len(args) > 1 && args[1] == "--not" {
+						return repo.unpublished, nil
+					}
+					return repo.commits, nil
+				},
+				UpdateRef: func(ref string, rev string) error {
+					repo.origHead = ref + "=" + rev
+					return nil
+				},
+				Rebase: func(args ...string) error {
+					repo.rebaseArgs = args
+					return nil
+				},
+				GetExecutable: func() (string, error) {
+					return "/usr/bin/git-team", nil
+				},
+			}
+
+			aliasesAndCoauthors := []string{"a"}
+			force := false
+			req := Request{Range: &revisionRange, AliasesAndCoauthors: &aliasesAndCoauthors, Force: &force}
+
+			expectedEvent := Failed{Reason: []error{errors.New(reason)}}
+
+			event := Policy{deps, req}.Apply()
+
+			if !reflect.DeepEqual(expectedEvent, event) {
+				t.Errorf("expected: %v, got: %v", expectedEvent, event)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestRetroShouldFailForAnEmptyRange(t *testing.T) {
+	t.Parallel()
+
+	repo := newRepository()
+	repo.commits = []string{}
+
+	deps := Dependencies{
+		SanityCheckCoauthors: func([]string) []error { return []error{} },
+		GitResolveAliases: func(aliases []string) ([]string, []error) {
+			resolved := []string{}
+			for _, alias := range aliases {
+				resolved = append(resolved, fmt.Sprintf("%s <%s@x.y>", alias, alias))
+			}
+			return resolved, []error{}
+		},
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		StateReader: &stateReaderMock{
+			query: func(activationscope.Scope) (state.State, error) {
+				return state.NewStateDisabled(), nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevList: func(args ...string) ([]string, error) {
+			if args[0] == "--max-count=1" {
+				rev, ok := repo.revs[args[1]]
+				if !ok {
+					return []string{}, nil
+				}
+				return []string{rev}, nil
+			}
+			if len(args) > 1 && args[1] == "--not" {
+				return repo.unpublished, nil
+			}
+			return repo.commits, nil
+		},
+		UpdateRef: func(ref string, rev string) error {
+			repo.origHead = ref + "=" + rev
+			return nil
+		},
+		Rebase: func(args ...string) error {
+			repo.rebaseArgs = args
+			return nil
+		},
+		GetExecutable: func() (string, error) {
+			return "/usr/bin/git-team", nil
+		},
+	}
+
+	revisionRange := "HEAD"
+	aliasesAndCoauthors := []string{"a"}
+	force := false
+	req := Request{Range: &revisionRange, AliasesAndCoauthors: &aliasesAndCoauthors, Force: &force}
+
+	expectedEvent := Failed{Reason: []error{errors.New("there are no commits in 'HEAD'")}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestRetroShouldFailWhenTheRebaseFails(t *testing.T) {
+	t.Parallel()
+
+	repo := newRepository()
+
+	deps := Dependencies{
+		SanityCheckCoauthors: func([]string) []error { return []error{} },
+		GitResolveAliases: func(aliases []string) ([]string, []error) {
+			resolved := []string{}
+			for _, alias := range aliases {
+				resolved = append(resolved, fmt.Sprintf("%s <%s@x.y>", alias, alias))
+			}
+			return resolved, []error{}
+		},
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		StateReader: &stateReaderMock{
+			query: func(activationscope.Scope) (state.State, error) {
+				return state.NewStateDisabled(), nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		RevList: func(args ...string) ([]string, error) {
+			if args[0] == "--max-count=1" {
+				rev, ok := repo.revs[args[1]]
+				if !ok {
+					return []string{}, nil
+				}
+				return []string{rev}, nil
+			}
+			if len(args) > 1 && args[1] == "--not" {
+				return repo.unpublished, nil
+			}
+			return repo.commits, nil
+		},
+		UpdateRef: func(ref string, rev string) error {
+			repo.origHead = ref + "=" + rev
+			return nil
+		},
+		Rebase: func(args ...string) error {
+			return errors.New("git rebase failed: error: cannot rebase: You have unstaged changes.")
+		},
+		GetExecutable: func() (string, error) {
+			return "/usr/bin/git-team", nil
+		},
+	}
+
+	revisionRange := "origin/main"
+	aliasesAndCoauthors := []string{"a"}
+	force := false
+	req := Request{Range: &revisionRange, AliasesAndCoauthors: &aliasesAndCoauthors, Force: &force}
+
+	expectedEvent := Failed{Reason: []error{errors.New("git rebase failed: error: cannot rebase: You have unstaged changes.")}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+
+	if strings.HasPrefix(repo.origHead, "ORIG_HEAD") {
+		t.Errorf("expected ORIG_HEAD to be left to git, got: %s", repo.origHead)
+		t.Fail()
+	}
+}
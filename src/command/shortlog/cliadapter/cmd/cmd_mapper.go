@@ -0,0 +1,58 @@
+package shortlogcmdadapter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/hekmekk/git-team/src/command/shortlog"
+	shortlogeventadapter "github.com/hekmekk/git-team/src/command/shortlog/cliadapter/event"
+	activation "github.com/hekmekk/git-team/src/shared/activation/impl"
+	commandadapter "github.com/hekmekk/git-team/src/shared/cli/commandadapter"
+	configds "github.com/hekmekk/git-team/src/shared/config/datasource"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/impl"
+	"github.com/hekmekk/git-team/src/shared/gitrepo"
+	"github.com/hekmekk/git-team/src/shared/history"
+)
+
+// Command the shortlog command
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:      "shortlog",
+		Usage:     "Count the commits of each contributor, crediting the author as well as every co-author",
+		ArgsUsage: "[<range>]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "weighted", Usage: "Split each commit evenly among the people involved"},
+			&cli.BoolFlag{Name: "mailmap", Usage: "Group people by their canonical identity according to the mailmap"},
+			&cli.BoolFlag{Name: "email", Aliases: []string{"e"}, Usage: "Show the email of each contributor"},
+			&cli.StringFlag{Name: "format", Value: "text", Usage: fmt.Sprintf("The output format, one of: %s", strings.Join(shortlog.Formats, ", "))},
+		},
+		Action: func(c *cli.Context) error {
+			revisionRange := c.Args().First()
+			weighted := c.Bool("weighted")
+			mailmap := c.Bool("mailmap")
+			showEmail := c.Bool("email")
+			format := c.String("format")
+			return commandadapter.Run(policy(&revisionRange, &weighted, &mailmap, &showEmail, &format), shortlogeventadapter.MapEventToEffect)
+		},
+	}
+}
+
+func policy(revisionRange *string, weighted *bool, mailmap *bool, showEmail *bool, format *string) shortlog.Policy {
+	return shortlog.Policy{
+		Req: shortlog.Request{
+			Range:     revisionRange,
+			Weighted:  weighted,
+			Mailmap:   mailmap,
+			ShowEmail: showEmail,
+			Format:    format,
+		},
+		Deps: shortlog.Dependencies{
+			ConfigReader:        configds.NewGitconfigDataSource(gitconfig.NewDataSource()),
+			ActivationValidator: activation.NewGitConfigDataSource(gitconfig.NewDataSource()),
+			Log:                 history.Log,
+			CheckMailmap:        gitrepo.CheckMailmap,
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package shortlogeventadapter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/hekmekk/git-team/src/command/shortlog"
+	"github.com/hekmekk/git-team/src/core/events"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+// MapEventToEffect convert shortlog events to effects for the cli
+func MapEventToEffect(event events.Event) effects.Effect {
+	switch evt := event.(type) {
+	case shortlog.Succeeded:
+		if len(evt.Contributors) == 0 {
+			return effects.NewExitOkMsg(color.CyanString("no commits found"))
+		}
+
+		switch evt.Format {
+		case "markdown":
+			return effects.NewExitOkMsg(toMarkdown(evt))
+		default:
+			return effects.NewExitOkMsg(toText(evt))
+		}
+	case shortlog.Failed:
+		return effects.NewExitErrMsg(evt.Reason)
+	default:
+		return effects.NewExitOk()
+	}
+}
+
+// toText render the contributors the way git shortlog --summary --numbered does
+func toText(evt shortlog.Succeeded) string {
+	var buffer bytes.Buffer
+	for _, contributor := range evt.Contributors {
+		fmt.Fprintf(&buffer, "%6s\t%s\n", count(contributor.Commits, evt.Weighted), name(contributor, evt.ShowEmail))
+	}
+	return strings.TrimRight(buffer.String(), "\n")
+}
+
+// toMarkdown render the contributors as a section to be pasted into a CHANGELOG
+func toMarkdown(evt shortlog.Succeeded) string {
+	var buffer bytes.Buffer
+	buffer.WriteString("### Contributors\n")
+	for _, contributor := range evt.Contributors {
+		unit := "commits"
+		if !evt.Weighted && contributor.Commits == 1 {
+			unit = "commit"
+		}
+		fmt.Fprintf(&buffer, "- %s (%s %s)\n", name(contributor, evt.ShowEmail), count(contributor.Commits, evt.Weighted), unit)
+	}
+	return strings.TrimRight(buffer.String(), "\n")
+}
+
+func count(commits float64, weighted bool) string {
+	if weighted {
+		return fmt.Sprintf("%.2f", commits)
+	}
+	return fmt.Sprintf("%d", int(commits))
+}
+
+func name(contributor shortlog.Contributor, showEmail bool) string {
+	if showEmail && contributor.Email != "" {
+		return fmt.Sprintf("%s <%s>", contributor.Name, contributor.Email)
+	}
+	return contributor.Name
+}
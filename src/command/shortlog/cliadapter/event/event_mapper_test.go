@@ -0,0 +1,91 @@
+package shortlogeventadapter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hekmekk/git-team/src/command/shortlog"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+var contributors = []shortlog.Contributor{
+	{Name: "B", Email: "b@x.y", Commits: 1.5},
+	{Name: "A", Email: "a@x.y", Commits: 1},
+}
+
+func TestMapEventToEffectSucceededAsText(t *testing.T) {
+	msg := "     1\tB\n     1\tA"
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffect(shortlog.Succeeded{Contributors: []shortlog.Contributor{{Name: "B", Commits: 1}, {Name: "A", Commits: 1}}, Format: "text"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %q, got: %q", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectSucceededAsWeightedTextWithEmails(t *testing.T) {
+	msg := "  1.50\tB <b@x.y>\n  1.00\tA <a@x.y>"
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffect(shortlog.Succeeded{Contributors: contributors, Weighted: true, ShowEmail: true, Format: "text"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %q, got: %q", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectSucceededAsMarkdown(t *testing.T) {
+	msg := "### Contributors\n- B (1.50 commits)\n- A (1.00 commits)"
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffect(shortlog.Succeeded{Contributors: contributors, Weighted: true, Format: "markdown"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %q, got: %q", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectSucceededAsMarkdownWithASingleCommit(t *testing.T) {
+	msg := "### Contributors\n- B <b@x.y> (2 commits)\n- A <a@x.y> (1 commit)"
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffect(shortlog.Succeeded{Contributors: []shortlog.Contributor{{Name: "B", Email: "b@x.y", Commits: 2}, {Name: "A", Email: "a@x.y", Commits: 1}}, ShowEmail: true, Format: "markdown"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %q, got: %q", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectSucceededWithoutCommits(t *testing.T) {
+	expectedEffect := effects.NewExitOkMsg("no commits found")
+
+	effect := MapEventToEffect(shortlog.Succeeded{Contributors: []shortlog.Contributor{}, Format: "markdown"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectFailed(t *testing.T) {
+	err := errors.New("failure")
+
+	expectedEffect := effects.NewExitErrMsg(err)
+
+	effect := MapEventToEffect(shortlog.Failed{Reason: err})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
@@ -0,0 +1,82 @@
+package shortlog
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hekmekk/git-team/src/shared/history"
+	"github.com/hekmekk/git-team/src/shared/trailer"
+)
+
+// Contributor somebody who authored or co-authored commits
+type Contributor struct {
+	Name    string
+	Email   string
+	Commits float64
+}
+
+// Contributors credit each commit to its author and every co-author. With weighted set, a commit counts as a fraction split evenly among the people involved. Identities are replaced by their canonical ones first, people with the same email are counted as one. The contributors are ordered by the number of commits.
+func Contributors(commits []history.Commit, format trailer.Format, weighted bool, canonical map[string]string) []Contributor {
+	index := map[string]int{}
+	contributors := []Contributor{}
+
+	for _, commit := range commits {
+		seen := map[string]bool{}
+		involved := []int{}
+		for _, identity := range commit.People(format) {
+			if canonicalIdentity, ok := canonical[identity]; ok {
+				identity = canonicalIdentity
+			}
+
+			key := history.Key(identity)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			i, exists := index[key]
+			if !exists {
+				i = len(contributors)
+				index[key] = i
+				name, email := split(identity)
+				contributors = append(contributors, Contributor{Name: name, Email: email})
+			}
+
+			involved = append(involved, i)
+		}
+
+		credit := 1.0
+		if weighted {
+			credit = 1.0 / float64(len(involved))
+		}
+
+		for _, i := range involved {
+			contributors[i].Commits += credit
+		}
+	}
+
+	sort.SliceStable(contributors, func(i, j int) bool {
+		if contributors[i].Commits != contributors[j].Commits {
+			return contributors[i].Commits > contributors[j].Commits
+		}
+		return strings.ToLower(contributors[i].Name) < strings.ToLower(contributors[j].Name)
+	})
+
+	return contributors
+}
+
+// split an identity of the shape "Name <email>" into name and email
+func split(identity string) (string, string) {
+	start := strings.LastIndex(identity, "<")
+	end := strings.LastIndex(identity, ">")
+	if start < 0 || end < start {
+		return strings.TrimSpace(identity), ""
+	}
+
+	name := strings.TrimSpace(identity[:start])
+	email := strings.TrimSpace(identity[start+1 : end])
+	if name == "" {
+		name = email
+	}
+	return name, email
+}
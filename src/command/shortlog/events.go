@@ -0,0 +1,14 @@
+package shortlog
+
+// Succeeded the contributors have been counted
+type Succeeded struct {
+	Contributors []Contributor
+	Weighted     bool
+	ShowEmail    bool
+	Format       string
+}
+
+// Failed failed to count the contributors
+type Failed struct {
+	Reason error
+}
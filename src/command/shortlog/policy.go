@@ -0,0 +1,126 @@
+package shortlog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hekmekk/git-team/src/core/events"
+	activation "github.com/hekmekk/git-team/src/shared/activation/interface"
+	config "github.com/hekmekk/git-team/src/shared/config/interface"
+	"github.com/hekmekk/git-team/src/shared/history"
+	"github.com/hekmekk/git-team/src/shared/trailer"
+)
+
+// Formats the supported output formats
+var Formats = []string{"text", "markdown"}
+
+// Dependencies the dependencies of the shortlog Policy module
+type Dependencies struct {
+	ConfigReader        config.Reader
+	ActivationValidator activation.Validator
+	Log                 func(args ...string) ([]history.Commit, error)
+	CheckMailmap        func(identities []string) ([]string, error)
+}
+
+// Request the commits to summarize and how to count and render the contributors
+type Request struct {
+	Range     *string
+	Weighted  *bool
+	Mailmap   *bool
+	ShowEmail *bool
+	Format    *string
+}
+
+// Policy the policy to apply
+type Policy struct {
+	Deps Dependencies
+	Req  Request
+}
+
+// Apply count the commits of each contributor, crediting the author as well as every co-author
+func (policy Policy) Apply() events.Event {
+	deps := policy.Deps
+	req := policy.Req
+
+	format := *req.Format
+	if format == "" {
+		format = Formats[0]
+	}
+
+	if !isOneOf(format, Formats) {
+		return Failed{Reason: fmt.Errorf("unknown format '%s', expected one of: %s", format, strings.Join(Formats, ", "))}
+	}
+
+	if !deps.ActivationValidator.IsInsideAGitRepository() {
+		return Failed{Reason: errors.New("failed to summarize the commit history: not inside a git repository")}
+	}
+
+	cfg, err := deps.ConfigReader.Read()
+	if err != nil {
+		return Failed{Reason: fmt.Errorf("failed to read config: %s", err)}
+	}
+
+	revisionRange := *req.Range
+	if revisionRange == "" {
+		revisionRange = "HEAD"
+	}
+
+	commits, err := deps.Log("--no-merges", revisionRange, "--")
+	if err != nil {
+		return Failed{Reason: err}
+	}
+
+	canonical := map[string]string{}
+	if *req.Mailmap {
+		canonical, err = canonicalIdentities(deps.CheckMailmap, commits, cfg.Trailer())
+		if err != nil {
+			return Failed{Reason: fmt.Errorf("failed to apply the mailmap: %s", err)}
+		}
+	}
+
+	return Succeeded{
+		Contributors: Contributors(commits, cfg.Trailer(), *req.Weighted, canonical),
+		Weighted:     *req.Weighted,
+		ShowEmail:    *req.ShowEmail,
+		Format:       format,
+	}
+}
+
+// canonicalIdentities look up the canonical identity of everybody involved in the commits, identities without an email are left as they are
+func canonicalIdentities(checkMailmap func([]string) ([]string, error), commits []history.Commit, format trailer.Format) (map[string]string, error) {
+	seen := map[string]bool{}
+	identities := []string{}
+	for _, commit := range commits {
+		for _, identity := range commit.People(format) {
+			if !seen[identity] && strings.Contains(identity, "<") && strings.HasSuffix(identity, ">") {
+				seen[identity] = true
+				identities = append(identities, identity)
+			}
+		}
+	}
+
+	mapped, err := checkMailmap(identities)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mapped) != len(identities) {
+		return nil, fmt.Errorf("expected %d identities, got %d", len(identities), len(mapped))
+	}
+
+	canonical := map[string]string{}
+	for i, identity := range identities {
+		canonical[identity] = mapped[i]
+	}
+	return canonical, nil
+}
+
+func isOneOf(candidate string, values []string) bool {
+	for _, value := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,339 @@
+package shortlog
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	config "github.com/hekmekk/git-team/src/shared/config/entity/config"
+	"github.com/hekmekk/git-team/src/shared/history"
+)
+
+type configReaderMock struct {
+	read func() (config.Config, error)
+}
+
+func (mock configReaderMock) Read() (config.Config, error) {
+	return mock.read()
+}
+
+type activationValidatorMock struct {
+	isInsideAGitRepository func() bool
+}
+
+func (mock activationValidatorMock) IsInsideAGitRepository() bool {
+	return mock.isInsideAGitRepository()
+}
+
+func TestShortlogShouldCreditTheAuthorAndEveryCoauthor(t *testing.T) {
+	commits := []history.Commit{
+		{Author: "A <a@x.y>", Message: "one\n\nCo-authored-by: B <b@x.y>\n"},
+		{Author: "B <b@x.y>", Message: "two\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>\nCo-authored-by: B <B@X.Y>\n"},
+		{Author: "Bee <b@x.y>", Message: "three\n"},
+		{Author: "D <d@x.y>", Message: "four\n"},
+	}
+
+	var logArgs []string
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			logArgs = args
+			return commits, nil
+		},
+		CheckMailmap: func(identities []string) ([]string, error) {
+			mapped := []string{}
+			for _, identity := range identities {
+				if identity == "D <d@x.y>" {
+					identity = "Cee <c@x.y>"
+				}
+				mapped = append(mapped, identity)
+			}
+			return mapped, nil
+		},
+	}
+
+	revisionRange := "v1.0.0..HEAD"
+	weighted := false
+	mailmap := false
+	showEmail := false
+	format := ""
+	req := Request{Range: &revisionRange, Weighted: &weighted, Mailmap: &mailmap, ShowEmail: &showEmail, Format: &format}
+
+	expectedEvent := Succeeded{
+		Contributors: []Contributor{
+			{Name: "B", Email: "b@x.y", Commits: 3},
+			{Name: "A", Email: "a@x.y", Commits: 2},
+			{Name: "C", Email: "c@x.y", Commits: 1},
+			{Name: "D", Email: "d@x.y", Commits: 1},
+		},
+		Format: "text",
+	}
+	expectedLogArgs := []string{"--no-merges", "v1.0.0..HEAD", "--"}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedLogArgs, logArgs) {
+		t.Errorf("expected: %s, got: %s", expectedLogArgs, logArgs)
+		t.Fail()
+	}
+}
+
+func TestShortlogShouldWeightSharedCommitsFractionally(t *testing.T) {
+	commits := []history.Commit{
+		{Author: "A <a@x.y>", Message: "one\n\nCo-authored-by: B <b@x.y>\n"},
+		{Author: "B <b@x.y>", Message: "two\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>\nCo-authored-by: B <B@X.Y>\n"},
+		{Author: "Bee <b@x.y>", Message: "three\n"},
+		{Author: "D <d@x.y>", Message: "four\n"},
+	}
+
+	var logArgs []string
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			logArgs = args
+			return commits, nil
+		},
+		CheckMailmap: func(identities []string) ([]string, error) {
+			mapped := []string{}
+			for _, identity := range identities {
+				if identity == "D <d@x.y>" {
+					identity = "Cee <c@x.y>"
+				}
+				mapped = append(mapped, identity)
+			}
+			return mapped, nil
+		},
+	}
+
+	revisionRange := ""
+	weighted := true
+	mailmap := false
+	showEmail := false
+	format := "markdown"
+	req := Request{Range: &revisionRange, Weighted: &weighted, Mailmap: &mailmap, ShowEmail: &showEmail, Format: &format}
+
+	half, third := 1.0/2, 1.0/3
+
+	expectedEvent := Succeeded{
+		Contributors: []Contributor{
+			{Name: "B", Email: "b@x.y", Commits: half + third + 1},
+			{Name: "D", Email: "d@x.y", Commits: 1},
+			{Name: "A", Email: "a@x.y", Commits: half + third},
+			{Name: "C", Email: "c@x.y", Commits: third},
+		},
+		Weighted: true,
+		Format:   "markdown",
+	}
+	expectedLogArgs := []string{"--no-merges", "HEAD", "--"}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedLogArgs, logArgs) {
+		t.Errorf("expected: %s, got: %s", expectedLogArgs, logArgs)
+		t.Fail()
+	}
+}
+
+func TestShortlogShouldGroupByTheCanonicalIdentities(t *testing.T) {
+	commits := []history.Commit{
+		{Author: "A <a@x.y>", Message: "one\n\nCo-authored-by: B <b@x.y>\n"},
+		{Author: "B <b@x.y>", Message: "two\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>\nCo-authored-by: B <B@X.Y>\n"},
+		{Author: "Bee <b@x.y>", Message: "three\n"},
+		{Author: "D <d@x.y>", Message: "four\n"},
+	}
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return commits, nil
+		},
+		CheckMailmap: func(identities []string) ([]string, error) {
+			mapped := []string{}
+			for _, identity := range identities {
+				if identity == "D <d@x.y>" {
+					identity = "Cee <c@x.y>"
+				}
+				mapped = append(mapped, identity)
+			}
+			return mapped, nil
+		},
+	}
+
+	revisionRange := ""
+	weighted := false
+	mailmap := true
+	showEmail := false
+	format := "text"
+	req := Request{Range: &revisionRange, Weighted: &weighted, Mailmap: &mailmap, ShowEmail: &showEmail, Format: &format}
+
+	expectedEvent := Succeeded{
+		Contributors: []Contributor{
+			{Name: "B", Email: "b@x.y", Commits: 3},
+			{Name: "A", Email: "a@x.y", Commits: 2},
+			{Name: "C", Email: "c@x.y", Commits: 2},
+		},
+		Format: "text",
+	}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestShortlogShouldFailWhenTheMailmapCannotBeApplied(t *testing.T) {
+	commits := []history.Commit{
+		{Author: "A <a@x.y>", Message: "one\n\nCo-authored-by: B <b@x.y>\n"},
+		{Author: "B <b@x.y>", Message: "two\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>\nCo-authored-by: B <B@X.Y>\n"},
+		{Author: "Bee <b@x.y>", Message: "three\n"},
+		{Author: "D <d@x.y>", Message: "four\n"},
+	}
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return commits, nil
+		},
+		CheckMailmap: func(identities []string) ([]string, error) {
+			return nil, errors.New("git check-mailmap failed")
+		},
+	}
+
+	revisionRange := ""
+	weighted := false
+	mailmap := true
+	showEmail := false
+	format := "text"
+	req := Request{Range: &revisionRange, Weighted: &weighted, Mailmap: &mailmap, ShowEmail: &showEmail, Format: &format}
+
+	expectedEvent := Failed{Reason: errors.New("failed to apply the mailmap: git check-mailmap failed")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestShortlogShouldFailForAnUnknownFormat(t *testing.T) {
+	deps := Dependencies{}
+
+	revisionRange := ""
+	weighted := false
+	mailmap := false
+	showEmail := false
+	format := "html"
+	req := Request{Range: &revisionRange, Weighted: &weighted, Mailmap: &mailmap, ShowEmail: &showEmail, Format: &format}
+
+	expectedEvent := Failed{Reason: errors.New("unknown format 'html', expected one of: text, markdown")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestShortlogShouldFailOutsideOfAGitRepository(t *testing.T) {
+	deps := Dependencies{
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return false },
+		},
+	}
+
+	revisionRange := ""
+	weighted := false
+	mailmap := false
+	showEmail := false
+	format := "text"
+	req := Request{Range: &revisionRange, Weighted: &weighted, Mailmap: &mailmap, ShowEmail: &showEmail, Format: &format}
+
+	expectedEvent := Failed{Reason: errors.New("failed to summarize the commit history: not inside a git repository")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestShortlogShouldFailWhenGitLogFails(t *testing.T) {
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return nil, errors.New("git log failed: unknown revision")
+		},
+		CheckMailmap: func(identities []string) ([]string, error) {
+			return identities, nil
+		},
+	}
+
+	revisionRange := ""
+	weighted := false
+	mailmap := false
+	showEmail := false
+	format := "text"
+	req := Request{Range: &revisionRange, Weighted: &weighted, Mailmap: &mailmap, ShowEmail: &showEmail, Format: &format}
+
+	expectedEvent := Failed{Reason: errors.New("git log failed: unknown revision")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
@@ -0,0 +1,54 @@
+package squashtrailerscmdadapter
+
+import (
+	"io/ioutil"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/hekmekk/git-team/src/command/squashtrailers"
+	squashtrailerseventadapter "github.com/hekmekk/git-team/src/command/squashtrailers/cliadapter/event"
+	activation "github.com/hekmekk/git-team/src/shared/activation/impl"
+	commandadapter "github.com/hekmekk/git-team/src/shared/cli/commandadapter"
+	configds "github.com/hekmekk/git-team/src/shared/config/datasource"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/impl"
+	"github.com/hekmekk/git-team/src/shared/gitrepo"
+	"github.com/hekmekk/git-team/src/shared/history"
+)
+
+// Command the squash-trailers command
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:      "squash-trailers",
+		Usage:     "Print the authors and co-authors of a range of commits as co-author trailers for squashing them",
+		ArgsUsage: "<range>",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "write-message", Usage: "Add the trailers to the message of the squash commit, i.e. .git/SQUASH_MSG"},
+			&cli.StringFlag{Name: "file", Usage: "Add the trailers to the given message file instead of .git/SQUASH_MSG, implies --write-message"},
+		},
+		Action: func(c *cli.Context) error {
+			revisionRange := c.Args().First()
+			writeMessage := c.Bool("write-message")
+			messageFile := c.String("file")
+			return commandadapter.Run(policy(&revisionRange, &writeMessage, &messageFile), squashtrailerseventadapter.MapEventToEffect)
+		},
+	}
+}
+
+func policy(revisionRange *string, writeMessage *bool, messageFile *string) squashtrailers.Policy {
+	return squashtrailers.Policy{
+		Req: squashtrailers.Request{
+			Range:        revisionRange,
+			WriteMessage: writeMessage,
+			MessageFile:  messageFile,
+		},
+		Deps: squashtrailers.Dependencies{
+			ConfigReader:        configds.NewGitconfigDataSource(gitconfig.NewDataSource()),
+			ActivationValidator: activation.NewGitConfigDataSource(gitconfig.NewDataSource()),
+			Log:                 history.Log,
+			CommitterIdentity:   gitrepo.CommitterIdentity,
+			GitPath:             gitrepo.GitPath,
+			ReadFile:            ioutil.ReadFile,
+			WriteFile:           ioutil.WriteFile,
+		},
+	}
+}
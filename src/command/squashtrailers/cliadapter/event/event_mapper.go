@@ -0,0 +1,30 @@
+package squashtrailerseventadapter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/hekmekk/git-team/src/command/squashtrailers"
+	"github.com/hekmekk/git-team/src/core/events"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+// MapEventToEffect convert squash-trailers events to effects for the cli
+func MapEventToEffect(event events.Event) effects.Effect {
+	switch evt := event.(type) {
+	case squashtrailers.Succeeded:
+		if evt.MessageFile != "" {
+			return effects.NewExitOkMsg(color.GreenString(fmt.Sprintf("Added %d co-author(s) to %s", len(evt.TrailerLines), evt.MessageFile)))
+		}
+		if len(evt.TrailerLines) == 0 {
+			return effects.NewExitOk()
+		}
+		return effects.NewExitOkMsg(strings.Join(evt.TrailerLines, "\n"))
+	case squashtrailers.Failed:
+		return effects.NewExitErrMsg(evt.Reason)
+	default:
+		return effects.NewExitOk()
+	}
+}
@@ -0,0 +1,56 @@
+package squashtrailerseventadapter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hekmekk/git-team/src/command/squashtrailers"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+func TestMapEventToEffectSucceeded(t *testing.T) {
+	expectedEffect := effects.NewExitOkMsg("Co-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>")
+
+	effect := MapEventToEffect(squashtrailers.Succeeded{TrailerLines: []string{"Co-authored-by: A <a@x.y>", "Co-authored-by: B <b@x.y>"}})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectSucceededWithoutCoauthors(t *testing.T) {
+	expectedEffect := effects.NewExitOk()
+
+	effect := MapEventToEffect(squashtrailers.Succeeded{TrailerLines: []string{}})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectSucceededWritingTheMessage(t *testing.T) {
+	expectedEffect := effects.NewExitOkMsg("Added 1 co-author(s) to .git/SQUASH_MSG")
+
+	effect := MapEventToEffect(squashtrailers.Succeeded{TrailerLines: []string{"Co-authored-by: A <a@x.y>"}, MessageFile: ".git/SQUASH_MSG"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectFailed(t *testing.T) {
+	err := errors.New("failure")
+
+	expectedEffect := effects.NewExitErrMsg(err)
+
+	effect := MapEventToEffect(squashtrailers.Failed{Reason: err})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
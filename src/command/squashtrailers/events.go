@@ -0,0 +1,12 @@
+package squashtrailers
+
+// Succeeded the co-authors of the range have been collected, and written to the message file if requested
+type Succeeded struct {
+	TrailerLines []string
+	MessageFile  string
+}
+
+// Failed failed to collect the co-authors of the range
+type Failed struct {
+	Reason error
+}
@@ -0,0 +1,117 @@
+package squashtrailers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/hekmekk/git-team/src/core/events"
+	activation "github.com/hekmekk/git-team/src/shared/activation/interface"
+	config "github.com/hekmekk/git-team/src/shared/config/interface"
+	"github.com/hekmekk/git-team/src/shared/history"
+	"github.com/hekmekk/git-team/src/shared/trailer"
+)
+
+// Dependencies the dependencies of the squash-trailers Policy module
+type Dependencies struct {
+	ConfigReader        config.Reader
+	ActivationValidator activation.Validator
+	Log                 func(args ...string) ([]history.Commit, error)
+	CommitterIdentity   func() (string, error)
+	GitPath             func(name string) (string, error)
+	ReadFile            func(path string) ([]byte, error)
+	WriteFile           func(path string, data []byte, perm os.FileMode) error
+}
+
+// Request the range of commits to be squashed and where to write the message to, if at all
+type Request struct {
+	Range        *string
+	WriteMessage *bool
+	MessageFile  *string
+}
+
+// Policy the policy to apply
+type Policy struct {
+	Deps Dependencies
+	Req  Request
+}
+
+// Apply collect the authors and co-authors of the commits in the range as co-authors of the squashed commit
+func (policy Policy) Apply() events.Event {
+	deps := policy.Deps
+	req := policy.Req
+
+	if *req.Range == "" {
+		return Failed{Reason: errors.New("the range of commits to squash is missing, e.g. main..feature")}
+	}
+
+	if !deps.ActivationValidator.IsInsideAGitRepository() {
+		return Failed{Reason: errors.New("failed to collect the co-authors: not inside a git repository")}
+	}
+
+	cfg, err := deps.ConfigReader.Read()
+	if err != nil {
+		return Failed{Reason: fmt.Errorf("failed to read config: %s", err)}
+	}
+
+	committer, err := deps.CommitterIdentity()
+	if err != nil {
+		return Failed{Reason: fmt.Errorf("failed to determine the committer: %s", err)}
+	}
+
+	commits, err := deps.Log("--no-merges", "--reverse", *req.Range, "--")
+	if err != nil {
+		return Failed{Reason: err}
+	}
+
+	format := cfg.Trailer()
+
+	trailerLines := []string{}
+	for _, coauthor := range coauthors(commits, format, committer) {
+		trailerLines = append(trailerLines, format.Line(coauthor))
+	}
+
+	if !*req.WriteMessage && *req.MessageFile == "" {
+		return Succeeded{TrailerLines: trailerLines}
+	}
+
+	messageFile := *req.MessageFile
+	if messageFile == "" {
+		messageFile, err = deps.GitPath("SQUASH_MSG")
+		if err != nil {
+			return Failed{Reason: fmt.Errorf("failed to locate SQUASH_MSG: %s", err)}
+		}
+	}
+
+	if len(trailerLines) == 0 {
+		return Succeeded{TrailerLines: trailerLines}
+	}
+
+	message, err := deps.ReadFile(messageFile)
+	if err != nil && !os.IsNotExist(err) {
+		return Failed{Reason: fmt.Errorf("failed to read %s: %s", messageFile, err)}
+	}
+
+	updated := trailer.Inject(string(message), trailerLines, []string{format.Prefix()}, trailer.NewCleanup("", ""))
+
+	if err := deps.WriteFile(messageFile, []byte(updated), 0644); err != nil {
+		return Failed{Reason: fmt.Errorf("failed to write %s: %s", messageFile, err)}
+	}
+
+	return Succeeded{TrailerLines: trailerLines, MessageFile: messageFile}
+}
+
+// coauthors every distinct author and co-author of the commits except for the committer, in the order of their first appearance
+func coauthors(commits []history.Commit, format trailer.Format, committer string) []string {
+	seen := map[string]bool{history.Key(committer): true}
+	coauthors := []string{}
+	for _, commit := range commits {
+		for _, identity := range commit.People(format) {
+			if !seen[history.Key(identity)] {
+				seen[history.Key(identity)] = true
+				coauthors = append(coauthors, identity)
+			}
+		}
+	}
+	return coauthors
+}
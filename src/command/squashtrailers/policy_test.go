@@ -0,0 +1,348 @@
+package squashtrailers
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	config "github.com/hekmekk/git-team/src/shared/config/entity/config"
+	"github.com/hekmekk/git-team/src/shared/history"
+)
+
+type configReaderMock struct {
+	read func() (config.Config, error)
+}
+
+func (mock configReaderMock) Read() (config.Config, error) {
+	return mock.read()
+}
+
+type activationValidatorMock struct {
+	isInsideAGitRepository func() bool
+}
+
+func (mock activationValidatorMock) IsInsideAGitRepository() bool {
+	return mock.isInsideAGitRepository()
+}
+
+type written struct {
+	path string
+	data string
+}
+
+func TestSquashTrailersShouldCollectTheAuthorsAndCoauthorsExceptForTheCommitter(t *testing.T) {
+	commits := []history.Commit{
+		{Author: "Me <me@x.y>", Message: "one\n\nCo-authored-by: A <a@x.y>\n"},
+		{Author: "B <b@x.y>", Message: "two\n\nCo-authored-by: Me <ME@x.y>\nCo-authored-by: a <A@x.y>\nCo-authored-by: C <c@x.y>\n"},
+	}
+
+	var logArgs []string
+	writes := []written{}
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			logArgs = args
+			return commits, nil
+		},
+		CommitterIdentity: func() (string, error) {
+			return "Me <me@x.y>", nil
+		},
+		GitPath: func(name string) (string, error) {
+			return ".git/" + name, nil
+		},
+		ReadFile: func(path string) ([]byte, error) {
+			return nil, os.ErrNotExist
+		},
+		WriteFile: func(path string, data []byte, perm os.FileMode) error {
+			writes = append(writes, written{path: path, data: string(data)})
+			return nil
+		},
+	}
+
+	revisionRange := "main..feature"
+	writeMessage := false
+	messageFile := ""
+	req := Request{Range: &revisionRange, WriteMessage: &writeMessage, MessageFile: &messageFile}
+
+	expectedEvent := Succeeded{TrailerLines: []string{"Co-authored-by: A <a@x.y>", "Co-authored-by: B <b@x.y>", "Co-authored-by: C <c@x.y>"}}
+	expectedLogArgs := []string{"--no-merges", "--reverse", "main..feature", "--"}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedLogArgs, logArgs) {
+		t.Errorf("expected: %s, got: %s", expectedLogArgs, logArgs)
+		t.Fail()
+	}
+
+	if len(writes) != 0 {
+		t.Errorf("expected no writes, got: %s", writes)
+		t.Fail()
+	}
+}
+
+func TestSquashTrailersShouldAddTheTrailersToTheSquashMessage(t *testing.T) {
+	commits := []history.Commit{
+		{Author: "Me <me@x.y>", Message: "one\n\nCo-authored-by: A <a@x.y>\n"},
+		{Author: "B <b@x.y>", Message: "two\n\nCo-authored-by: Me <ME@x.y>\nCo-authored-by: a <A@x.y>\nCo-authored-by: C <c@x.y>\n"},
+	}
+
+	writes := []written{}
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return commits, nil
+		},
+		CommitterIdentity: func() (string, error) {
+			return "Me <me@x.y>", nil
+		},
+		GitPath: func(name string) (string, error) {
+			return ".git/" + name, nil
+		},
+		ReadFile: func(path string) ([]byte, error) {
+			if path != ".git/SQUASH_MSG" {
+				return nil, os.ErrNotExist
+			}
+			return []byte("Squashed commit of the following:\n\nCo-authored-by: C <c@x.y>\n"), nil
+		},
+		WriteFile: func(path string, data []byte, perm os.FileMode) error {
+			writes = append(writes, written{path: path, data: string(data)})
+			return nil
+		},
+	}
+
+	revisionRange := "main..feature"
+	writeMessage := true
+	messageFile := ""
+	req := Request{Range: &revisionRange, WriteMessage: &writeMessage, MessageFile: &messageFile}
+
+	expectedEvent := Succeeded{TrailerLines: []string{"Co-authored-by: A <a@x.y>", "Co-authored-by: B <b@x.y>", "Co-authored-by: C <c@x.y>"}, MessageFile: ".git/SQUASH_MSG"}
+	expectedWrites := []written{{path: ".git/SQUASH_MSG", data: "Squashed commit of the following:\n\nCo-authored-by: C <c@x.y>\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\n"}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedWrites, writes) {
+		t.Errorf("expected: %s, got: %s", expectedWrites, writes)
+		t.Fail()
+	}
+}
+
+func TestSquashTrailersShouldCreateTheGivenMessageFile(t *testing.T) {
+	commits := []history.Commit{
+		{Author: "Me <me@x.y>", Message: "one\n\nCo-authored-by: A <a@x.y>\n"},
+		{Author: "B <b@x.y>", Message: "two\n\nCo-authored-by: Me <ME@x.y>\nCo-authored-by: a <A@x.y>\nCo-authored-by: C <c@x.y>\n"},
+	}
+
+	writes := []written{}
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return commits, nil
+		},
+		CommitterIdentity: func() (string, error) {
+			return "Me <me@x.y>", nil
+		},
+		GitPath: func(name string) (string, error) {
+			return ".git/" + name, nil
+		},
+		ReadFile: func(path string) ([]byte, error) {
+			return nil, os.ErrNotExist
+		},
+		WriteFile: func(path string, data []byte, perm os.FileMode) error {
+			writes = append(writes, written{path: path, data: string(data)})
+			return nil
+		},
+	}
+
+	revisionRange := "main..feature"
+	writeMessage := false
+	messageFile := "msg.txt"
+	req := Request{Range: &revisionRange, WriteMessage: &writeMessage, MessageFile: &messageFile}
+
+	expectedEvent := Succeeded{TrailerLines: []string{"Co-authored-by: A <a@x.y>", "Co-authored-by: B <b@x.y>", "Co-authored-by: C <c@x.y>"}, MessageFile: "msg.txt"}
+	expectedWrites := []written{{path: "msg.txt", data: "\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n"}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedWrites, writes) {
+		t.Errorf("expected: %s, got: %s", expectedWrites, writes)
+		t.Fail()
+	}
+}
+
+func TestSquashTrailersShouldNotWriteTheMessageWithoutCoauthors(t *testing.T) {
+	writes := []written{}
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return []history.Commit{{Author: "Me <me@x.y>", Message: "solo\n"}}, nil
+		},
+		CommitterIdentity: func() (string, error) {
+			return "Me <me@x.y>", nil
+		},
+		GitPath: func(name string) (string, error) {
+			return ".git/" + name, nil
+		},
+		ReadFile: func(path string) ([]byte, error) {
+			return nil, os.ErrNotExist
+		},
+		WriteFile: func(path string, data []byte, perm os.FileMode) error {
+			writes = append(writes, written{path: path, data: string(data)})
+			return nil
+		},
+	}
+
+	revisionRange := "main..feature"
+	writeMessage := true
+	messageFile := ""
+	req := Request{Range: &revisionRange, WriteMessage: &writeMessage, MessageFile: &messageFile}
+
+	expectedEvent := Succeeded{TrailerLines: []string{}}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+
+	if len(writes) != 0 {
+		t.Errorf("expected no writes, got: %s", writes)
+		t.Fail()
+	}
+}
+
+func TestSquashTrailersShouldFailWithoutARange(t *testing.T) {
+	deps := Dependencies{}
+
+	revisionRange := ""
+	writeMessage := false
+	messageFile := ""
+	req := Request{Range: &revisionRange, WriteMessage: &writeMessage, MessageFile: &messageFile}
+
+	expectedEvent := Failed{Reason: errors.New("the range of commits to squash is missing, e.g. main..feature")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestSquashTrailersShouldFailOutsideOfAGitRepository(t *testing.T) {
+	deps := Dependencies{
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return false },
+		},
+	}
+
+	revisionRange := "main..feature"
+	writeMessage := false
+	messageFile := ""
+	req := Request{Range: &revisionRange, WriteMessage: &writeMessage, MessageFile: &messageFile}
+
+	expectedEvent := Failed{Reason: errors.New("failed to collect the co-authors: not inside a git repository")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestSquashTrailersShouldFailWhenTheMessageCannotBeWritten(t *testing.T) {
+	commits := []history.Commit{
+		{Author: "Me <me@x.y>", Message: "one\n\nCo-authored-by: A <a@x.y>\n"},
+		{Author: "B <b@x.y>", Message: "two\n\nCo-authored-by: Me <ME@x.y>\nCo-authored-by: a <A@x.y>\nCo-authored-by: C <c@x.y>\n"},
+	}
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return commits, nil
+		},
+		CommitterIdentity: func() (string, error) {
+			return "Me <me@x.y>", nil
+		},
+		GitPath: func(name string) (string, error) {
+			return ".git/" + name, nil
+		},
+		ReadFile: func(path string) ([]byte, error) {
+			return nil, os.ErrNotExist
+		},
+		WriteFile: func(path string, data []byte, perm os.FileMode) error {
+			return errors.New("permission denied")
+		},
+	}
+
+	revisionRange := "main..feature"
+	writeMessage := true
+	messageFile := ""
+	req := Request{Range: &revisionRange, WriteMessage: &writeMessage, MessageFile: &messageFile}
+
+	expectedEvent := Failed{Reason: errors.New("failed to write .git/SQUASH_MSG: permission denied")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
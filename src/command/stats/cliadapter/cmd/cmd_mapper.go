@@ -0,0 +1,51 @@
+package statscmdadapter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/hekmekk/git-team/src/command/stats"
+	statseventadapter "github.com/hekmekk/git-team/src/command/stats/cliadapter/event"
+	activation "github.com/hekmekk/git-team/src/shared/activation/impl"
+	commandadapter "github.com/hekmekk/git-team/src/shared/cli/commandadapter"
+	configds "github.com/hekmekk/git-team/src/shared/config/datasource"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/impl"
+	"github.com/hekmekk/git-team/src/shared/history"
+)
+
+// Command the stats command
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:      "stats",
+		Usage:     "Show who has paired with whom, based on the authors and co-authors of the commits",
+		ArgsUsage: "[<range>]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "since", Usage: "Only consider commits more recent than a date, e.g. \"2 weeks ago\" or \"monday\""},
+			&cli.StringFlag{Name: "format", Value: "table", Usage: fmt.Sprintf("The output format, one of: %s", strings.Join(stats.Formats, ", "))},
+		},
+		Action: func(c *cli.Context) error {
+			revisionRange := c.Args().First()
+			since := c.String("since")
+			format := c.String("format")
+			return commandadapter.Run(policy(&revisionRange, &since, &format), statseventadapter.MapEventToEffect)
+		},
+	}
+}
+
+func policy(revisionRange *string, since *string, format *string) stats.Policy {
+	return stats.Policy{
+		Req: stats.Request{
+			Range:  revisionRange,
+			Since:  since,
+			Format: format,
+		},
+		Deps: stats.Dependencies{
+			ConfigReader:        configds.NewGitconfigDataSource(gitconfig.NewDataSource()),
+			GitConfigReader:     gitconfig.NewDataSource(),
+			ActivationValidator: activation.NewGitConfigDataSource(gitconfig.NewDataSource()),
+			Log:                 history.Log,
+		},
+	}
+}
@@ -0,0 +1,87 @@
+package statseventadapter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+
+	"github.com/hekmekk/git-team/src/command/stats"
+	"github.com/hekmekk/git-team/src/core/events"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+// MapEventToEffect convert stats events to effects for the cli
+func MapEventToEffect(event events.Event) effects.Effect {
+	switch evt := event.(type) {
+	case stats.Succeeded:
+		switch evt.Format {
+		case "csv":
+			return effects.NewExitOkMsg(toCSV(evt.Matrix))
+		case "json":
+			return effects.NewExitOkMsg(toJSON(evt.Matrix))
+		default:
+			return effects.NewExitOkMsg(toTable(evt.Matrix))
+		}
+	case stats.Failed:
+		return effects.NewExitErrMsg(evt.Reason)
+	default:
+		return effects.NewExitOk()
+	}
+}
+
+func toTable(matrix stats.Matrix) string {
+	if len(matrix.People) == 0 {
+		return color.CyanString("no commits found")
+	}
+
+	var buffer bytes.Buffer
+	writer := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(writer, "\t%s\n", strings.Join(matrix.People, "\t"))
+	for i, person := range matrix.People {
+		fmt.Fprintf(writer, "%s\t%s\n", person, strings.Join(toStrings(matrix.Commits[i]), "\t"))
+	}
+	writer.Flush()
+
+	return strings.TrimRight(buffer.String(), "\n")
+}
+
+func toCSV(matrix stats.Matrix) string {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	writer.Write(append([]string{""}, matrix.People...))
+	for i, person := range matrix.People {
+		writer.Write(append([]string{person}, toStrings(matrix.Commits[i])...))
+	}
+	writer.Flush()
+
+	return strings.TrimRight(buffer.String(), "\n")
+}
+
+type jsonMatrix struct {
+	People  []string `json:"people"`
+	Commits [][]int  `json:"commits"`
+}
+
+func toJSON(matrix stats.Matrix) string {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(jsonMatrix{People: matrix.People, Commits: matrix.Commits})
+	return strings.TrimRight(buffer.String(), "\n")
+}
+
+func toStrings(counts []int) []string {
+	values := []string{}
+	for _, count := range counts {
+		values = append(values, strconv.Itoa(count))
+	}
+	return values
+}
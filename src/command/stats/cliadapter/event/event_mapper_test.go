@@ -0,0 +1,89 @@
+package statseventadapter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hekmekk/git-team/src/command/stats"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+var matrix = stats.Matrix{
+	People:  []string{"a", "B <b@x.y>"},
+	Commits: [][]int{{3, 1}, {1, 2}},
+}
+
+func TestMapEventToEffectSucceededAsTable(t *testing.T) {
+	msg := "           a  B <b@x.y>\na          3  1\nB <b@x.y>  1  2"
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffect(stats.Succeeded{Matrix: matrix, Format: "table"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %q, got: %q", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectSucceededAsCSV(t *testing.T) {
+	msg := ",a,B <b@x.y>\na,3,1\nB <b@x.y>,1,2"
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffect(stats.Succeeded{Matrix: matrix, Format: "csv"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectSucceededAsJSON(t *testing.T) {
+	msg := `{"people":["a","B <b@x.y>"],"commits":[[3,1],[1,2]]}`
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffect(stats.Succeeded{Matrix: matrix, Format: "json"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectSucceededWithoutCommits(t *testing.T) {
+	expectedEffect := effects.NewExitOkMsg("no commits found")
+
+	effect := MapEventToEffect(stats.Succeeded{Matrix: stats.Matrix{People: []string{}, Commits: [][]int{}}, Format: "table"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectFailed(t *testing.T) {
+	err := errors.New("stats failure")
+
+	expectedEffect := effects.NewExitErrMsg(err)
+
+	effect := MapEventToEffect(stats.Failed{Reason: err})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectUnknownEvent(t *testing.T) {
+	expectedEffect := effects.NewExitOk()
+
+	effect := MapEventToEffect("UNKNOWN_EVENT")
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
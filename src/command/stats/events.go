@@ -0,0 +1,12 @@
+package stats
+
+// Succeeded the pairing matrix has been computed
+type Succeeded struct {
+	Matrix Matrix
+	Format string
+}
+
+// Failed failed to compute the pairing matrix
+type Failed struct {
+	Reason error
+}
@@ -0,0 +1,71 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/hekmekk/git-team/src/shared/history"
+	"github.com/hekmekk/git-team/src/shared/roster"
+	"github.com/hekmekk/git-team/src/shared/trailer"
+)
+
+// Matrix the number of commits each pair of people has shared. The diagonal holds the number of commits each person has been involved in.
+type Matrix struct {
+	People  []string
+	Commits [][]int
+}
+
+// NewMatrix count the shared commits, naming people by their alias where possible. People are ordered by the number of commits they have been involved in.
+func NewMatrix(commits []history.Commit, format trailer.Format, knownPeople roster.Roster) Matrix {
+	index := map[string]int{}
+	names := []string{}
+	counts := map[[2]int]int{}
+
+	for _, commit := range commits {
+		involved := []int{}
+		for _, identity := range commit.People(format) {
+			key := history.Key(identity)
+			if alias, ok := knownPeople.Alias(identity); ok {
+				key = "alias:" + alias
+			}
+
+			i, exists := index[key]
+			if !exists {
+				i = len(names)
+				index[key] = i
+				names = append(names, knownPeople.Name(identity))
+			}
+
+			involved = append(involved, i)
+		}
+
+		for _, i := range involved {
+			for _, j := range involved {
+				counts[[2]int{i, j}]++
+			}
+		}
+	}
+
+	order := make([]int, len(names))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ca, cb := counts[[2]int{order[a], order[a]}], counts[[2]int{order[b], order[b]}]
+		if ca != cb {
+			return ca > cb
+		}
+		return names[order[a]] < names[order[b]]
+	})
+
+	matrix := Matrix{People: []string{}, Commits: [][]int{}}
+	for _, i := range order {
+		matrix.People = append(matrix.People, names[i])
+		row := []int{}
+		for _, j := range order {
+			row = append(row, counts[[2]int{i, j}])
+		}
+		matrix.Commits = append(matrix.Commits, row)
+	}
+
+	return matrix
+}
@@ -0,0 +1,97 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hekmekk/git-team/src/core/events"
+	activation "github.com/hekmekk/git-team/src/shared/activation/interface"
+	config "github.com/hekmekk/git-team/src/shared/config/interface"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/interface"
+	"github.com/hekmekk/git-team/src/shared/history"
+	"github.com/hekmekk/git-team/src/shared/roster"
+)
+
+// Formats the supported output formats
+var Formats = []string{"table", "csv", "json"}
+
+// Dependencies the dependencies of the stats Policy module
+type Dependencies struct {
+	ConfigReader        config.Reader
+	GitConfigReader     gitconfig.Reader
+	ActivationValidator activation.Validator
+	Log                 func(args ...string) ([]history.Commit, error)
+}
+
+// Request the commits to analyse and how to render the result
+type Request struct {
+	Range  *string
+	Since  *string
+	Format *string
+}
+
+// Policy the policy to apply
+type Policy struct {
+	Deps Dependencies
+	Req  Request
+}
+
+// Apply count who has shared commits with whom, based on the authors and co-authors of the commits
+func (policy Policy) Apply() events.Event {
+	deps := policy.Deps
+	req := policy.Req
+
+	format := *req.Format
+	if format == "" {
+		format = Formats[0]
+	}
+
+	if !isOneOf(format, Formats) {
+		return Failed{Reason: fmt.Errorf("unknown format '%s', expected one of: %s", format, strings.Join(Formats, ", "))}
+	}
+
+	if !deps.ActivationValidator.IsInsideAGitRepository() {
+		return Failed{Reason: errors.New("failed to analyse the commit history: not inside a git repository")}
+	}
+
+	cfg, err := deps.ConfigReader.Read()
+	if err != nil {
+		return Failed{Reason: fmt.Errorf("failed to read config: %s", err)}
+	}
+
+	knownPeople, err := roster.Load(deps.GitConfigReader)
+	if err != nil {
+		return Failed{Reason: fmt.Errorf("failed to retrieve assignments: %s", err)}
+	}
+
+	commits, err := deps.Log(LogArgs(*req.Range, *req.Since)...)
+	if err != nil {
+		return Failed{Reason: err}
+	}
+
+	return Succeeded{Matrix: NewMatrix(commits, cfg.Trailer(), knownPeople), Format: format}
+}
+
+// LogArgs the git log arguments which select the non-merge commits of a range, optionally limited to those more recent than a date
+func LogArgs(revisionRange string, since string) []string {
+	if revisionRange == "" {
+		revisionRange = "HEAD"
+	}
+
+	args := []string{"--no-merges"}
+	if since != "" {
+		args = append(args, "--since="+since)
+	}
+
+	return append(args, revisionRange, "--")
+}
+
+func isOneOf(candidate string, values []string) bool {
+	for _, value := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
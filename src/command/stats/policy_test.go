@@ -0,0 +1,234 @@
+package stats
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	config "github.com/hekmekk/git-team/src/shared/config/entity/config"
+	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+	"github.com/hekmekk/git-team/src/shared/history"
+)
+
+type configReaderMock struct {
+	read func() (config.Config, error)
+}
+
+func (mock configReaderMock) Read() (config.Config, error) {
+	return mock.read()
+}
+
+type gitConfigReaderMock struct {
+	getRegexp func(gitconfigscope.Scope, string) (map[string]string, error)
+}
+
+func (mock gitConfigReaderMock) Get(scope gitconfigscope.Scope, key string) (string, error) {
+	return "", nil
+}
+
+func (mock gitConfigReaderMock) GetAll(scope gitconfigscope.Scope, key string) ([]string, error) {
+	return []string{}, nil
+}
+
+func (mock gitConfigReaderMock) GetRegexp(scope gitconfigscope.Scope, pattern string) (map[string]string, error) {
+	return mock.getRegexp(scope, pattern)
+}
+
+func (mock gitConfigReaderMock) List(scope gitconfigscope.Scope) (map[string]string, error) {
+	return nil, nil
+}
+
+type activationValidatorMock struct {
+	isInsideAGitRepository func() bool
+}
+
+func (mock activationValidatorMock) IsInsideAGitRepository() bool {
+	return mock.isInsideAGitRepository()
+}
+
+func TestStatsShouldCountTheSharedCommits(t *testing.T) {
+	t.Parallel()
+
+	commits := []history.Commit{
+		{Author: "A <a@x.y>", Message: "one\n\nCo-authored-by: B <b@x.y>\n"},
+		{Author: "B <b@x.y>", Message: "two\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>\n"},
+		{Author: "C <c@x.y>", Message: "three\n"},
+		{Author: "Alice <A@X.Y>", Message: "four\n"},
+	}
+
+	var logArgs []string
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			logArgs = args
+			return commits, nil
+		},
+	}
+
+	revisionRange := "main..feature"
+	since := "monday"
+	format := ""
+	req := Request{Range: &revisionRange, Since: &since, Format: &format}
+
+	expectedEvent := Succeeded{
+		Matrix: Matrix{
+			People: []string{"a", "B <b@x.y>", "C <c@x.y>"},
+			Commits: [][]int{
+				{3, 2, 1},
+				{2, 2, 1},
+				{1, 1, 2},
+			},
+		},
+		Format: "table",
+	}
+	expectedLogArgs := []string{"--no-merges", "--since=monday", "main..feature", "--"}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedLogArgs, logArgs) {
+		t.Errorf("expected: %s, got: %s", expectedLogArgs, logArgs)
+		t.Fail()
+	}
+}
+
+func TestStatsShouldDefaultToTheHistoryOfHEAD(t *testing.T) {
+	t.Parallel()
+
+	var logArgs []string
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			logArgs = args
+			return []history.Commit{}, nil
+		},
+	}
+
+	revisionRange := ""
+	since := ""
+	format := "json"
+	req := Request{Range: &revisionRange, Since: &since, Format: &format}
+
+	expectedLogArgs := []string{"--no-merges", "HEAD", "--"}
+
+	Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedLogArgs, logArgs) {
+		t.Errorf("expected: %s, got: %s", expectedLogArgs, logArgs)
+		t.Fail()
+	}
+}
+
+func TestStatsShouldFailForAnUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	deps := Dependencies{}
+
+	revisionRange := ""
+	since := ""
+	format := "xml"
+	req := Request{Range: &revisionRange, Since: &since, Format: &format}
+
+	expectedEvent := Failed{Reason: errors.New("unknown format 'xml', expected one of: table, csv, json")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestStatsShouldFailWhenReadingTheHistoryFails(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("git log failed: fatal: bad revision 'nope'")
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return nil, err
+		},
+	}
+
+	revisionRange := "nope"
+	since := ""
+	format := "csv"
+	req := Request{Range: &revisionRange, Since: &since, Format: &format}
+
+	expectedEvent := Failed{Reason: err}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestStatsShouldFailOutsideOfAGitRepository(t *testing.T) {
+	t.Parallel()
+
+	deps := Dependencies{
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return false },
+		},
+	}
+
+	revisionRange := ""
+	since := ""
+	format := ""
+	req := Request{Range: &revisionRange, Since: &since, Format: &format}
+
+	expectedEvent := Failed{Reason: errors.New("failed to analyse the commit history: not inside a git repository")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hekmekk/git-team/src/command/status"
 	"github.com/hekmekk/git-team/src/core/events"
 	"github.com/hekmekk/git-team/src/shared/cli/effects"
+	"github.com/hekmekk/git-team/src/shared/role"
 	state "github.com/hekmekk/git-team/src/shared/state/entity"
 )
 
@@ -39,6 +40,34 @@ func toString(theState state.State) string {
 				buffer.WriteString(color.WhiteString("\n─ %s", coauthor))
 			}
 		}
+		for _, theRole := range role.All() {
+			identities := []string{}
+			for _, assignment := range theState.Roles {
+				if assignment.Role == theRole {
+					identities = append(identities, assignment.Identity)
+				}
+			}
+			sort.Strings(identities)
+			if len(identities) > 0 {
+				buffer.WriteString("\n\n")
+				buffer.WriteString(color.New(color.FgBlue).Add(color.Bold).Sprintf("%ss", theRole))
+				for _, identity := range identities {
+					buffer.WriteString(color.WhiteString("\n─ %s", identity))
+				}
+			}
+		}
+		if len(theState.Displaced) > 0 {
+			keys := make([]string, 0, len(theState.Displaced))
+			for key := range theState.Displaced {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			buffer.WriteString("\n\n")
+			buffer.WriteString(color.New(color.FgBlue).Add(color.Bold).Sprint("displaced settings (restored on disable)"))
+			for _, key := range keys {
+				buffer.WriteString(color.WhiteString("\n─ %s: %s", key, theState.Displaced[key]))
+			}
+		}
 	}
 
 	return buffer.String()
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hekmekk/git-team/src/command/status"
 	"github.com/hekmekk/git-team/src/shared/cli/effects"
+	"github.com/hekmekk/git-team/src/shared/role"
 	state "github.com/hekmekk/git-team/src/shared/state/entity"
 )
 
@@ -24,6 +25,38 @@ func TestMapEventToEffectStateRetrievalSucceededEnabled(t *testing.T) {
 	}
 }
 
+func TestMapEventToEffectStateRetrievalSucceededEnabledWithDisplacedSettings(t *testing.T) {
+	msg := "git-team enabled\n\nco-authors\n─ Mr. Noujz <noujz@mr.se>\n\ndisplaced settings (restored on disable)\n─ commit.template: /path/to/template\n─ core.hooksPath: /path/to/hooks"
+	state := state.NewStateEnabled([]string{"Mr. Noujz <noujz@mr.se>"}).WithDisplaced(map[string]string{"core.hooksPath": "/path/to/hooks", "commit.template": "/path/to/template"})
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffect(status.StateRetrievalSucceeded{State: state})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectStateRetrievalSucceededEnabledWithRoles(t *testing.T) {
+	msg := "git-team enabled\n\nco-authors\n─ Mr. Noujz <noujz@mr.se>\n\nnavigators\n─ C <c@x.y>\n\nreviewers\n─ A <a@x.y>\n─ B <b@x.y>"
+	state := state.NewStateEnabled([]string{"Mr. Noujz <noujz@mr.se>"}).WithRoles([]role.Assignment{
+		{Role: role.Reviewer, Identity: "B <b@x.y>"},
+		{Role: role.Navigator, Identity: "C <c@x.y>"},
+		{Role: role.Reviewer, Identity: "A <a@x.y>"},
+	})
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffect(status.StateRetrievalSucceeded{State: state})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
 func TestMapEventToEffectStateRetrievalSucceededDisabled(t *testing.T) {
 	msg := "git-team disabled"
 	state := state.NewStateDisabled()
@@ -37,6 +37,10 @@ func (policy Policy) Apply() events.Event {
 		return StateRetrievalFailed{Reason: fmt.Errorf("failed to get status with activation-scope=%s: not inside a git repository", activationScope)}
 	}
 
+	if activationScope == activationscope.Directory && cfg.ActivationDirectory == "" {
+		return StateRetrievalFailed{Reason: fmt.Errorf("failed to get status with activation-scope=%s: activation-directory is not configured", activationScope)}
+	}
+
 	state, stateRepositoryQueryErr := deps.StateReader.Query(cfg.ActivationScope)
 	if stateRepositoryQueryErr != nil {
 		return StateRetrievalFailed{Reason: fmt.Errorf("failed to query current state: %s", stateRepositoryQueryErr)}
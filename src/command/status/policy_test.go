@@ -160,3 +160,29 @@ func TestStatusShouldNotBeRetrievedDueToStateRetrievalError(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestStatusShouldFailWhenActivationDirectoryIsNotConfigured(t *testing.T) {
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Directory}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool {
+				return true
+			},
+		},
+	}
+
+	expectedErr := errors.New("failed to get status with activation-scope=directory: activation-directory is not configured")
+
+	expectedEvent := StateRetrievalFailed{Reason: expectedErr}
+
+	event := Policy{deps}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
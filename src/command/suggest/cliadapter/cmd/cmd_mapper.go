@@ -0,0 +1,61 @@
+package suggestcmdadapter
+
+import (
+	"github.com/urfave/cli/v2"
+
+	enablecmdadapter "github.com/hekmekk/git-team/src/command/enable/cliadapter/cmd"
+	"github.com/hekmekk/git-team/src/command/suggest"
+	suggesteventadapter "github.com/hekmekk/git-team/src/command/suggest/cliadapter/event"
+	"github.com/hekmekk/git-team/src/core/events"
+	activation "github.com/hekmekk/git-team/src/shared/activation/impl"
+	commandadapter "github.com/hekmekk/git-team/src/shared/cli/commandadapter"
+	configds "github.com/hekmekk/git-team/src/shared/config/datasource"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/impl"
+	"github.com/hekmekk/git-team/src/shared/gitrepo"
+	"github.com/hekmekk/git-team/src/shared/history"
+)
+
+// Command the suggest command
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "suggest",
+		Usage: "Suggest pairs or mobs whose members have not worked together for the longest time",
+		Flags: []cli.Flag{
+			&cli.IntFlag{Name: "size", Value: 2, Usage: "The number of people per group"},
+			&cli.StringFlag{Name: "since", Usage: "Only consider commits more recent than a date, e.g. \"3 months ago\""},
+			&cli.BoolFlag{Name: "enable", Value: false, Usage: "Enable the co-authors of the suggestion which includes you"},
+			dryrun.Flag(),
+		},
+		Action: func(c *cli.Context) error {
+			size := c.Int("size")
+			since := c.String("since")
+			enable := c.Bool("enable")
+
+			enablePolicy, mapEnableEvent := enablecmdadapter.PolicyFactory(c)
+			enableCoauthors := func(coauthors []string) events.Event {
+				return enablePolicy(&coauthors).Apply()
+			}
+
+			return commandadapter.Run(policy(&size, &since, &enable, enableCoauthors), suggesteventadapter.MapEventToEffectFactory(mapEnableEvent))
+		},
+	}
+}
+
+func policy(size *int, since *string, enable *bool, enableCoauthors func([]string) events.Event) suggest.Policy {
+	return suggest.Policy{
+		Req: suggest.Request{
+			Size:   size,
+			Since:  since,
+			Enable: enable,
+		},
+		Deps: suggest.Dependencies{
+			ConfigReader:            configds.NewGitconfigDataSource(gitconfig.NewDataSource()),
+			GitConfigReader:         gitconfig.NewDataSource(),
+			ActivationValidator:     activation.NewGitConfigDataSource(gitconfig.NewDataSource()),
+			GetEffectiveConfigValue: gitrepo.EffectiveConfigValue,
+			Log:                     history.Log,
+			Enable:                  enableCoauthors,
+		},
+	}
+}
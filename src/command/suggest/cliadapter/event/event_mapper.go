@@ -0,0 +1,54 @@
+package suggesteventadapter
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/hekmekk/git-team/src/command/suggest"
+	"github.com/hekmekk/git-team/src/core/events"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+// MapEventToEffectFactory convert suggest events to effects for the cli, the outcome of enabling the own group is converted by mapEnableEvent
+func MapEventToEffectFactory(mapEnableEvent func(events.Event) effects.Effect) func(events.Event) effects.Effect {
+	return func(event events.Event) effects.Effect {
+		switch evt := event.(type) {
+		case suggest.Succeeded:
+			return effects.NewExitOkMsg(toString(evt))
+		case suggest.Enabled:
+			return mapEnableEvent(evt.Event)
+		case suggest.Failed:
+			return effects.NewExitErrMsg(evt.Reason)
+		default:
+			return effects.NewExitOk()
+		}
+	}
+}
+
+func toString(evt suggest.Succeeded) string {
+	var buffer bytes.Buffer
+	buffer.WriteString(color.New(color.FgBlue).Add(color.Bold).Sprint("suggestions"))
+
+	for i, group := range evt.Groups {
+		names := []string{}
+		for _, member := range group.Members {
+			names = append(names, member.Name)
+		}
+
+		lastTogether := "never worked together"
+		if !group.LastTogether.IsZero() {
+			lastTogether = "last worked together on " + group.LastTogether.Format("2006-01-02")
+		}
+
+		marker := ""
+		if i == evt.Own {
+			marker = " *"
+		}
+
+		buffer.WriteString(color.WhiteString("\n─ %s (%s)%s", strings.Join(names, " + "), lastTogether, marker))
+	}
+
+	return buffer.String()
+}
@@ -0,0 +1,78 @@
+package suggesteventadapter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hekmekk/git-team/src/command/suggest"
+	"github.com/hekmekk/git-team/src/core/events"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+var succeeded = suggest.Succeeded{
+	Groups: []suggest.Group{
+		{Members: []suggest.Member{{Name: "U <u@x.y>", Identity: "U <u@x.y>"}, {Name: "a", Identity: "A <a@x.y>"}}},
+		{Members: []suggest.Member{{Name: "b", Identity: "B <b@x.y>"}, {Name: "c", Identity: "C <c@x.y>"}}, LastTogether: time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC)},
+	},
+	Own:         0,
+	CurrentUser: "U <u@x.y>",
+}
+
+func TestMapEventToEffectSucceeded(t *testing.T) {
+	msg := "suggestions\n─ U <u@x.y> + a (never worked together) *\n─ b + c (last worked together on 2021-05-01)"
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffectFactory(nil)(succeeded)
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectEnabledShouldMapTheOutcomeOfEnable(t *testing.T) {
+	var mappedEvent events.Event
+	expectedEffect := effects.NewExitOkMsg("enabled")
+
+	effect := MapEventToEffectFactory(func(event events.Event) effects.Effect {
+		mappedEvent = event
+		return expectedEffect
+	})(suggest.Enabled{Coauthors: []string{"A <a@x.y>"}, Event: "ENABLED"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+
+	if "ENABLED" != mappedEvent {
+		t.Errorf("expected: %s, got: %s", "ENABLED", mappedEvent)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectFailed(t *testing.T) {
+	err := errors.New("suggest failure")
+
+	expectedEffect := effects.NewExitErrMsg(err)
+
+	effect := MapEventToEffectFactory(nil)(suggest.Failed{Reason: err})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectUnknownEvent(t *testing.T) {
+	expectedEffect := effects.NewExitOk()
+
+	effect := MapEventToEffectFactory(nil)("UNKNOWN_EVENT")
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
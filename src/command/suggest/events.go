@@ -0,0 +1,38 @@
+package suggest
+
+import (
+	"time"
+
+	"github.com/hekmekk/git-team/src/core/events"
+)
+
+// Member somebody who is part of a suggested group
+type Member struct {
+	Name     string
+	Identity string
+}
+
+// Group a suggested pair or mob along with the last time its members have worked together, which is zero if they never have
+type Group struct {
+	Members      []Member
+	LastTogether time.Time
+}
+
+// Succeeded pairings have been suggested
+type Succeeded struct {
+	Groups []Group
+	// Own the index of the group which includes the current user, -1 if there is none
+	Own         int
+	CurrentUser string
+}
+
+// Enabled the co-authors of the suggestion which includes the current user have been enabled, Event being the outcome of enable
+type Enabled struct {
+	Coauthors []string
+	Event     events.Event
+}
+
+// Failed failed to suggest pairings
+type Failed struct {
+	Reason error
+}
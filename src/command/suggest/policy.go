@@ -0,0 +1,229 @@
+package suggest
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hekmekk/git-team/src/core/events"
+	activation "github.com/hekmekk/git-team/src/shared/activation/interface"
+	config "github.com/hekmekk/git-team/src/shared/config/interface"
+	giterror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/interface"
+	"github.com/hekmekk/git-team/src/shared/history"
+	"github.com/hekmekk/git-team/src/shared/roster"
+	"github.com/hekmekk/git-team/src/shared/trailer"
+)
+
+// Dependencies the dependencies of the suggest Policy module
+type Dependencies struct {
+	ConfigReader            config.Reader
+	GitConfigReader         gitconfig.Reader
+	ActivationValidator     activation.Validator
+	GetEffectiveConfigValue func(key string) (string, error)
+	Log                     func(args ...string) ([]history.Commit, error)
+	Enable                  func(coauthors []string) events.Event
+}
+
+// Request the size of the groups to suggest, how far back to look for previous pairings and whether to enable the suggestion which includes the current user
+type Request struct {
+	Size   *int
+	Since  *string
+	Enable *bool
+}
+
+// Policy the policy to apply
+type Policy struct {
+	Deps Dependencies
+	Req  Request
+}
+
+// Apply split the roster and the current user into groups whose members have not worked together for as long as possible
+func (policy Policy) Apply() events.Event {
+	deps := policy.Deps
+	req := policy.Req
+
+	size := *req.Size
+	if size < 2 {
+		return Failed{Reason: fmt.Errorf("the size of a group must be at least 2, got: %d", size)}
+	}
+
+	cfg, err := deps.ConfigReader.Read()
+	if err != nil {
+		return Failed{Reason: fmt.Errorf("failed to read config: %s", err)}
+	}
+
+	knownPeople, err := roster.Load(deps.GitConfigReader)
+	if err != nil {
+		return Failed{Reason: fmt.Errorf("failed to retrieve assignments: %s", err)}
+	}
+
+	currentUser, err := lookupCurrentUser(deps)
+	if err != nil {
+		return Failed{Reason: err}
+	}
+
+	people := []Member{}
+	if currentUser != "" {
+		people = append(people, Member{Name: knownPeople.Name(currentUser), Identity: currentUser})
+	}
+	for _, a := range knownPeople.Assignments() {
+		if currentUser == "" || history.Key(a.Coauthor) != history.Key(currentUser) {
+			people = append(people, Member{Name: a.Alias, Identity: a.Coauthor})
+		}
+	}
+
+	if len(people) < size {
+		return Failed{Reason: fmt.Errorf("not enough people for a group of %d, add some with 'git team assignments add'", size)}
+	}
+
+	lastTogether := map[[2]string]time.Time{}
+	if deps.ActivationValidator.IsInsideAGitRepository() {
+		args := []string{"--no-merges"}
+		if *req.Since != "" {
+			args = append(args, "--since="+*req.Since)
+		}
+
+		commits, err := deps.Log(append(args, "HEAD", "--")...)
+		if err != nil {
+			return Failed{Reason: err}
+		}
+
+		lastTogether = collectLastTogether(commits, cfg.Trailer())
+	}
+
+	groups := group(people, size, lastTogether)
+
+	own := -1
+	for i, g := range groups {
+		for _, member := range g.Members {
+			if currentUser != "" && member.Identity == currentUser {
+				own = i
+			}
+		}
+	}
+
+	if !*req.Enable {
+		return Succeeded{Groups: groups, Own: own, CurrentUser: currentUser}
+	}
+
+	if own < 0 {
+		return Failed{Reason: errors.New("none of the suggestions includes you, make sure user.email is configured")}
+	}
+
+	coauthors := []string{}
+	for _, member := range groups[own].Members {
+		if member.Identity != currentUser {
+			coauthors = append(coauthors, member.Identity)
+		}
+	}
+
+	return Enabled{Coauthors: coauthors, Event: deps.Enable(coauthors)}
+}
+
+func lookupCurrentUser(deps Dependencies) (string, error) {
+	name, err := deps.GetEffectiveConfigValue("user.name")
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return "", fmt.Errorf("failed to get user.name: %s", err)
+	}
+
+	email, err := deps.GetEffectiveConfigValue("user.email")
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return "", fmt.Errorf("failed to get user.email: %s", err)
+	}
+
+	if email == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf("%s <%s>", name, email), nil
+}
+
+// collectLastTogether the date of the most recent commit shared by each pair of people, keyed by their sorted keys
+func collectLastTogether(commits []history.Commit, format trailer.Format) map[[2]string]time.Time {
+	lastTogether := map[[2]string]time.Time{}
+	for _, commit := range commits {
+		people := commit.People(format)
+		for i := range people {
+			for _, other := range people[i+1:] {
+				key := pairKey(people[i], other)
+				if commit.AuthorDate.After(lastTogether[key]) {
+					lastTogether[key] = commit.AuthorDate
+				}
+			}
+		}
+	}
+	return lastTogether
+}
+
+// group greedily form groups, starting with the first person, by adding whoever has not worked with the group for the longest time.
+// People who are left over form a smaller group, unless it is a single person, who joins the last group instead.
+func group(people []Member, size int, lastTogether map[[2]string]time.Time) []Group {
+	remaining := append([]Member{}, people...)
+	groups := []Group{}
+
+	for len(remaining) >= size {
+		members := []Member{remaining[0]}
+		remaining = remaining[1:]
+
+		for len(members) < size {
+			best := 0
+			for i := 1; i < len(remaining); i++ {
+				if isStaler(latest(members, remaining[i], lastTogether), latest(members, remaining[best], lastTogether)) {
+					best = i
+				}
+			}
+
+			members = append(members, remaining[best])
+			remaining = append(remaining[:best], remaining[best+1:]...)
+		}
+
+		groups = append(groups, Group{Members: members})
+	}
+
+	if len(remaining) == 1 {
+		last := &groups[len(groups)-1]
+		last.Members = append(last.Members, remaining...)
+	} else if len(remaining) > 1 {
+		groups = append(groups, Group{Members: remaining})
+	}
+
+	for i := range groups {
+		groups[i].LastTogether = lastTogetherOf(groups[i].Members, lastTogether)
+	}
+
+	return groups
+}
+
+// latest the most recent time the candidate has worked with any of the members
+func latest(members []Member, candidate Member, lastTogether map[[2]string]time.Time) time.Time {
+	result := time.Time{}
+	for _, member := range members {
+		if t := lastTogether[pairKey(member.Identity, candidate.Identity)]; t.After(result) {
+			result = t
+		}
+	}
+	return result
+}
+
+// lastTogetherOf the most recent time any two members of the group have worked together
+func lastTogetherOf(members []Member, lastTogether map[[2]string]time.Time) time.Time {
+	result := time.Time{}
+	for i, member := range members {
+		if t := latest(members[i+1:], member, lastTogether); t.After(result) {
+			result = t
+		}
+	}
+	return result
+}
+
+func isStaler(t time.Time, other time.Time) bool {
+	return t.Before(other)
+}
+
+func pairKey(identity string, otherIdentity string) [2]string {
+	keys := []string{history.Key(identity), history.Key(otherIdentity)}
+	sort.Strings(keys)
+	return [2]string{keys[0], keys[1]}
+}
@@ -0,0 +1,501 @@
+package suggest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hekmekk/git-team/src/core/events"
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	config "github.com/hekmekk/git-team/src/shared/config/entity/config"
+	gitconfigerror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
+	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+	"github.com/hekmekk/git-team/src/shared/history"
+)
+
+type configReaderMock struct {
+	read func() (config.Config, error)
+}
+
+func (mock configReaderMock) Read() (config.Config, error) {
+	return mock.read()
+}
+
+type gitConfigReaderMock struct {
+	getRegexp func(gitconfigscope.Scope, string) (map[string]string, error)
+}
+
+func (mock gitConfigReaderMock) Get(scope gitconfigscope.Scope, key string) (string, error) {
+	return "", nil
+}
+
+func (mock gitConfigReaderMock) GetAll(scope gitconfigscope.Scope, key string) ([]string, error) {
+	return []string{}, nil
+}
+
+func (mock gitConfigReaderMock) GetRegexp(scope gitconfigscope.Scope, pattern string) (map[string]string, error) {
+	return mock.getRegexp(scope, pattern)
+}
+
+func (mock gitConfigReaderMock) List(scope gitconfigscope.Scope) (map[string]string, error) {
+	return nil, nil
+}
+
+type activationValidatorMock struct {
+	isInsideAGitRepository func() bool
+}
+
+func (mock activationValidatorMock) IsInsideAGitRepository() bool {
+	return mock.isInsideAGitRepository()
+}
+
+var (
+	day1 = time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+	day2 = time.Date(2021, 5, 2, 0, 0, 0, 0, time.UTC)
+
+	u = Member{Name: "U <u@x.y>", Identity: "U <u@x.y>"}
+	a = Member{Name: "a", Identity: "A <a@x.y>"}
+	b = Member{Name: "b", Identity: "B <b@x.y>"}
+	c = Member{Name: "c", Identity: "C <c@x.y>"}
+)
+
+func TestSuggestShouldPairPeopleWhoHaveNotWorkedTogetherForTheLongestTime(t *testing.T) {
+	t.Parallel()
+
+	commits := []history.Commit{
+		{Author: "U <u@x.y>", AuthorDate: day2, Message: "x\n\nCo-authored-by: A <a@x.y>\n"},
+		{Author: "U <u@x.y>", AuthorDate: day1, Message: "x\n\nCo-authored-by: B <b@x.y>\n"},
+		{Author: "U <u@x.y>", AuthorDate: day1, Message: "x\n\nCo-authored-by: C <c@x.y>\n"},
+		{Author: "A <a@x.y>", AuthorDate: day1, Message: "x\n\nCo-authored-by: C <c@x.y>\n"},
+	}
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>", "team.alias.b": "B <b@x.y>", "team.alias.c": "C <c@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		GetEffectiveConfigValue: func(key string) (string, error) {
+			switch key {
+			case "user.name":
+				return "U", nil
+			case "user.email":
+				return "u@x.y", nil
+			}
+			return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return commits, nil
+		},
+	}
+
+	size := 2
+	since := ""
+	enable := false
+	req := Request{Size: &size, Since: &since, Enable: &enable}
+
+	expectedEvent := Succeeded{
+		Groups: []Group{
+			{Members: []Member{u, b}, LastTogether: day1},
+			{Members: []Member{a, c}, LastTogether: day1},
+		},
+		Own:         0,
+		CurrentUser: "U <u@x.y>",
+	}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestSuggestShouldPreferPeopleWhoHaveNeverWorkedTogether(t *testing.T) {
+	t.Parallel()
+
+	commits := []history.Commit{
+		{Author: "U <u@x.y>", AuthorDate: day1, Message: "x\n\nCo-authored-by: A <a@x.y>\n"},
+		{Author: "U <u@x.y>", AuthorDate: day1, Message: "x\n\nCo-authored-by: B <b@x.y>\n"},
+	}
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>", "team.alias.b": "B <b@x.y>", "team.alias.c": "C <c@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		GetEffectiveConfigValue: func(key string) (string, error) {
+			switch key {
+			case "user.name":
+				return "U", nil
+			case "user.email":
+				return "u@x.y", nil
+			}
+			return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return commits, nil
+		},
+	}
+
+	size := 2
+	since := ""
+	enable := false
+	req := Request{Size: &size, Since: &since, Enable: &enable}
+
+	expectedEvent := Succeeded{
+		Groups: []Group{
+			{Members: []Member{u, c}},
+			{Members: []Member{a, b}},
+		},
+		Own:         0,
+		CurrentUser: "U <u@x.y>",
+	}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestSuggestShouldAddASinglePersonWhoIsLeftOverToTheLastGroup(t *testing.T) {
+	t.Parallel()
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>", "team.alias.b": "B <b@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		GetEffectiveConfigValue: func(key string) (string, error) {
+			switch key {
+			case "user.name":
+				return "U", nil
+			case "user.email":
+				return "u@x.y", nil
+			}
+			return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return []history.Commit{}, nil
+		},
+	}
+
+	size := 2
+	since := ""
+	enable := false
+	req := Request{Size: &size, Since: &since, Enable: &enable}
+
+	expectedEvent := Succeeded{
+		Groups:      []Group{{Members: []Member{u, a, b}}},
+		Own:         0,
+		CurrentUser: "U <u@x.y>",
+	}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestSuggestShouldFormMobs(t *testing.T) {
+	t.Parallel()
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>", "team.alias.b": "B <b@x.y>", "team.alias.c": "C <c@x.y>", "team.alias.d": "D <d@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		GetEffectiveConfigValue: func(key string) (string, error) {
+			switch key {
+			case "user.name":
+				return "U", nil
+			case "user.email":
+				return "u@x.y", nil
+			}
+			return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return []history.Commit{}, nil
+		},
+	}
+
+	size := 3
+	since := ""
+	enable := false
+	req := Request{Size: &size, Since: &since, Enable: &enable}
+
+	expectedEvent := Succeeded{
+		Groups: []Group{
+			{Members: []Member{u, a, b}},
+			{Members: []Member{c, {Name: "d", Identity: "D <d@x.y>"}}},
+		},
+		Own:         0,
+		CurrentUser: "U <u@x.y>",
+	}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestSuggestShouldNotIncludeTheCurrentUserTwice(t *testing.T) {
+	t.Parallel()
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.me": "Me <U@x.y>", "team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		GetEffectiveConfigValue: func(key string) (string, error) {
+			switch key {
+			case "user.name":
+				return "U", nil
+			case "user.email":
+				return "u@x.y", nil
+			}
+			return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return []history.Commit{}, nil
+		},
+	}
+
+	size := 2
+	since := ""
+	enable := false
+	req := Request{Size: &size, Since: &since, Enable: &enable}
+
+	expectedEvent := Succeeded{
+		Groups:      []Group{{Members: []Member{{Name: "me", Identity: "U <u@x.y>"}, a}}},
+		Own:         0,
+		CurrentUser: "U <u@x.y>",
+	}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestSuggestShouldEnableTheCoauthorsOfTheOwnGroup(t *testing.T) {
+	t.Parallel()
+
+	var enabledCoauthors []string
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>", "team.alias.b": "B <b@x.y>", "team.alias.c": "C <c@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		GetEffectiveConfigValue: func(key string) (string, error) {
+			switch key {
+			case "user.name":
+				return "U", nil
+			case "user.email":
+				return "u@x.y", nil
+			}
+			return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return []history.Commit{}, nil
+		},
+		Enable: func(coauthors []string) events.Event {
+			enabledCoauthors = coauthors
+			return "ENABLED"
+		},
+	}
+
+	size := 2
+	since := ""
+	enable := true
+	req := Request{Size: &size, Since: &since, Enable: &enable}
+
+	expectedCoauthors := []string{"A <a@x.y>"}
+	expectedEvent := Enabled{Coauthors: expectedCoauthors, Event: "ENABLED"}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedCoauthors, enabledCoauthors) {
+		t.Errorf("expected: %s, got: %s", expectedCoauthors, enabledCoauthors)
+		t.Fail()
+	}
+}
+
+func TestSuggestShouldFailToEnableWithoutOwnGroup(t *testing.T) {
+	t.Parallel()
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>", "team.alias.b": "B <b@x.y>", "team.alias.c": "C <c@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		GetEffectiveConfigValue: func(key string) (string, error) {
+			return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return []history.Commit{}, nil
+		},
+		Enable: func(coauthors []string) events.Event {
+			t.Errorf("unexpected call to enable with: %s", coauthors)
+			t.Fail()
+			return nil
+		},
+	}
+
+	size := 2
+	since := ""
+	enable := true
+	req := Request{Size: &size, Since: &since, Enable: &enable}
+
+	expectedEvent := Failed{Reason: errors.New("none of the suggestions includes you, make sure user.email is configured")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestSuggestShouldFailWithoutEnoughPeople(t *testing.T) {
+	t.Parallel()
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		GetEffectiveConfigValue: func(key string) (string, error) {
+			switch key {
+			case "user.name":
+				return "U", nil
+			case "user.email":
+				return "u@x.y", nil
+			}
+			return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return []history.Commit{}, nil
+		},
+	}
+
+	size := 3
+	since := ""
+	enable := false
+	req := Request{Size: &size, Since: &since, Enable: &enable}
+
+	expectedEvent := Failed{Reason: errors.New("not enough people for a group of 3, add some with 'git team assignments add'")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestSuggestShouldFailForGroupsOfLessThanTwo(t *testing.T) {
+	t.Parallel()
+
+	deps := Dependencies{}
+
+	size := 1
+	since := ""
+	enable := false
+	req := Request{Size: &size, Since: &since, Enable: &enable}
+
+	expectedEvent := Failed{Reason: errors.New("the size of a group must be at least 2, got: 1")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
@@ -0,0 +1,56 @@
+package verifycmdadapter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/hekmekk/git-team/src/command/verify"
+	verifyeventadapter "github.com/hekmekk/git-team/src/command/verify/cliadapter/event"
+	"github.com/hekmekk/git-team/src/core/validation"
+	activation "github.com/hekmekk/git-team/src/shared/activation/impl"
+	commandadapter "github.com/hekmekk/git-team/src/shared/cli/commandadapter"
+	configds "github.com/hekmekk/git-team/src/shared/config/datasource"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/impl"
+	"github.com/hekmekk/git-team/src/shared/history"
+)
+
+// Command the verify command
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:      "verify",
+		Usage:     "Check the co-author trailers of a range of commits, e.g. in CI, and exit with an error code on violations",
+		ArgsUsage: "<range>",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{Name: "allowed-domain", Usage: "Only accept co-authors with an email of this domain, may be repeated"},
+			&cli.BoolFlag{Name: "strict", Value: false, Usage: "Only accept co-authors which are among the assignments"},
+			&cli.StringFlag{Name: "format", Value: "text", Usage: fmt.Sprintf("The output format, one of: %s", strings.Join(verify.Formats, ", "))},
+		},
+		Action: func(c *cli.Context) error {
+			revisionRange := c.Args().First()
+			allowedDomains := c.StringSlice("allowed-domain")
+			strict := c.Bool("strict")
+			format := c.String("format")
+			return commandadapter.Run(policy(&revisionRange, &allowedDomains, &strict, &format), verifyeventadapter.MapEventToEffect)
+		},
+	}
+}
+
+func policy(revisionRange *string, allowedDomains *[]string, strict *bool, format *string) verify.Policy {
+	return verify.Policy{
+		Req: verify.Request{
+			Range:          revisionRange,
+			AllowedDomains: allowedDomains,
+			Strict:         strict,
+			Format:         format,
+		},
+		Deps: verify.Dependencies{
+			SanityCheckCoauthor: validation.SanityCheckCoauthor,
+			ConfigReader:        configds.NewGitconfigDataSource(gitconfig.NewDataSource()),
+			GitConfigReader:     gitconfig.NewDataSource(),
+			ActivationValidator: activation.NewGitConfigDataSource(gitconfig.NewDataSource()),
+			Log:                 history.Log,
+		},
+	}
+}
@@ -0,0 +1,107 @@
+package verifyeventadapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/hekmekk/git-team/src/command/verify"
+	"github.com/hekmekk/git-team/src/core/events"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+// MapEventToEffect convert verify events to effects for the cli, exiting with an error code if any commit violates the rules
+func MapEventToEffect(event events.Event) effects.Effect {
+	switch evt := event.(type) {
+	case verify.Succeeded:
+		var output string
+		switch evt.Format {
+		case "json":
+			output = toJSON(evt)
+		default:
+			output = toText(evt)
+		}
+
+		if len(evt.Reports) > 0 {
+			return effects.NewExitErrOutput(output)
+		}
+		return effects.NewExitOkMsg(output)
+	case verify.Failed:
+		return effects.NewExitErrMsg(evt.Reason)
+	default:
+		return effects.NewExitOk()
+	}
+}
+
+func toText(evt verify.Succeeded) string {
+	if len(evt.Reports) == 0 {
+		return color.GreenString("%s verified", pluralize(evt.Commits, "commit"))
+	}
+
+	var buffer bytes.Buffer
+	for _, report := range evt.Reports {
+		buffer.WriteString(color.New(color.FgBlue).Add(color.Bold).Sprintf("%s %s", shortHash(report.Hash), report.Subject))
+		for _, violation := range report.Violations {
+			buffer.WriteString(color.WhiteString("\n─ [%s] %s", violation.Kind, violation.Message))
+		}
+		buffer.WriteString("\n\n")
+	}
+	verb := "violate"
+	if len(evt.Reports) == 1 {
+		verb = "violates"
+	}
+	buffer.WriteString(color.RedString("%d of %s %s the co-author rules", len(evt.Reports), pluralize(evt.Commits, "commit"), verb))
+
+	return buffer.String()
+}
+
+type jsonViolation struct {
+	Kind    verify.Kind `json:"kind"`
+	Line    string      `json:"line"`
+	Message string      `json:"message"`
+}
+
+type jsonReport struct {
+	Commit     string          `json:"commit"`
+	Subject    string          `json:"subject"`
+	Violations []jsonViolation `json:"violations"`
+}
+
+type jsonResult struct {
+	Commits    int          `json:"commits"`
+	Violations []jsonReport `json:"violations"`
+}
+
+func toJSON(evt verify.Succeeded) string {
+	result := jsonResult{Commits: evt.Commits, Violations: []jsonReport{}}
+	for _, report := range evt.Reports {
+		violations := []jsonViolation{}
+		for _, violation := range report.Violations {
+			violations = append(violations, jsonViolation{Kind: violation.Kind, Line: violation.Line, Message: violation.Message})
+		}
+		result.Violations = append(result.Violations, jsonReport{Commit: report.Hash, Subject: report.Subject, Violations: violations})
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(result)
+	return strings.TrimRight(buffer.String(), "\n")
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
+
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
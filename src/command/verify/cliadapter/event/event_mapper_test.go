@@ -0,0 +1,83 @@
+package verifyeventadapter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/fatih/color"
+
+	"github.com/hekmekk/git-team/src/command/verify"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+var reports = []verify.Report{
+	{
+		Hash:    "2222222222222222222222222222222222222222",
+		Subject: "malformed",
+		Violations: []verify.Violation{
+			{Kind: verify.Malformed, Line: "Co-authored-by: foo", Message: "not a valid coauthor: foo"},
+			{Kind: verify.Duplicate, Line: "Co-authored-by: A <a@x.y>", Message: "co-author 'A <a@x.y>' is named more than once"},
+		},
+	},
+}
+
+func TestMapEventToEffectSucceededWithoutViolations(t *testing.T) {
+	expectedEffect := effects.NewExitOkMsg(color.GreenString("3 commits verified"))
+
+	effect := MapEventToEffect(verify.Succeeded{Commits: 3, Reports: []verify.Report{}, Format: "text"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectSucceededWithViolations(t *testing.T) {
+	expectedEffect := effects.NewExitErrOutput(
+		color.New(color.FgBlue).Add(color.Bold).Sprint("22222222 malformed") +
+			color.WhiteString("\n─ [malformed] not a valid coauthor: foo") +
+			color.WhiteString("\n─ [duplicate] co-author 'A <a@x.y>' is named more than once") +
+			"\n\n" +
+			color.RedString("1 of 1 commit violates the co-author rules"),
+	)
+
+	effect := MapEventToEffect(verify.Succeeded{Commits: 1, Reports: reports, Format: "text"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectSucceededAsJSON(t *testing.T) {
+	cases := []struct {
+		reports        []verify.Report
+		expectedEffect effects.Effect
+	}{
+		{[]verify.Report{}, effects.NewExitOkMsg(`{"commits":2,"violations":[]}`)},
+		{reports, effects.NewExitErrOutput(`{"commits":2,"violations":[{"commit":"2222222222222222222222222222222222222222","subject":"malformed","violations":[{"kind":"malformed","line":"Co-authored-by: foo","message":"not a valid coauthor: foo"},{"kind":"duplicate","line":"Co-authored-by: A <a@x.y>","message":"co-author 'A <a@x.y>' is named more than once"}]}]}`)},
+	}
+
+	for _, caseLoopVar := range cases {
+		effect := MapEventToEffect(verify.Succeeded{Commits: 2, Reports: caseLoopVar.reports, Format: "json"})
+
+		if !reflect.DeepEqual(caseLoopVar.expectedEffect, effect) {
+			t.Errorf("expected: %s, got: %s", caseLoopVar.expectedEffect, effect)
+			t.Fail()
+		}
+	}
+}
+
+func TestMapEventToEffectFailed(t *testing.T) {
+	err := errors.New("failed to verify the commits: not inside a git repository")
+
+	expectedEffect := effects.NewExitErrMsg(err)
+
+	effect := MapEventToEffect(verify.Failed{Reason: err})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
@@ -0,0 +1,41 @@
+package verify
+
+// Kind the kind of rule a co-author trailer violates
+type Kind string
+
+const (
+	// Malformed the co-author is not of the shape "Name <email>"
+	Malformed Kind = "malformed"
+	// Domain the email of the co-author is outside of the allowed domains
+	Domain Kind = "domain"
+	// Unknown the co-author is not among the assignments
+	Unknown Kind = "unknown"
+	// Duplicate the co-author is named more than once
+	Duplicate Kind = "duplicate"
+)
+
+// Violation a co-author trailer line violating a rule
+type Violation struct {
+	Kind    Kind
+	Line    string
+	Message string
+}
+
+// Report the violations of a single commit
+type Report struct {
+	Hash       string
+	Subject    string
+	Violations []Violation
+}
+
+// Succeeded the commits have been verified, Reports lists those with violations
+type Succeeded struct {
+	Commits int
+	Reports []Report
+	Format  string
+}
+
+// Failed failed to verify the commits
+type Failed struct {
+	Reason error
+}
@@ -0,0 +1,276 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hekmekk/git-team/src/core/events"
+	activation "github.com/hekmekk/git-team/src/shared/activation/interface"
+	config "github.com/hekmekk/git-team/src/shared/config/interface"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/interface"
+	"github.com/hekmekk/git-team/src/shared/history"
+	"github.com/hekmekk/git-team/src/shared/roster"
+	"github.com/hekmekk/git-team/src/shared/trailer"
+)
+
+// Formats the supported output formats
+var Formats = []string{"text", "json"}
+
+// Dependencies the dependencies of the verify Policy module
+type Dependencies struct {
+	SanityCheckCoauthor func(string) error
+	ConfigReader        config.Reader
+	GitConfigReader     gitconfig.Reader
+	ActivationValidator activation.Validator
+	Log                 func(args ...string) ([]history.Commit, error)
+}
+
+// Request the commits to verify, the rules to apply and how to render the result
+type Request struct {
+	Range          *string
+	AllowedDomains *[]string
+	Strict         *bool
+	Format         *string
+}
+
+// Policy the policy to apply
+type Policy struct {
+	Deps Dependencies
+	Req  Request
+}
+
+// Apply check the co-author trailers of the commits in the range
+func (policy Policy) Apply() events.Event {
+	deps := policy.Deps
+	req := policy.Req
+
+	format := *req.Format
+	if format == "" {
+		format = Formats[0]
+	}
+
+	if !isOneOf(format, Formats) {
+		return Failed{Reason: fmt.Errorf("unknown format '%s', expected one of: %s", format, strings.Join(Formats, ", "))}
+	}
+
+	revisionRange := *req.Range
+	if revisionRange == "" {
+		return Failed{Reason: errors.New("the range of commits to verify is missing, e.g. origin/main..HEAD")}
+	}
+
+	if !deps.ActivationValidator.IsInsideAGitRepository() {
+		return Failed{Reason: errors.New("failed to verify the commits: not inside a git repository")}
+	}
+
+	cfg, err := deps.ConfigReader.Read()
+	if err != nil {
+		return Failed{Reason: fmt.Errorf("failed to read config: %s", err)}
+	}
+
+	var knownPeople *roster.Roster
+	if *req.Strict {
+		loaded, err := roster.Load(deps.GitConfigReader)
+		if err != nil {
+			return Failed{Reason: fmt.Errorf("failed to retrieve assignments: %s", err)}
+		}
+		knownPeople = &loaded
+	}
+
+	commits, err := deps.Log(revisionRange, "--")
+	if err != nil {
+		return Failed{Reason: err}
+	}
+
+	rules := rules{
+		sanityCheckCoauthor: deps.SanityCheckCoauthor,
+		formats:             trailerFormats(cfg.Trailer()),
+		allowedDomains:      normalizeDomains(*req.AllowedDomains),
+		knownPeople:         knownPeople,
+	}
+
+	reports := []Report{}
+	for _, commit := range commits {
+		if violations := rules.check(commit.Message); len(violations) > 0 {
+			reports = append(reports, Report{Hash: commit.Hash, Subject: subject(commit.Message), Violations: violations})
+		}
+	}
+
+	return Succeeded{Commits: len(commits), Reports: reports, Format: format}
+}
+
+type rules struct {
+	sanityCheckCoauthor func(string) error
+	formats             []trailer.Format
+	allowedDomains      []string
+	knownPeople         *roster.Roster
+}
+
+// check every co-author line of the message, wherever it is. Git would not recognize co-author lines outside of the trailer block, and a malformed line may keep git from recognizing the block at all.
+func (rules rules) check(message string) []Violation {
+	violations := []Violation{}
+	seen := make(map[string]bool)
+
+	// the subject is not a trailer
+	for _, paragraph := range paragraphs(strings.Split(message, "\n")[1:]) {
+		isTrailerShaped := rules.isTrailerShaped(paragraph)
+		for _, line := range paragraph {
+			coauthor, isCoauthorLine, err := rules.coauthorOf(line, isTrailerShaped)
+			if !isCoauthorLine {
+				continue
+			}
+
+			if err == nil {
+				err = rules.sanityCheckCoauthor(coauthor)
+			}
+
+			if err != nil {
+				violations = append(violations, Violation{Kind: Malformed, Line: line, Message: err.Error()})
+				continue
+			}
+
+			key := history.Key(coauthor)
+			if seen[key] {
+				violations = append(violations, Violation{Kind: Duplicate, Line: line, Message: fmt.Sprintf("co-author '%s' is named more than once", coauthor)})
+				continue
+			}
+			seen[key] = true
+
+			if len(rules.allowedDomains) > 0 && !isOneOf(domainOf(key), rules.allowedDomains) {
+				violations = append(violations, Violation{Kind: Domain, Line: line, Message: fmt.Sprintf("co-author '%s' is outside of the allowed domains: %s", coauthor, strings.Join(rules.allowedDomains, ", "))})
+			}
+
+			if rules.knownPeople != nil {
+				if _, isKnown := rules.knownPeople.Alias(coauthor); !isKnown {
+					violations = append(violations, Violation{Kind: Unknown, Line: line, Message: fmt.Sprintf("co-author '%s' is not among the assignments", coauthor)})
+				}
+			}
+		}
+	}
+
+	return violations
+}
+
+// coauthorOf the co-author of a line, which is a co-author line if it starts with the trailer key followed by a colon, regardless of case. Within a trailer-shaped paragraph, a line starting with the trailer key is a co-author line even without the colon, whereas elsewhere it is most likely prose. Co-author lines which do not fit the format are malformed.
+func (rules rules) coauthorOf(line string, isTrailerShaped bool) (string, bool, error) {
+	for _, format := range rules.formats {
+		prefix := format.Prefix()
+		if len(line) >= len(prefix) && strings.EqualFold(line[:len(prefix)], prefix) {
+			return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line[len(prefix):]), strings.TrimSpace(format.Suffix()))), true, nil
+		}
+	}
+
+	key, hasColon := keyOf(line)
+	if !hasColon && !isTrailerShaped {
+		return "", false, nil
+	}
+
+	if format, isCoauthorKey := rules.formatOf(key); isCoauthorKey {
+		return "", true, fmt.Errorf("'%s' is not of the shape '%s'", line, format.Line("Name <email>"))
+	}
+
+	return "", false, nil
+}
+
+// isTrailerShaped whether every line of the paragraph is a trailer, counting lines which start with the trailer key but lack the colon
+func (rules rules) isTrailerShaped(paragraph []string) bool {
+	for _, line := range paragraph {
+		key, hasColon := keyOf(line)
+		if hasColon && isToken(key) {
+			continue
+		}
+
+		if _, isCoauthorKey := rules.formatOf(key); !isCoauthorKey {
+			return false
+		}
+	}
+	return true
+}
+
+// formatOf the format whose trailer key is the given one, regardless of case
+func (rules rules) formatOf(key string) (trailer.Format, bool) {
+	for _, format := range rules.formats {
+		if key != "" && strings.EqualFold(key, format.Key) {
+			return format, true
+		}
+	}
+	return trailer.Format{}, false
+}
+
+// keyOf the key of a line, which ends at the colon or, if it is missing, at the first whitespace
+func keyOf(line string) (string, bool) {
+	end := strings.IndexAny(line, ": \t")
+	if end < 0 {
+		return line, false
+	}
+	return line[:end], line[end] == ':'
+}
+
+// isToken whether the key may be the token of a trailer, see git-interpret-trailers
+func isToken(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, r := range key {
+		if !(r == '-' || '0' <= r && r <= '9' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// paragraphs the lines split at blank lines, without trailing whitespace
+func paragraphs(lines []string) [][]string {
+	result := [][]string{}
+	paragraph := []string{}
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			if len(paragraph) > 0 {
+				result = append(result, paragraph)
+			}
+			paragraph = []string{}
+			continue
+		}
+		paragraph = append(paragraph, line)
+	}
+	if len(paragraph) > 0 {
+		result = append(result, paragraph)
+	}
+	return result
+}
+
+// trailerFormats the configured format as well as the default one, just like history.Commit.Coauthors
+func trailerFormats(format trailer.Format) []trailer.Format {
+	if format == trailer.Default() {
+		return []trailer.Format{format}
+	}
+	return []trailer.Format{format, trailer.Default()}
+}
+
+func normalizeDomains(domains []string) []string {
+	normalized := []string{}
+	for _, domain := range domains {
+		if domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@")); domain != "" {
+			normalized = append(normalized, domain)
+		}
+	}
+	return normalized
+}
+
+func domainOf(email string) string {
+	return email[strings.LastIndex(email, "@")+1:]
+}
+
+func subject(message string) string {
+	return strings.TrimSpace(strings.SplitN(message, "\n", 2)[0])
+}
+
+func isOneOf(candidate string, values []string) bool {
+	for _, value := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
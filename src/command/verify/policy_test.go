@@ -0,0 +1,503 @@
+package verify
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hekmekk/git-team/src/core/events"
+	"github.com/hekmekk/git-team/src/core/validation"
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	config "github.com/hekmekk/git-team/src/shared/config/entity/config"
+	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+	"github.com/hekmekk/git-team/src/shared/history"
+)
+
+type configReaderMock struct {
+	read func() (config.Config, error)
+}
+
+func (mock configReaderMock) Read() (config.Config, error) {
+	return mock.read()
+}
+
+type gitConfigReaderMock struct {
+	getRegexp func(gitconfigscope.Scope, string) (map[string]string, error)
+}
+
+func (mock gitConfigReaderMock) Get(scope gitconfigscope.Scope, key string) (string, error) {
+	return "", nil
+}
+
+func (mock gitConfigReaderMock) GetAll(scope gitconfigscope.Scope, key string) ([]string, error) {
+	return []string{}, nil
+}
+
+func (mock gitConfigReaderMock) GetRegexp(scope gitconfigscope.Scope, pattern string) (map[string]string, error) {
+	return mock.getRegexp(scope, pattern)
+}
+
+func (mock gitConfigReaderMock) List(scope gitconfigscope.Scope) (map[string]string, error) {
+	return nil, nil
+}
+
+type activationValidatorMock struct {
+	isInsideAGitRepository func() bool
+}
+
+func (mock activationValidatorMock) IsInsideAGitRepository() bool {
+	return mock.isInsideAGitRepository()
+}
+
+func TestVerifyShouldReportMalformedAndDuplicateCoauthors(t *testing.T) {
+	t.Parallel()
+
+	commits := []history.Commit{
+		{Hash: "1111", Message: "valid\n\nCo-authored-by: A <a@x.y>\nSigned-off-by: Me <me@x.y>\n"},
+		{Hash: "2222", Message: "malformed\n\nCo-authored-by: foo\nCo-authored-by:A <a@x.y>\n"},
+		{Hash: "3333", Message: "duplicate\n\nCo-authored-by: A <a@x.y>\nco-authored-by: Alice <A@X.Y>\n"},
+		{Hash: "4444", Message: "stranger\n\nCo-authored-by: C <c@evil.com>\n"},
+		{Hash: "5555", Message: "not a trailer\n\nCo-authored-by: foo\n\nThe end.\n"},
+	}
+
+	var logArgs []string
+
+	deps := Dependencies{
+		SanityCheckCoauthor: validation.SanityCheckCoauthor,
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			logArgs = args
+			return commits, nil
+		},
+	}
+
+	revisionRange := "origin/main..HEAD"
+	allowedDomains := []string{}
+	strict := false
+	format := ""
+	req := Request{Range: &revisionRange, AllowedDomains: &allowedDomains, Strict: &strict, Format: &format}
+
+	expectedEvent := Succeeded{
+		Commits: 5,
+		Reports: []Report{
+			{
+				Hash:    "2222",
+				Subject: "malformed",
+				Violations: []Violation{
+					{Kind: Malformed, Line: "Co-authored-by: foo", Message: "not a valid coauthor: foo"},
+					{Kind: Malformed, Line: "Co-authored-by:A <a@x.y>", Message: "'Co-authored-by:A <a@x.y>' is not of the shape 'Co-authored-by: Name <email>'"},
+				},
+			},
+			{
+				Hash:    "3333",
+				Subject: "duplicate",
+				Violations: []Violation{
+					{Kind: Duplicate, Line: "co-authored-by: Alice <A@X.Y>", Message: "co-author 'Alice <A@X.Y>' is named more than once"},
+				},
+			},
+			{
+				Hash:    "5555",
+				Subject: "not a trailer",
+				Violations: []Violation{
+					{Kind: Malformed, Line: "Co-authored-by: foo", Message: "not a valid coauthor: foo"},
+				},
+			},
+		},
+		Format: "text",
+	}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+
+	expectedLogArgs := []string{"origin/main..HEAD", "--"}
+	if !reflect.DeepEqual(expectedLogArgs, logArgs) {
+		t.Errorf("expected: %s, got: %s", expectedLogArgs, logArgs)
+		t.Fail()
+	}
+}
+
+func TestVerifyShouldReportCoauthorsOutsideOfTheAllowedDomainsAndUnknownOnes(t *testing.T) {
+	t.Parallel()
+
+	commits := []history.Commit{
+		{Hash: "1111", Message: "valid\n\nCo-authored-by: A <a@x.y>\nSigned-off-by: Me <me@x.y>\n"},
+		{Hash: "4444", Message: "stranger\n\nCo-authored-by: C <c@evil.com>\n"},
+	}
+
+	deps := Dependencies{
+		SanityCheckCoauthor: validation.SanityCheckCoauthor,
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return commits, nil
+		},
+	}
+
+	revisionRange := "origin/main..HEAD"
+	allowedDomains := []string{"@X.y"}
+	strict := true
+	format := "json"
+	req := Request{Range: &revisionRange, AllowedDomains: &allowedDomains, Strict: &strict, Format: &format}
+
+	expectedEvent := Succeeded{
+		Commits: 2,
+		Reports: []Report{
+			{
+				Hash:    "4444",
+				Subject: "stranger",
+				Violations: []Violation{
+					{Kind: Domain, Line: "Co-authored-by: C <c@evil.com>", Message: "co-author 'C <c@evil.com>' is outside of the allowed domains: x.y"},
+					{Kind: Unknown, Line: "Co-authored-by: C <c@evil.com>", Message: "co-author 'C <c@evil.com>' is not among the assignments"},
+				},
+			},
+		},
+		Format: "json",
+	}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestVerifyShouldReportCoauthorLinesWithoutColon(t *testing.T) {
+	t.Parallel()
+
+	deps := Dependencies{
+		SanityCheckCoauthor: validation.SanityCheckCoauthor,
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return []history.Commit{{Hash: "7777", Message: "no colon\n\nCo-authored-by Jane <j@x.com>\n"}}, nil
+		},
+	}
+
+	revisionRange := "origin/main..HEAD"
+	allowedDomains := []string{}
+	strict := false
+	format := "text"
+	req := Request{Range: &revisionRange, AllowedDomains: &allowedDomains, Strict: &strict, Format: &format}
+
+	expectedEvent := Succeeded{
+		Commits: 1,
+		Reports: []Report{
+			{
+				Hash:    "7777",
+				Subject: "no colon",
+				Violations: []Violation{
+					{Kind: Malformed, Line: "Co-authored-by Jane <j@x.com>", Message: "'Co-authored-by Jane <j@x.com>' is not of the shape 'Co-authored-by: Name <email>'"},
+				},
+			},
+		},
+		Format: "text",
+	}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestVerifyShouldIgnoreProseStartingWithTheTrailerKey(t *testing.T) {
+	t.Parallel()
+
+	deps := Dependencies{
+		SanityCheckCoauthor: validation.SanityCheckCoauthor,
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return []history.Commit{{Hash: "7778", Message: "collapse trailers\n\nCo-authored-by trailers are now collapsed\ninto a single paragraph.\n\nCo-authored-by: A <a@x.y>\n"}}, nil
+		},
+	}
+
+	revisionRange := "origin/main..HEAD"
+	allowedDomains := []string{}
+	strict := false
+	format := "text"
+	req := Request{Range: &revisionRange, AllowedDomains: &allowedDomains, Strict: &strict, Format: &format}
+
+	expectedEvent := Succeeded{
+		Commits: 1,
+		Reports: []Report{},
+		Format:  "text",
+	}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestVerifyShouldCheckAllCoauthorLinesOfAParagraphWithAMalformedLine(t *testing.T) {
+	t.Parallel()
+
+	deps := Dependencies{
+		SanityCheckCoauthor: validation.SanityCheckCoauthor,
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return []history.Commit{{Hash: "8888", Message: "broken block\n\nCo-authored-by: C <c@evil.com>\nthis line breaks the trailer block\nCo-authored-by: C <c@evil.com>\n"}}, nil
+		},
+	}
+
+	revisionRange := "origin/main..HEAD"
+	allowedDomains := []string{"x.y"}
+	strict := false
+	format := "text"
+	req := Request{Range: &revisionRange, AllowedDomains: &allowedDomains, Strict: &strict, Format: &format}
+
+	expectedEvent := Succeeded{
+		Commits: 1,
+		Reports: []Report{
+			{
+				Hash:    "8888",
+				Subject: "broken block",
+				Violations: []Violation{
+					{Kind: Domain, Line: "Co-authored-by: C <c@evil.com>", Message: "co-author 'C <c@evil.com>' is outside of the allowed domains: x.y"},
+					{Kind: Duplicate, Line: "Co-authored-by: C <c@evil.com>", Message: "co-author 'C <c@evil.com>' is named more than once"},
+				},
+			},
+		},
+		Format: "text",
+	}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestVerifyShouldCheckCoauthorLinesDirectlyBelowTheBody(t *testing.T) {
+	t.Parallel()
+
+	deps := Dependencies{
+		SanityCheckCoauthor: validation.SanityCheckCoauthor,
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return []history.Commit{{Hash: "9999", Message: "no blank line\n\nsome body text\nCo-authored-by: foo\nco-authored-by: B <b@evil.com>\n"}}, nil
+		},
+	}
+
+	revisionRange := "origin/main..HEAD"
+	allowedDomains := []string{"x.y"}
+	strict := true
+	format := "text"
+	req := Request{Range: &revisionRange, AllowedDomains: &allowedDomains, Strict: &strict, Format: &format}
+
+	expectedEvent := Succeeded{
+		Commits: 1,
+		Reports: []Report{
+			{
+				Hash:    "9999",
+				Subject: "no blank line",
+				Violations: []Violation{
+					{Kind: Malformed, Line: "Co-authored-by: foo", Message: "not a valid coauthor: foo"},
+					{Kind: Domain, Line: "co-authored-by: B <b@evil.com>", Message: "co-author 'B <b@evil.com>' is outside of the allowed domains: x.y"},
+					{Kind: Unknown, Line: "co-authored-by: B <b@evil.com>", Message: "co-author 'B <b@evil.com>' is not among the assignments"},
+				},
+			},
+		},
+		Format: "text",
+	}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestVerifyShouldHonorTheConfiguredTrailerKey(t *testing.T) {
+	t.Parallel()
+
+	deps := Dependencies{
+		SanityCheckCoauthor: validation.SanityCheckCoauthor,
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global, TrailerKey: "Pair"}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return []history.Commit{{Hash: "6666", Message: "pair\n\nPair: A <a@x.y>\nPair: bar\nCo-authored-by: A <a@x.y>\n"}}, nil
+		},
+	}
+
+	revisionRange := "origin/main..HEAD"
+	allowedDomains := []string{}
+	strict := false
+	format := "text"
+	req := Request{Range: &revisionRange, AllowedDomains: &allowedDomains, Strict: &strict, Format: &format}
+
+	expectedEvent := Succeeded{
+		Commits: 1,
+		Reports: []Report{
+			{
+				Hash:    "6666",
+				Subject: "pair",
+				Violations: []Violation{
+					{Kind: Malformed, Line: "Pair: bar", Message: "not a valid coauthor: bar"},
+					{Kind: Duplicate, Line: "Co-authored-by: A <a@x.y>", Message: "co-author 'A <a@x.y>' is named more than once"},
+				},
+			},
+		},
+		Format: "text",
+	}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestVerifyShouldFail(t *testing.T) {
+	t.Parallel()
+
+	insideAGitRepository := &activationValidatorMock{
+		isInsideAGitRepository: func() bool { return true },
+	}
+
+	cases := []struct {
+		name                string
+		activationValidator *activationValidatorMock
+		revisionRange       string
+		format              string
+		expectedEvent       events.Event
+	}{
+		{"unknown format", insideAGitRepository, "HEAD", "xml", Failed{Reason: errors.New("unknown format 'xml', expected one of: text, json")}},
+		{"missing range", insideAGitRepository, "", "", Failed{Reason: errors.New("the range of commits to verify is missing, e.g. origin/main..HEAD")}},
+		{"outside of a repository", &activationValidatorMock{isInsideAGitRepository: func() bool { return false }}, "HEAD", "", Failed{Reason: errors.New("failed to verify the commits: not inside a git repository")}},
+		{"git log fails", insideAGitRepository, "nope", "", Failed{Reason: errors.New("git log failed: bad revision 'nope'")}},
+	}
+
+	for _, caseLoopVar := range cases {
+		name := caseLoopVar.name
+		activationValidator := caseLoopVar.activationValidator
+		revisionRange := caseLoopVar.revisionRange
+		format := caseLoopVar.format
+		expectedEvent := caseLoopVar.expectedEvent
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			deps := Dependencies{
+				SanityCheckCoauthor: validation.SanityCheckCoauthor,
+				ConfigReader: &configReaderMock{
+					read: func() (config.Config, error) {
+						return config.Config{ActivationScope: activationscope.Global}, nil
+					},
+				},
+				GitConfigReader: &gitConfigReaderMock{
+					getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+						return map[string]string{}, nil
+					},
+				},
+				ActivationValidator: activationValidator,
+				Log: func(args ...string) ([]history.Commit, error) {
+					return nil, errors.New("git log failed: bad revision 'nope'")
+				},
+			}
+
+			allowedDomains := []string{}
+			strict := false
+			req := Request{Range: &revisionRange, AllowedDomains: &allowedDomains, Strict: &strict, Format: &format}
+
+			event := Policy{deps, req}.Apply()
+
+			if !reflect.DeepEqual(expectedEvent, event) {
+				t.Errorf("expected: %s, got: %s", expectedEvent, event)
+				t.Fail()
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package whocmdadapter
+
+import (
+	"io/ioutil"
+
+	"github.com/urfave/cli/v2"
+
+	enablecmdadapter "github.com/hekmekk/git-team/src/command/enable/cliadapter/cmd"
+	"github.com/hekmekk/git-team/src/command/who"
+	whoeventadapter "github.com/hekmekk/git-team/src/command/who/cliadapter/event"
+	"github.com/hekmekk/git-team/src/core/events"
+	activation "github.com/hekmekk/git-team/src/shared/activation/impl"
+	commandadapter "github.com/hekmekk/git-team/src/shared/cli/commandadapter"
+	configds "github.com/hekmekk/git-team/src/shared/config/datasource"
+	"github.com/hekmekk/git-team/src/shared/dryrun"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/impl"
+	"github.com/hekmekk/git-team/src/shared/gitrepo"
+	"github.com/hekmekk/git-team/src/shared/history"
+)
+
+// Command the who command
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:      "who",
+		Usage:     "Rank the code owners and the recent authors and co-authors of paths as people to pair with",
+		ArgsUsage: "<path>...",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "since", Value: "6 months ago", Usage: "Only consider commits more recent than a date"},
+			&cli.IntFlag{Name: "enable", Value: 0, Usage: "Enable the top N candidates as co-authors"},
+			dryrun.Flag(),
+		},
+		Action: func(c *cli.Context) error {
+			paths := c.Args().Slice()
+			since := c.String("since")
+			top := c.Int("enable")
+
+			enablePolicy, mapEnableEvent := enablecmdadapter.PolicyFactory(c)
+			enableCoauthors := func(coauthors []string) events.Event {
+				return enablePolicy(&coauthors).Apply()
+			}
+
+			return commandadapter.Run(policy(&paths, &since, &top, enableCoauthors), whoeventadapter.MapEventToEffectFactory(mapEnableEvent))
+		},
+	}
+}
+
+func policy(paths *[]string, since *string, top *int, enableCoauthors func([]string) events.Event) who.Policy {
+	return who.Policy{
+		Req: who.Request{
+			Paths: paths,
+			Since: since,
+			Top:   top,
+		},
+		Deps: who.Dependencies{
+			ConfigReader:            configds.NewGitconfigDataSource(gitconfig.NewDataSource()),
+			GitConfigReader:         gitconfig.NewDataSource(),
+			ActivationValidator:     activation.NewGitConfigDataSource(gitconfig.NewDataSource()),
+			GetEffectiveConfigValue: gitrepo.EffectiveConfigValue,
+			RevParse:                gitrepo.RevParse,
+			ListFiles:               gitrepo.ListFiles,
+			ReadFile:                ioutil.ReadFile,
+			Log:                     history.Log,
+			Enable:                  enableCoauthors,
+		},
+	}
+}
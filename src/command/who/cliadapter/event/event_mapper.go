@@ -0,0 +1,56 @@
+package whoeventadapter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/hekmekk/git-team/src/command/who"
+	"github.com/hekmekk/git-team/src/core/events"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+// MapEventToEffectFactory convert who events to effects for the cli, the outcome of enabling the top candidates is converted by mapEnableEvent
+func MapEventToEffectFactory(mapEnableEvent func(events.Event) effects.Effect) func(events.Event) effects.Effect {
+	return func(event events.Event) effects.Effect {
+		switch evt := event.(type) {
+		case who.Succeeded:
+			return effects.NewExitOkMsg(toString(evt.Candidates))
+		case who.Enabled:
+			return mapEnableEvent(evt.Event)
+		case who.Failed:
+			return effects.NewExitErrMsg(evt.Reason)
+		default:
+			return effects.NewExitOk()
+		}
+	}
+}
+
+func toString(candidates []who.Candidate) string {
+	if len(candidates) == 0 {
+		return color.CyanString("nobody has worked on these paths recently")
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(color.New(color.FgBlue).Add(color.Bold).Sprint("candidates"))
+
+	for _, candidate := range candidates {
+		details := []string{}
+		if candidate.CodeOwner {
+			details = append(details, "code owner")
+		}
+		switch candidate.Commits {
+		case 0:
+		case 1:
+			details = append(details, "1 commit")
+		default:
+			details = append(details, fmt.Sprintf("%d commits", candidate.Commits))
+		}
+
+		buffer.WriteString(color.WhiteString("\n─ %s (%s)", candidate.Name, strings.Join(details, ", ")))
+	}
+
+	return buffer.String()
+}
@@ -0,0 +1,75 @@
+package whoeventadapter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hekmekk/git-team/src/command/who"
+	"github.com/hekmekk/git-team/src/core/events"
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+var candidates = []who.Candidate{
+	{Name: "@org/team", CodeOwner: true},
+	{Name: "a", Coauthor: "a", CodeOwner: true, Commits: 1},
+	{Name: "B <b@x.y>", Coauthor: "B <b@x.y>", Commits: 3},
+	{Name: "c", Coauthor: "c", Commits: 2},
+}
+
+func TestMapEventToEffectSucceeded(t *testing.T) {
+	msg := "candidates\n─ @org/team (code owner)\n─ a (code owner, 1 commit)\n─ B <b@x.y> (3 commits)\n─ c (2 commits)"
+
+	expectedEffect := effects.NewExitOkMsg(msg)
+
+	effect := MapEventToEffectFactory(nil)(who.Succeeded{Candidates: candidates})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectSucceededWithoutCandidates(t *testing.T) {
+	expectedEffect := effects.NewExitOkMsg("nobody has worked on these paths recently")
+
+	effect := MapEventToEffectFactory(nil)(who.Succeeded{Candidates: []who.Candidate{}})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectEnabledShouldMapTheOutcomeOfEnable(t *testing.T) {
+	var mappedEvent events.Event
+	expectedEffect := effects.NewExitOkMsg("enabled")
+
+	effect := MapEventToEffectFactory(func(event events.Event) effects.Effect {
+		mappedEvent = event
+		return expectedEffect
+	})(who.Enabled{Coauthors: []string{"a"}, Event: "ENABLED"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+
+	if "ENABLED" != mappedEvent {
+		t.Errorf("expected: %s, got: %s", "ENABLED", mappedEvent)
+		t.Fail()
+	}
+}
+
+func TestMapEventToEffectFailed(t *testing.T) {
+	err := errors.New("failure")
+
+	expectedEffect := effects.NewExitErrMsg(err)
+
+	effect := MapEventToEffectFactory(nil)(who.Failed{Reason: err})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
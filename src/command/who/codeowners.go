@@ -0,0 +1,93 @@
+package who
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CodeownersLocations the locations of the CODEOWNERS file relative to the root of the repository, in the order GitHub and GitLab look for it
+var CodeownersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+type codeownersRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// codeowners the rules of a CODEOWNERS file, of which the last matching one applies to a file
+type codeowners struct {
+	rules []codeownersRule
+}
+
+// parseCodeowners parse a CODEOWNERS file, skipping comments and GitLab's section headers
+func parseCodeowners(content string) codeowners {
+	rules := []codeownersRule{}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+
+		if comment := strings.Index(line, " #"); comment >= 0 {
+			line = line[:comment]
+		}
+
+		fields := strings.Fields(line)
+		rules = append(rules, codeownersRule{pattern: toRegexp(fields[0]), owners: fields[1:]})
+	}
+	return codeowners{rules: rules}
+}
+
+// owners the owners of a file given relative to the root of the repository
+func (c codeowners) owners(path string) []string {
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if c.rules[i].pattern.MatchString(path) {
+			return c.rules[i].owners
+		}
+	}
+	return []string{}
+}
+
+// toRegexp translate a pattern with the semantics of .gitignore, in which a pattern matches the files below the directories it matches as well
+func toRegexp(pattern string) *regexp.Regexp {
+	directoryOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	// a pattern without a slash, apart from a trailing one, matches at any depth
+	anchor := "^"
+	if strings.HasPrefix(pattern, "/") || strings.Contains(pattern, "/") {
+		pattern = strings.TrimPrefix(pattern, "/")
+	} else {
+		anchor = "^(.*/)?"
+	}
+
+	var expression strings.Builder
+	expression.WriteString(anchor)
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expression.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expression.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expression.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expression.WriteString("[^/]")
+		default:
+			expression.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+
+	// unlike .gitignore, GitHub does not apply docs/* to the files in subdirectories of docs
+	switch {
+	case directoryOnly:
+		expression.WriteString("/.*$")
+	case strings.HasSuffix(pattern, "/*"):
+		expression.WriteString("$")
+	default:
+		expression.WriteString("(/.*)?$")
+	}
+
+	return regexp.MustCompile(expression.String())
+}
@@ -0,0 +1,64 @@
+package who
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCodeownersOwners(t *testing.T) {
+	t.Parallel()
+
+	rules := parseCodeowners(`# the default owners
+*       @global
+
+[Docs]
+docs/*  docs@x.y
+/build/ @build # inline comment
+*.go    @gophers
+/src/**/test/ @testers
+README.md
+`)
+
+	cases := []struct {
+		path           string
+		expectedOwners []string
+	}{
+		{"main.go", []string{"@gophers"}},
+		{"src/deep/main.go", []string{"@gophers"}},
+		{"docs/index.md", []string{"docs@x.y"}},
+		{"docs/nested/index.md", []string{"@global"}},
+		{"build/Dockerfile", []string{"@build"}},
+		{"src/build/Dockerfile", []string{"@global"}},
+		{"src/a/b/test/data.json", []string{"@testers"}},
+		{"src/test/data.json", []string{"@testers"}},
+		{"README.md", []string{}},
+		{"LICENSE", []string{"@global"}},
+	}
+
+	for _, caseLoopVar := range cases {
+		path := caseLoopVar.path
+		expectedOwners := caseLoopVar.expectedOwners
+
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+
+			owners := rules.owners(path)
+
+			if !reflect.DeepEqual(expectedOwners, owners) {
+				t.Errorf("expected: %s, got: %s", expectedOwners, owners)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestCodeownersOwnersWithoutRules(t *testing.T) {
+	t.Parallel()
+
+	owners := parseCodeowners("").owners("main.go")
+
+	if !reflect.DeepEqual([]string{}, owners) {
+		t.Errorf("expected: %s, got: %s", []string{}, owners)
+		t.Fail()
+	}
+}
@@ -0,0 +1,29 @@
+package who
+
+import (
+	"github.com/hekmekk/git-team/src/core/events"
+)
+
+// Candidate somebody to pair with on the paths
+type Candidate struct {
+	Name      string
+	Coauthor  string
+	CodeOwner bool
+	Commits   int
+}
+
+// Succeeded the candidates have been ranked
+type Succeeded struct {
+	Candidates []Candidate
+}
+
+// Enabled the top candidates have been enabled, Event being the outcome of enable
+type Enabled struct {
+	Coauthors []string
+	Event     events.Event
+}
+
+// Failed failed to find candidates
+type Failed struct {
+	Reason error
+}
@@ -0,0 +1,236 @@
+package who
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hekmekk/git-team/src/core/events"
+	activation "github.com/hekmekk/git-team/src/shared/activation/interface"
+	config "github.com/hekmekk/git-team/src/shared/config/interface"
+	giterror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/interface"
+	"github.com/hekmekk/git-team/src/shared/history"
+	"github.com/hekmekk/git-team/src/shared/roster"
+)
+
+// Dependencies the dependencies of the who Policy module
+type Dependencies struct {
+	ConfigReader            config.Reader
+	GitConfigReader         gitconfig.Reader
+	ActivationValidator     activation.Validator
+	GetEffectiveConfigValue func(key string) (string, error)
+	RevParse                func(args ...string) ([]string, error)
+	ListFiles               func(paths ...string) ([]string, error)
+	ReadFile                func(path string) ([]byte, error)
+	Log                     func(args ...string) ([]history.Commit, error)
+	Enable                  func(coauthors []string) events.Event
+}
+
+// Request the paths to find people for, how far to look back in the history and how many of the top candidates to enable, none if zero
+type Request struct {
+	Paths *[]string
+	Since *string
+	Top   *int
+}
+
+// Policy the policy to apply
+type Policy struct {
+	Deps Dependencies
+	Req  Request
+}
+
+// Apply rank the code owners and the recent authors and co-authors of the paths, except for the current user
+func (policy Policy) Apply() events.Event {
+	deps := policy.Deps
+	req := policy.Req
+
+	if len(*req.Paths) == 0 {
+		return Failed{Reason: errors.New("the paths to find people for are missing")}
+	}
+
+	if !deps.ActivationValidator.IsInsideAGitRepository() {
+		return Failed{Reason: errors.New("failed to find people: not inside a git repository")}
+	}
+
+	cfg, err := deps.ConfigReader.Read()
+	if err != nil {
+		return Failed{Reason: fmt.Errorf("failed to read config: %s", err)}
+	}
+
+	knownPeople, err := roster.Load(deps.GitConfigReader)
+	if err != nil {
+		return Failed{Reason: fmt.Errorf("failed to retrieve assignments: %s", err)}
+	}
+
+	currentUserEmail, err := deps.GetEffectiveConfigValue("user.email")
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return Failed{Reason: fmt.Errorf("failed to get user.email: %s", err)}
+	}
+
+	files, err := deps.ListFiles(*req.Paths...)
+	if err != nil {
+		return Failed{Reason: err}
+	}
+
+	if len(files) == 0 {
+		return Failed{Reason: fmt.Errorf("no tracked files match: %s", strings.Join(*req.Paths, ", "))}
+	}
+
+	rules, err := readCodeowners(deps)
+	if err != nil {
+		return Failed{Reason: err}
+	}
+
+	logArgs := []string{"--no-merges"}
+	if *req.Since != "" {
+		logArgs = append(logArgs, "--since="+*req.Since)
+	}
+
+	commits, err := deps.Log(append(logArgs, append([]string{"HEAD", "--"}, *req.Paths...)...)...)
+	if err != nil {
+		return Failed{Reason: err}
+	}
+
+	ranking := newRanking(knownPeople)
+	for _, commit := range commits {
+		for _, identity := range commit.People(cfg.Trailer()) {
+			ranking.addCommit(identity)
+		}
+	}
+
+	owners := []string{}
+	seen := map[string]bool{}
+	for _, file := range files {
+		for _, owner := range rules.owners(file) {
+			if !seen[owner] {
+				seen[owner] = true
+				owners = append(owners, owner)
+			}
+		}
+	}
+	for _, owner := range owners {
+		ranking.addCodeOwner(owner)
+	}
+
+	excluded := ""
+	if currentUserEmail != "" {
+		excluded = history.Key("<" + currentUserEmail + ">")
+	}
+
+	candidates := ranking.candidates(excluded)
+
+	if *req.Top <= 0 {
+		return Succeeded{Candidates: candidates}
+	}
+
+	coauthors := []string{}
+	for _, candidate := range candidates {
+		if candidate.Coauthor != "" && len(coauthors) < *req.Top {
+			coauthors = append(coauthors, candidate.Coauthor)
+		}
+	}
+
+	if len(coauthors) == 0 {
+		return Failed{Reason: errors.New("none of the candidates can be enabled, add them with 'git team assignments add'")}
+	}
+
+	return Enabled{Coauthors: coauthors, Event: deps.Enable(coauthors)}
+}
+
+// readCodeowners the rules of the first CODEOWNERS file found, none if there is no such file
+func readCodeowners(deps Dependencies) (codeowners, error) {
+	toplevel, err := deps.RevParse("--show-toplevel")
+	if err != nil {
+		return codeowners{}, err
+	}
+
+	if len(toplevel) == 0 {
+		return codeowners{}, errors.New("failed to determine the root of the repository")
+	}
+
+	for _, location := range CodeownersLocations {
+		path := filepath.Join(toplevel[0], location)
+		content, err := deps.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return codeowners{}, fmt.Errorf("failed to read %s: %s", path, err)
+		}
+		return parseCodeowners(string(content)), nil
+	}
+
+	return codeowners{}, nil
+}
+
+type ranking struct {
+	knownPeople roster.Roster
+	index       map[string]int
+	ranked      []Candidate
+	keys        []string
+}
+
+func newRanking(knownPeople roster.Roster) *ranking {
+	return &ranking{knownPeople: knownPeople, index: map[string]int{}}
+}
+
+func (r *ranking) candidate(key string, name string, coauthor string) *Candidate {
+	i, exists := r.index[key]
+	if !exists {
+		i = len(r.ranked)
+		r.index[key] = i
+		r.ranked = append(r.ranked, Candidate{Name: name, Coauthor: coauthor})
+		r.keys = append(r.keys, key)
+	}
+	return &r.ranked[i]
+}
+
+func (r *ranking) addCommit(identity string) {
+	name := r.knownPeople.Name(identity)
+	r.candidate(history.Key(identity), name, name).Commits++
+}
+
+// addCodeOwner add an owner given as email or as @user or @org/team, which is recognized if it is an alias
+func (r *ranking) addCodeOwner(owner string) {
+	if strings.HasPrefix(owner, "@") {
+		if identity, ok := r.knownPeople.Resolve(strings.TrimPrefix(owner, "@")); ok {
+			r.candidate(history.Key(identity), r.knownPeople.Name(identity), r.knownPeople.Name(identity)).CodeOwner = true
+			return
+		}
+		r.candidate(strings.ToLower(owner), owner, "").CodeOwner = true
+		return
+	}
+
+	identity := "<" + owner + ">"
+	if alias, ok := r.knownPeople.Alias(identity); ok {
+		r.candidate(history.Key(identity), alias, alias).CodeOwner = true
+		return
+	}
+	r.candidate(history.Key(identity), owner, "").CodeOwner = true
+}
+
+// candidates the candidates apart from the excluded one, code owners first, then by the number of commits
+func (r *ranking) candidates(excluded string) []Candidate {
+	candidates := []Candidate{}
+	for i, candidate := range r.ranked {
+		if r.keys[i] != excluded {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].CodeOwner != candidates[j].CodeOwner {
+			return candidates[i].CodeOwner
+		}
+		if candidates[i].Commits != candidates[j].Commits {
+			return candidates[i].Commits > candidates[j].Commits
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+
+	return candidates
+}
@@ -0,0 +1,520 @@
+package who
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hekmekk/git-team/src/core/events"
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	config "github.com/hekmekk/git-team/src/shared/config/entity/config"
+	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+	"github.com/hekmekk/git-team/src/shared/history"
+)
+
+type configReaderMock struct {
+	read func() (config.Config, error)
+}
+
+func (mock configReaderMock) Read() (config.Config, error) {
+	return mock.read()
+}
+
+type gitConfigReaderMock struct {
+	getRegexp func(gitconfigscope.Scope, string) (map[string]string, error)
+}
+
+func (mock gitConfigReaderMock) Get(scope gitconfigscope.Scope, key string) (string, error) {
+	return "", nil
+}
+
+func (mock gitConfigReaderMock) GetAll(scope gitconfigscope.Scope, key string) ([]string, error) {
+	return []string{}, nil
+}
+
+func (mock gitConfigReaderMock) GetRegexp(scope gitconfigscope.Scope, pattern string) (map[string]string, error) {
+	return mock.getRegexp(scope, pattern)
+}
+
+func (mock gitConfigReaderMock) List(scope gitconfigscope.Scope) (map[string]string, error) {
+	return nil, nil
+}
+
+type activationValidatorMock struct {
+	isInsideAGitRepository func() bool
+}
+
+func (mock activationValidatorMock) IsInsideAGitRepository() bool {
+	return mock.isInsideAGitRepository()
+}
+
+func TestWhoShouldRankTheCodeOwnersFirstFollowedByTheRecentAuthorsAndCoauthors(t *testing.T) {
+	commits := []history.Commit{
+		{Author: "Me <me@x.y>", Message: "one\n\nCo-authored-by: C <c@x.y>\n"},
+		{Author: "D <d@x.y>", Message: "two\n\nCo-authored-by: C <C@x.y>\nCo-authored-by: A <a@x.y>\n"},
+	}
+
+	var logArgs []string
+
+	files := map[string]string{"/repo/CODEOWNERS": "* @b\n*_test.go d@x.y @org/testers\n"}
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>", "team.alias.b": "B <b@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		GetEffectiveConfigValue: func(key string) (string, error) {
+			return "me@x.y", nil
+		},
+		RevParse: func(args ...string) ([]string, error) {
+			return []string{"/repo"}, nil
+		},
+		ListFiles: func(paths ...string) ([]string, error) {
+			return []string{"src/api/handler.go", "src/api/handler_test.go"}, nil
+		},
+		ReadFile: func(path string) ([]byte, error) {
+			content, exists := files[path]
+			if !exists {
+				return nil, os.ErrNotExist
+			}
+			return []byte(content), nil
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			logArgs = args
+			return commits, nil
+		},
+	}
+
+	paths := []string{"src/api"}
+	since := "1 month ago"
+	top := 0
+	req := Request{Paths: &paths, Since: &since, Top: &top}
+
+	expectedEvent := Succeeded{
+		Candidates: []Candidate{
+			{Name: "D <d@x.y>", Coauthor: "D <d@x.y>", CodeOwner: true, Commits: 1},
+			{Name: "@org/testers", CodeOwner: true},
+			{Name: "b", Coauthor: "b", CodeOwner: true},
+			{Name: "C <c@x.y>", Coauthor: "C <c@x.y>", Commits: 2},
+			{Name: "a", Coauthor: "a", Commits: 1},
+		},
+	}
+	expectedLogArgs := []string{"--no-merges", "--since=1 month ago", "HEAD", "--", "src/api"}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedLogArgs, logArgs) {
+		t.Errorf("expected: %s, got: %s", expectedLogArgs, logArgs)
+		t.Fail()
+	}
+}
+
+func TestWhoShouldEnableTheTopCandidatesWhichCanBeEnabled(t *testing.T) {
+	commits := []history.Commit{
+		{Author: "Me <me@x.y>", Message: "one\n\nCo-authored-by: C <c@x.y>\n"},
+		{Author: "D <d@x.y>", Message: "two\n\nCo-authored-by: C <C@x.y>\nCo-authored-by: A <a@x.y>\n"},
+	}
+
+	var enabledCoauthors []string
+
+	files := map[string]string{"/repo/CODEOWNERS": "* @b\n*_test.go d@x.y @org/testers\n"}
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>", "team.alias.b": "B <b@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		GetEffectiveConfigValue: func(key string) (string, error) {
+			return "me@x.y", nil
+		},
+		RevParse: func(args ...string) ([]string, error) {
+			return []string{"/repo"}, nil
+		},
+		ListFiles: func(paths ...string) ([]string, error) {
+			return []string{"src/api/handler.go", "src/api/handler_test.go"}, nil
+		},
+		ReadFile: func(path string) ([]byte, error) {
+			content, exists := files[path]
+			if !exists {
+				return nil, os.ErrNotExist
+			}
+			return []byte(content), nil
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return commits, nil
+		},
+		Enable: func(coauthors []string) events.Event {
+			enabledCoauthors = coauthors
+			return "ENABLED"
+		},
+	}
+
+	paths := []string{"src/api"}
+	since := "1 month ago"
+	top := 2
+	req := Request{Paths: &paths, Since: &since, Top: &top}
+
+	expectedCoauthors := []string{"D <d@x.y>", "b"}
+	expectedEvent := Enabled{Coauthors: expectedCoauthors, Event: "ENABLED"}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedCoauthors, enabledCoauthors) {
+		t.Errorf("expected: %s, got: %s", expectedCoauthors, enabledCoauthors)
+		t.Fail()
+	}
+}
+
+func TestWhoShouldFailToEnableWithoutCandidatesWhichCanBeEnabled(t *testing.T) {
+	files := map[string]string{"/repo/CODEOWNERS": "* @org/testers\n"}
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>", "team.alias.b": "B <b@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		GetEffectiveConfigValue: func(key string) (string, error) {
+			return "me@x.y", nil
+		},
+		RevParse: func(args ...string) ([]string, error) {
+			return []string{"/repo"}, nil
+		},
+		ListFiles: func(paths ...string) ([]string, error) {
+			return []string{"src/api/handler.go", "src/api/handler_test.go"}, nil
+		},
+		ReadFile: func(path string) ([]byte, error) {
+			content, exists := files[path]
+			if !exists {
+				return nil, os.ErrNotExist
+			}
+			return []byte(content), nil
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return []history.Commit{}, nil
+		},
+		Enable: func(coauthors []string) events.Event {
+			t.Errorf("unexpected call to enable with: %s", coauthors)
+			t.Fail()
+			return nil
+		},
+	}
+
+	paths := []string{"src/api"}
+	since := "1 month ago"
+	top := 1
+	req := Request{Paths: &paths, Since: &since, Top: &top}
+
+	expectedEvent := Failed{Reason: errors.New("none of the candidates can be enabled, add them with 'git team assignments add'")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestWhoShouldRecognizeCodeOwnersByAlias(t *testing.T) {
+	commits := []history.Commit{
+		{Author: "Me <me@x.y>", Message: "one\n\nCo-authored-by: C <c@x.y>\n"},
+		{Author: "D <d@x.y>", Message: "two\n\nCo-authored-by: C <C@x.y>\nCo-authored-by: A <a@x.y>\n"},
+	}
+
+	files := map[string]string{"/repo/.github/CODEOWNERS": "/src/ @b e@x.y\n", "/repo/CODEOWNERS": "* @ignored\n"}
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>", "team.alias.b": "B <b@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		GetEffectiveConfigValue: func(key string) (string, error) {
+			return "me@x.y", nil
+		},
+		RevParse: func(args ...string) ([]string, error) {
+			return []string{"/repo"}, nil
+		},
+		ListFiles: func(paths ...string) ([]string, error) {
+			return []string{"src/api/handler.go", "src/api/handler_test.go"}, nil
+		},
+		ReadFile: func(path string) ([]byte, error) {
+			content, exists := files[path]
+			if !exists {
+				return nil, os.ErrNotExist
+			}
+			return []byte(content), nil
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return commits, nil
+		},
+	}
+
+	paths := []string{"src/api"}
+	since := ""
+	top := 0
+	req := Request{Paths: &paths, Since: &since, Top: &top}
+
+	expectedEvent := Succeeded{
+		Candidates: []Candidate{
+			{Name: "b", Coauthor: "b", CodeOwner: true},
+			{Name: "e@x.y", CodeOwner: true},
+			{Name: "C <c@x.y>", Coauthor: "C <c@x.y>", Commits: 2},
+			{Name: "D <d@x.y>", Coauthor: "D <d@x.y>", Commits: 1},
+			{Name: "a", Coauthor: "a", Commits: 1},
+		},
+	}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestWhoShouldWorkWithoutCodeowners(t *testing.T) {
+	commits := []history.Commit{
+		{Author: "Me <me@x.y>", Message: "one\n\nCo-authored-by: C <c@x.y>\n"},
+		{Author: "D <d@x.y>", Message: "two\n\nCo-authored-by: C <C@x.y>\nCo-authored-by: A <a@x.y>\n"},
+	}
+
+	var logArgs []string
+
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>", "team.alias.b": "B <b@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		GetEffectiveConfigValue: func(key string) (string, error) {
+			return "me@x.y", nil
+		},
+		RevParse: func(args ...string) ([]string, error) {
+			return []string{"/repo"}, nil
+		},
+		ListFiles: func(paths ...string) ([]string, error) {
+			return []string{"src/api/handler.go", "src/api/handler_test.go"}, nil
+		},
+		ReadFile: func(path string) ([]byte, error) {
+			return nil, os.ErrNotExist
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			logArgs = args
+			return commits, nil
+		},
+	}
+
+	paths := []string{"src/api"}
+	since := ""
+	top := 0
+	req := Request{Paths: &paths, Since: &since, Top: &top}
+
+	expectedEvent := Succeeded{
+		Candidates: []Candidate{
+			{Name: "C <c@x.y>", Coauthor: "C <c@x.y>", Commits: 2},
+			{Name: "D <d@x.y>", Coauthor: "D <d@x.y>", Commits: 1},
+			{Name: "a", Coauthor: "a", Commits: 1},
+		},
+	}
+	expectedLogArgs := []string{"--no-merges", "HEAD", "--", "src/api"}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, event)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedLogArgs, logArgs) {
+		t.Errorf("expected: %s, got: %s", expectedLogArgs, logArgs)
+		t.Fail()
+	}
+}
+
+func TestWhoShouldFailWithoutPaths(t *testing.T) {
+	deps := Dependencies{}
+
+	paths := []string{}
+	since := ""
+	top := 0
+	req := Request{Paths: &paths, Since: &since, Top: &top}
+
+	expectedEvent := Failed{Reason: errors.New("the paths to find people for are missing")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestWhoShouldFailWithoutMatchingFiles(t *testing.T) {
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>", "team.alias.b": "B <b@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		GetEffectiveConfigValue: func(key string) (string, error) {
+			return "me@x.y", nil
+		},
+		RevParse: func(args ...string) ([]string, error) {
+			return []string{"/repo"}, nil
+		},
+		ListFiles: func(paths ...string) ([]string, error) {
+			return []string{}, nil
+		},
+		ReadFile: func(path string) ([]byte, error) {
+			return nil, os.ErrNotExist
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return []history.Commit{}, nil
+		},
+	}
+
+	paths := []string{"nope", "nada"}
+	since := ""
+	top := 0
+	req := Request{Paths: &paths, Since: &since, Top: &top}
+
+	expectedEvent := Failed{Reason: errors.New("no tracked files match: nope, nada")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestWhoShouldFailWhenTheCodeownersCannotBeRead(t *testing.T) {
+	deps := Dependencies{
+		ConfigReader: &configReaderMock{
+			read: func() (config.Config, error) {
+				return config.Config{ActivationScope: activationscope.Global}, nil
+			},
+		},
+		GitConfigReader: &gitConfigReaderMock{
+			getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+				return map[string]string{"team.alias.a": "A <a@x.y>", "team.alias.b": "B <b@x.y>"}, nil
+			},
+		},
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return true },
+		},
+		GetEffectiveConfigValue: func(key string) (string, error) {
+			return "me@x.y", nil
+		},
+		RevParse: func(args ...string) ([]string, error) {
+			return []string{"/repo"}, nil
+		},
+		ListFiles: func(paths ...string) ([]string, error) {
+			return []string{"src/api/handler.go", "src/api/handler_test.go"}, nil
+		},
+		ReadFile: func(path string) ([]byte, error) {
+			return nil, errors.New("permission denied")
+		},
+		Log: func(args ...string) ([]history.Commit, error) {
+			return []history.Commit{}, nil
+		},
+	}
+
+	paths := []string{"src/api"}
+	since := ""
+	top := 0
+	req := Request{Paths: &paths, Since: &since, Top: &top}
+
+	expectedEvent := Failed{Reason: errors.New("failed to read /repo/.github/CODEOWNERS: permission denied")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
+
+func TestWhoShouldFailOutsideOfAGitRepository(t *testing.T) {
+	deps := Dependencies{
+		ActivationValidator: &activationValidatorMock{
+			isInsideAGitRepository: func() bool { return false },
+		},
+	}
+
+	paths := []string{"src/api"}
+	since := ""
+	top := 0
+	req := Request{Paths: &paths, Since: &since, Top: &top}
+
+	expectedEvent := Failed{Reason: errors.New("failed to find people: not inside a git repository")}
+
+	event := Policy{deps, req}.Apply()
+
+	if !reflect.DeepEqual(expectedEvent, event) {
+		t.Errorf("expected: %s, got: %s", expectedEvent, event)
+		t.Fail()
+	}
+}
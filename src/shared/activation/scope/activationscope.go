@@ -8,6 +8,8 @@ const (
 	Global Scope = iota
 	// RepoLocal git team will be enabled and disabled for the current repository
 	RepoLocal
+	// Directory git team will be enabled and disabled for all repositories below the configured activation-directory
+	Directory
 	// Unknown no idea what to do with this value
 	Unknown
 )
@@ -15,9 +17,10 @@ const (
 func (scope Scope) String() string {
 	names := [...]string{
 		"global",
-		"repo-local"}
+		"repo-local",
+		"directory"}
 
-	if scope < Global || scope > RepoLocal {
+	if scope < Global || scope > Directory {
 		return "unknown"
 	}
 
@@ -31,6 +34,8 @@ func FromString(candidate string) Scope {
 		return Global
 	case "repo-local":
 		return RepoLocal
+	case "directory":
+		return Directory
 	default:
 		return Unknown
 	}
@@ -14,6 +14,7 @@ func TestFromString(t *testing.T) {
 	}{
 		{"global", Global},
 		{"repo-local", RepoLocal},
+		{"directory", Directory},
 		{"unknown", Unknown},
 		{"some other string", Unknown},
 	}
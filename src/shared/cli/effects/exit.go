@@ -75,3 +75,22 @@ func NewExitErrMsg(err error) Effect {
 		message: color.RedString(fmt.Sprintf("error: %s", err)),
 	}
 }
+
+// ExitWithOutput print a message to stdout regardless of the exit code
+type ExitWithOutput struct {
+	kind    exitType
+	message string
+}
+
+func (exit ExitWithOutput) Run() error {
+	fmt.Println(exit.message)
+	return ExitWithoutMsg{kind: exit.kind}.Run()
+}
+
+// NewExitErrOutput exit with error code after printing a message to stdout as is, e.g. a report which is to be processed further
+func NewExitErrOutput(message string) Effect {
+	return ExitWithOutput{
+		kind:    Error,
+		message: message,
+	}
+}
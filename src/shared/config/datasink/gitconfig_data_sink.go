@@ -1,7 +1,10 @@
 package datasink
 
 import (
+	"errors"
+
 	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	giterror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
 	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/interface"
 	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
 )
@@ -20,3 +23,72 @@ func NewGitconfigDataSink(gitConfigWriter gitconfig.Writer) GitconfigDataSink {
 func (ds GitconfigDataSink) SetActivationScope(scope activationscope.Scope) error {
 	return ds.GitConfigWriter.ReplaceAll(gitconfigscope.Global, "team.config.activation-scope", scope.String())
 }
+
+// SetActivationDirectory write activation-directory setting to gitconfig
+func (ds GitconfigDataSink) SetActivationDirectory(directory string) error {
+	return ds.GitConfigWriter.ReplaceAll(gitconfigscope.Global, "team.config.activation-directory", directory)
+}
+
+// SetHooksChain write hooks-chain setting to gitconfig, an empty chain resets it to the default
+func (ds GitconfigDataSink) SetHooksChain(hooksDirs []string) error {
+	if err := ds.GitConfigWriter.UnsetAll(gitconfigscope.Global, "team.config.hooks-chain"); err != nil && !errors.Is(err, giterror.ErrTryingToUnsetAnOptionWhichDoesNotExist) {
+		return err
+	}
+
+	for _, hooksDir := range hooksDirs {
+		if err := ds.GitConfigWriter.Add(gitconfigscope.Global, "team.config.hooks-chain", hooksDir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetInjectSources write inject-sources setting to gitconfig, no sources reset it to the default
+func (ds GitconfigDataSink) SetInjectSources(sources []string) error {
+	if err := ds.GitConfigWriter.UnsetAll(gitconfigscope.Global, "team.config.inject-sources"); err != nil && !errors.Is(err, giterror.ErrTryingToUnsetAnOptionWhichDoesNotExist) {
+		return err
+	}
+
+	for _, source := range sources {
+		if err := ds.GitConfigWriter.Add(gitconfigscope.Global, "team.config.inject-sources", source); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetTrailerKey write trailer-key setting to gitconfig, an empty key resets it to the default
+func (ds GitconfigDataSink) SetTrailerKey(key string) error {
+	return ds.replaceOrUnset("team.config.trailer-key", key)
+}
+
+// SetTrailerFormat write trailer-format setting to gitconfig, an empty format resets it to the default
+func (ds GitconfigDataSink) SetTrailerFormat(format string) error {
+	return ds.replaceOrUnset("team.config.trailer-format", format)
+}
+
+func (ds GitconfigDataSink) replaceOrUnset(key string, value string) error {
+	if value != "" {
+		return ds.GitConfigWriter.ReplaceAll(gitconfigscope.Global, key, value)
+	}
+
+	if err := ds.GitConfigWriter.UnsetAll(gitconfigscope.Global, key); err != nil && !errors.Is(err, giterror.ErrTryingToUnsetAnOptionWhichDoesNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// SetRequireCoauthors write require-coauthors setting to the gitconfig of the current repository, false removes it
+func (ds GitconfigDataSink) SetRequireCoauthors(required bool) error {
+	if !required {
+		if err := ds.GitConfigWriter.UnsetAll(gitconfigscope.Local, "team.config.require-coauthors"); err != nil && !errors.Is(err, giterror.ErrTryingToUnsetAnOptionWhichDoesNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	return ds.GitConfigWriter.ReplaceAll(gitconfigscope.Local, "team.config.require-coauthors", "true")
+}
@@ -6,15 +6,18 @@ import (
 	"testing"
 
 	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	gitconfigerror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
 	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
 )
 
 type gitConfigWriterMock struct {
+	add        func(scope gitconfigscope.Scope, key string, value string) error
 	replaceAll func(scope gitconfigscope.Scope, key string, value string) error
+	unsetAll   func(scope gitconfigscope.Scope, key string) error
 }
 
 func (mock gitConfigWriterMock) Add(scope gitconfigscope.Scope, key string, value string) error {
-	return nil
+	return mock.add(scope, key, value)
 }
 
 func (mock gitConfigWriterMock) ReplaceAll(scope gitconfigscope.Scope, key string, value string) error {
@@ -22,7 +25,7 @@ func (mock gitConfigWriterMock) ReplaceAll(scope gitconfigscope.Scope, key strin
 }
 
 func (mock gitConfigWriterMock) UnsetAll(scope gitconfigscope.Scope, key string) error {
-	return nil
+	return mock.unsetAll(scope, key)
 }
 
 func TestSetActivationScopeSucceeds(t *testing.T) {
@@ -68,3 +71,194 @@ func TestSetActivationScopeFails(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestSetActivationDirectorySucceeds(t *testing.T) {
+	gitConfigWriter := gitConfigWriterMock{
+		replaceAll: func(scope gitconfigscope.Scope, key string, value string) error {
+			if scope != gitconfigscope.Global {
+				return errors.New("wrong scope")
+			}
+			if key != "team.config.activation-directory" {
+				return errors.New("wrong key")
+			}
+			if value != "~/work/" {
+				return errors.New("wrong value")
+			}
+			return nil
+		},
+	}
+
+	err := NewGitconfigDataSink(gitConfigWriter).SetActivationDirectory("~/work/")
+
+	if err != nil {
+		t.Errorf("expected: no error, received: '%s'", err)
+		t.Fail()
+	}
+}
+
+func TestSetHooksChainSucceeds(t *testing.T) {
+	expectedHooksDirs := []string{".githooks", ".git/hooks"}
+
+	hooksDirs := []string{}
+	gitConfigWriter := gitConfigWriterMock{
+		unsetAll: func(scope gitconfigscope.Scope, key string) error {
+			if key != "team.config.hooks-chain" {
+				return errors.New("wrong key")
+			}
+			return gitconfigerror.ErrTryingToUnsetAnOptionWhichDoesNotExist
+		},
+		add: func(scope gitconfigscope.Scope, key string, value string) error {
+			if scope != gitconfigscope.Global {
+				return errors.New("wrong scope")
+			}
+			if key != "team.config.hooks-chain" {
+				return errors.New("wrong key")
+			}
+			hooksDirs = append(hooksDirs, value)
+			return nil
+		},
+	}
+
+	err := NewGitconfigDataSink(gitConfigWriter).SetHooksChain(expectedHooksDirs)
+
+	if err != nil {
+		t.Errorf("expected: no error, received: '%s'", err)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedHooksDirs, hooksDirs) {
+		t.Errorf("expected: %s, received: %s", expectedHooksDirs, hooksDirs)
+		t.Fail()
+	}
+}
+
+func TestSetHooksChainFailsWhenUnsetFails(t *testing.T) {
+	expectedErr := gitconfigerror.ErrConfigFileCannotBeWritten
+
+	gitConfigWriter := gitConfigWriterMock{
+		unsetAll: func(scope gitconfigscope.Scope, key string) error {
+			return gitconfigerror.ErrConfigFileCannotBeWritten
+		},
+	}
+
+	err := NewGitconfigDataSink(gitConfigWriter).SetHooksChain([]string{".githooks"})
+
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf("expected: '%s', received: '%s'", expectedErr, err)
+		t.Fail()
+	}
+}
+
+func TestSetTrailerKeySucceeds(t *testing.T) {
+	gitConfigWriter := gitConfigWriterMock{
+		replaceAll: func(scope gitconfigscope.Scope, key string, value string) error {
+			if scope != gitconfigscope.Global {
+				return errors.New("wrong scope")
+			}
+			if key != "team.config.trailer-key" {
+				return errors.New("wrong key")
+			}
+			if value != "Pair" {
+				return errors.New("wrong value")
+			}
+			return nil
+		},
+	}
+
+	err := NewGitconfigDataSink(gitConfigWriter).SetTrailerKey("Pair")
+
+	if err != nil {
+		t.Errorf("expected: no error, received: '%s'", err)
+		t.Fail()
+	}
+}
+
+func TestSetTrailerFormatUnsetsTheSettingWhenResetToDefault(t *testing.T) {
+	gitConfigWriter := gitConfigWriterMock{
+		unsetAll: func(scope gitconfigscope.Scope, key string) error {
+			if scope != gitconfigscope.Global {
+				return errors.New("wrong scope")
+			}
+			if key != "team.config.trailer-format" {
+				return errors.New("wrong key")
+			}
+			return gitconfigerror.ErrTryingToUnsetAnOptionWhichDoesNotExist
+		},
+	}
+
+	err := NewGitconfigDataSink(gitConfigWriter).SetTrailerFormat("")
+
+	if err != nil {
+		t.Errorf("expected: no error, received: '%s'", err)
+		t.Fail()
+	}
+}
+
+func TestSetInjectSourcesSucceeds(t *testing.T) {
+	expectedSources := []string{"message", "commit"}
+
+	sources := []string{}
+	gitConfigWriter := gitConfigWriterMock{
+		unsetAll: func(scope gitconfigscope.Scope, key string) error {
+			if key != "team.config.inject-sources" {
+				return errors.New("wrong key")
+			}
+			return nil
+		},
+		add: func(scope gitconfigscope.Scope, key string, value string) error {
+			if scope != gitconfigscope.Global {
+				return errors.New("wrong scope")
+			}
+			if key != "team.config.inject-sources" {
+				return errors.New("wrong key")
+			}
+			sources = append(sources, value)
+			return nil
+		},
+	}
+
+	err := NewGitconfigDataSink(gitConfigWriter).SetInjectSources(expectedSources)
+
+	if err != nil {
+		t.Errorf("expected: no error, received: '%s'", err)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedSources, sources) {
+		t.Errorf("expected: %s, received: %s", expectedSources, sources)
+		t.Fail()
+	}
+}
+
+func TestSetRequireCoauthorsSucceeds(t *testing.T) {
+	gitConfigWriter := gitConfigWriterMock{
+		replaceAll: func(scope gitconfigscope.Scope, key string, value string) error {
+			if scope != gitconfigscope.Local {
+				return errors.New("wrong scope")
+			}
+			if key != "team.config.require-coauthors" {
+				return errors.New("wrong key")
+			}
+			if value != "true" {
+				return errors.New("wrong value")
+			}
+			return nil
+		},
+		unsetAll: func(scope gitconfigscope.Scope, key string) error {
+			if scope != gitconfigscope.Local {
+				return errors.New("wrong scope")
+			}
+			if key != "team.config.require-coauthors" {
+				return errors.New("wrong key")
+			}
+			return gitconfigerror.ErrTryingToUnsetAnOptionWhichDoesNotExist
+		},
+	}
+
+	for _, required := range []bool{true, false} {
+		if err := NewGitconfigDataSink(gitConfigWriter).SetRequireCoauthors(required); err != nil {
+			t.Errorf("expected: no error, received: '%s'", err)
+			t.Fail()
+		}
+	}
+}
@@ -22,10 +22,43 @@ func NewGitconfigDataSource(gitSettingsReader gitconfig.Reader) GitconfigDataSou
 }
 
 func (ds GitconfigDataSource) Read() (config.Config, error) {
+	activationDirectory, err := ds.GitConfigReader.Get(gitconfigscope.Global, "team.config.activation-directory")
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return config.Config{}, fmt.Errorf("failed to get team.config.activation-directory: %s", err)
+	}
+
+	hooksChain, err := ds.GitConfigReader.GetAll(gitconfigscope.Global, "team.config.hooks-chain")
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return config.Config{}, fmt.Errorf("failed to get team.config.hooks-chain: %s", err)
+	}
+
+	if len(hooksChain) == 0 {
+		hooksChain = nil
+	}
+
+	injectSources, err := ds.GitConfigReader.GetAll(gitconfigscope.Global, "team.config.inject-sources")
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return config.Config{}, fmt.Errorf("failed to get team.config.inject-sources: %s", err)
+	}
+
+	if len(injectSources) == 0 {
+		injectSources = nil
+	}
+
+	trailerKey, err := ds.GitConfigReader.Get(gitconfigscope.Global, "team.config.trailer-key")
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return config.Config{}, fmt.Errorf("failed to get team.config.trailer-key: %s", err)
+	}
+
+	trailerFormat, err := ds.GitConfigReader.Get(gitconfigscope.Global, "team.config.trailer-format")
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return config.Config{}, fmt.Errorf("failed to get team.config.trailer-format: %s", err)
+	}
+
 	rawScope, err := ds.GitConfigReader.Get(gitconfigscope.Global, "team.config.activation-scope")
 
 	if err != nil && errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
-		return config.Config{ActivationScope: activationscope.Global}, nil
+		return config.Config{ActivationScope: activationscope.Global, ActivationDirectory: activationDirectory, HooksChain: hooksChain, TrailerKey: trailerKey, TrailerFormat: trailerFormat, InjectSources: injectSources}, nil
 	}
 
 	if err != nil {
@@ -38,7 +71,12 @@ func (ds GitconfigDataSource) Read() (config.Config, error) {
 	}
 
 	cfg := config.Config{
-		ActivationScope: scope,
+		ActivationScope:     scope,
+		ActivationDirectory: activationDirectory,
+		HooksChain:          hooksChain,
+		TrailerKey:          trailerKey,
+		TrailerFormat:       trailerFormat,
+		InjectSources:       injectSources,
 	}
 
 	return cfg, nil
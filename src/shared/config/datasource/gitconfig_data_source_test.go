@@ -12,7 +12,8 @@ import (
 )
 
 type gitConfigReaderMock struct {
-	get func(gitconfigscope.Scope, string) (string, error)
+	get    func(gitconfigscope.Scope, string) (string, error)
+	getAll func(gitconfigscope.Scope, string) ([]string, error)
 }
 
 func (mock gitConfigReaderMock) Get(scope gitconfigscope.Scope, key string) (string, error) {
@@ -20,7 +21,10 @@ func (mock gitConfigReaderMock) Get(scope gitconfigscope.Scope, key string) (str
 }
 
 func (mock gitConfigReaderMock) GetAll(scope gitconfigscope.Scope, key string) ([]string, error) {
-	return []string{}, nil
+	if mock.getAll == nil {
+		return []string{}, nil
+	}
+	return mock.getAll(scope, key)
 }
 
 func (mock gitConfigReaderMock) GetRegexp(scope gitconfigscope.Scope, pattern string) (map[string]string, error) {
@@ -54,6 +58,9 @@ func TestLoadSucceeds(t *testing.T) {
 					if scope != gitconfigscope.Global {
 						return "", fmt.Errorf("wrong scope: %s", scope)
 					}
+					if key == "team.config.activation-directory" || key == "team.config.trailer-key" || key == "team.config.trailer-format" {
+						return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+					}
 					if key != "team.config.activation-scope" {
 						return "", fmt.Errorf("wrong key: %s", key)
 					}
@@ -80,6 +87,9 @@ func TestLoadSucceedsWhenActivationScopeIsUnsetInGitconfig(t *testing.T) {
 			if scope != gitconfigscope.Global {
 				return "", fmt.Errorf("wrong scope: %s", scope)
 			}
+			if key == "team.config.activation-directory" || key == "team.config.trailer-key" || key == "team.config.trailer-format" {
+				return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+			}
 			if key != "team.config.activation-scope" {
 				return "", fmt.Errorf("wrong key: %s", key)
 			}
@@ -107,6 +117,9 @@ func TestLoadFailsWhenReadingFromGitconfigFails(t *testing.T) {
 			if scope != gitconfigscope.Global {
 				return "", fmt.Errorf("wrong scope: %s", scope)
 			}
+			if key == "team.config.activation-directory" || key == "team.config.trailer-key" || key == "team.config.trailer-format" {
+				return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+			}
 			if key != "team.config.activation-scope" {
 				return "", fmt.Errorf("wrong key: %s", key)
 			}
@@ -128,6 +141,9 @@ func TestLoadFailsWhenActivationScopeFromGitconfigIsUnknown(t *testing.T) {
 			if scope != gitconfigscope.Global {
 				return "", fmt.Errorf("wrong scope: %s", scope)
 			}
+			if key == "team.config.activation-directory" || key == "team.config.trailer-key" || key == "team.config.trailer-format" {
+				return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+			}
 			if key != "team.config.activation-scope" {
 				return "", fmt.Errorf("wrong key: %s", key)
 			}
@@ -141,3 +157,193 @@ func TestLoadFailsWhenActivationScopeFromGitconfigIsUnknown(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestLoadSucceedsWithActivationDirectory(t *testing.T) {
+	t.Parallel()
+
+	expectedCfg := config.Config{ActivationScope: activationscope.Directory, ActivationDirectory: "~/work/"}
+
+	gitConfigReader := gitConfigReaderMock{
+		get: func(scope gitconfigscope.Scope, key string) (string, error) {
+			if scope != gitconfigscope.Global {
+				return "", fmt.Errorf("wrong scope: %s", scope)
+			}
+			switch key {
+			case "team.config.activation-scope":
+				return "directory", nil
+			case "team.config.activation-directory":
+				return "~/work/", nil
+			case "team.config.trailer-key", "team.config.trailer-format":
+				return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+			default:
+				return "", fmt.Errorf("wrong key: %s", key)
+			}
+		},
+	}
+
+	cfg, err := NewGitconfigDataSource(gitConfigReader).Read()
+
+	if err != nil {
+		t.Errorf("expected no error, received: %s", err)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedCfg, cfg) {
+		t.Errorf("expected: %s, received %s", expectedCfg, cfg)
+		t.Fail()
+	}
+}
+
+func TestLoadFailsWhenReadingActivationDirectoryFromGitconfigFails(t *testing.T) {
+	t.Parallel()
+	gitConfigReader := gitConfigReaderMock{
+		get: func(scope gitconfigscope.Scope, key string) (string, error) {
+			if key == "team.config.activation-directory" {
+				return "", gitconfigerror.ErrConfigFileIsInvalid
+			}
+			return "global", nil
+		},
+	}
+	cfg, err := NewGitconfigDataSource(gitConfigReader).Read()
+
+	if err == nil {
+		t.Errorf("expected error, received %s", cfg)
+		t.Fail()
+	}
+}
+
+func TestLoadSucceedsWithHooksChain(t *testing.T) {
+	t.Parallel()
+
+	expectedCfg := config.Config{ActivationScope: activationscope.Global, HooksChain: []string{".githooks", ".git/hooks"}}
+
+	gitConfigReader := gitConfigReaderMock{
+		get: func(scope gitconfigscope.Scope, key string) (string, error) {
+			return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+		getAll: func(scope gitconfigscope.Scope, key string) ([]string, error) {
+			if scope != gitconfigscope.Global {
+				return nil, fmt.Errorf("wrong scope: %s", scope)
+			}
+			if key == "team.config.inject-sources" {
+				return nil, gitconfigerror.ErrSectionOrKeyIsInvalid
+			}
+			if key != "team.config.hooks-chain" {
+				return nil, fmt.Errorf("wrong key: %s", key)
+			}
+			return []string{".githooks", ".git/hooks"}, nil
+		},
+	}
+
+	cfg, err := NewGitconfigDataSource(gitConfigReader).Read()
+
+	if err != nil {
+		t.Errorf("expected no error, received: %s", err)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedCfg, cfg) {
+		t.Errorf("expected: %s, received %s", expectedCfg, cfg)
+		t.Fail()
+	}
+}
+
+func TestLoadFailsWhenReadingHooksChainFromGitconfigFails(t *testing.T) {
+	t.Parallel()
+	gitConfigReader := gitConfigReaderMock{
+		get: func(scope gitconfigscope.Scope, key string) (string, error) {
+			return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+		getAll: func(scope gitconfigscope.Scope, key string) ([]string, error) {
+			return nil, gitconfigerror.ErrConfigFileIsInvalid
+		},
+	}
+	cfg, err := NewGitconfigDataSource(gitConfigReader).Read()
+
+	if err == nil {
+		t.Errorf("expected error, received %s", cfg)
+		t.Fail()
+	}
+}
+
+func TestLoadSucceedsWithTrailerSettings(t *testing.T) {
+	t.Parallel()
+
+	expectedCfg := config.Config{ActivationScope: activationscope.Global, TrailerKey: "Pair", TrailerFormat: "{key} with {value}"}
+
+	gitConfigReader := gitConfigReaderMock{
+		get: func(scope gitconfigscope.Scope, key string) (string, error) {
+			if scope != gitconfigscope.Global {
+				return "", fmt.Errorf("wrong scope: %s", scope)
+			}
+			switch key {
+			case "team.config.trailer-key":
+				return "Pair", nil
+			case "team.config.trailer-format":
+				return "{key} with {value}", nil
+			default:
+				return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+			}
+		},
+	}
+
+	cfg, err := NewGitconfigDataSource(gitConfigReader).Read()
+
+	if err != nil {
+		t.Errorf("expected no error, received: %s", err)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedCfg, cfg) {
+		t.Errorf("expected: %s, received %s", expectedCfg, cfg)
+		t.Fail()
+	}
+}
+
+func TestLoadFailsWhenReadingTrailerKeyFromGitconfigFails(t *testing.T) {
+	t.Parallel()
+	gitConfigReader := gitConfigReaderMock{
+		get: func(scope gitconfigscope.Scope, key string) (string, error) {
+			if key == "team.config.trailer-key" {
+				return "", gitconfigerror.ErrConfigFileIsInvalid
+			}
+			return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+	}
+	cfg, err := NewGitconfigDataSource(gitConfigReader).Read()
+
+	if err == nil {
+		t.Errorf("expected error, received %s", cfg)
+		t.Fail()
+	}
+}
+
+func TestLoadSucceedsWithInjectSources(t *testing.T) {
+	t.Parallel()
+
+	expectedCfg := config.Config{ActivationScope: activationscope.Global, InjectSources: []string{"message", "commit"}}
+
+	gitConfigReader := gitConfigReaderMock{
+		get: func(scope gitconfigscope.Scope, key string) (string, error) {
+			return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+		getAll: func(scope gitconfigscope.Scope, key string) ([]string, error) {
+			if key == "team.config.inject-sources" {
+				return []string{"message", "commit"}, nil
+			}
+			return nil, gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+	}
+
+	cfg, err := NewGitconfigDataSource(gitConfigReader).Read()
+
+	if err != nil {
+		t.Errorf("expected no error, received: %s", err)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedCfg, cfg) {
+		t.Errorf("expected: %s, received %s", expectedCfg, cfg)
+		t.Fail()
+	}
+}
@@ -2,9 +2,40 @@ package entity
 
 import (
 	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	"github.com/hekmekk/git-team/src/shared/trailer"
 )
 
 // Config config for git-team
 type Config struct {
-	ActivationScope activationscope.Scope
+	ActivationScope     activationscope.Scope
+	ActivationDirectory string
+	HooksChain          []string
+	TrailerKey          string
+	TrailerFormat       string
+	InjectSources       []string
+}
+
+// CommitSources the commit message sources passed to the prepare-commit-msg hook, see githooks(5)
+var CommitSources = []string{"message", "template", "merge", "squash", "commit", "none"}
+
+// Trailer the format of co-author lines, falling back to the defaults for unset values
+func (cfg Config) Trailer() trailer.Format {
+	return trailer.NewFormat(cfg.TrailerKey, cfg.TrailerFormat)
+}
+
+// DefaultInjectSources the commit sources which receive the co-authors unless configured otherwise
+var DefaultInjectSources = []string{"message", "merge", "squash"}
+
+// Injects whether the co-authors are to be added to commit messages of the given source, falling back to the defaults if nothing has been configured
+func (cfg Config) Injects(commitSource string) bool {
+	injectSources := cfg.InjectSources
+	if len(injectSources) == 0 {
+		injectSources = DefaultInjectSources
+	}
+	for _, injectSource := range injectSources {
+		if injectSource == commitSource {
+			return true
+		}
+	}
+	return false
 }
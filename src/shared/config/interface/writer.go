@@ -7,4 +7,10 @@ import (
 // Writer write a single property
 type Writer interface {
 	SetActivationScope(scope activationscope.Scope) error
+	SetActivationDirectory(directory string) error
+	SetHooksChain(hooksDirs []string) error
+	SetTrailerKey(key string) error
+	SetTrailerFormat(format string) error
+	SetInjectSources(sources []string) error
+	SetRequireCoauthors(required bool) error
 }
@@ -0,0 +1,38 @@
+package displaced
+
+// Setting a gitconfig setting which is overridden by git-team while it is enabled
+type Setting struct {
+	Key       string
+	BackupKey string
+}
+
+var (
+	// HooksPath the hooks directory, i.e. core.hooksPath
+	HooksPath = Setting{Key: "core.hooksPath", BackupKey: "team.displaced.hooks-path"}
+	// CommitTemplate the commit message template, i.e. commit.template
+	CommitTemplate = Setting{Key: "commit.template", BackupKey: "team.displaced.commit-template"}
+)
+
+// Settings all settings which are overridden by git-team
+var Settings = []Setting{HooksPath, CommitTemplate}
+
+// BackupKeyPattern the pattern matching the keys under which displaced values are backed up
+const BackupKeyPattern = "^team\\.displaced\\."
+
+// FromBackups map backed up values (backup key -> value) to the settings they belong to (key -> value)
+func FromBackups(backups map[string]string) map[string]string {
+	var values map[string]string
+
+	for _, setting := range Settings {
+		value, ok := backups[setting.BackupKey]
+		if !ok {
+			continue
+		}
+		if values == nil {
+			values = make(map[string]string)
+		}
+		values[setting.Key] = value
+	}
+
+	return values
+}
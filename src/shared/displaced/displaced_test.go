@@ -0,0 +1,35 @@
+package displaced
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFromBackups(t *testing.T) {
+	backups := map[string]string{
+		"team.displaced.hooks-path":      "/path/to/hooks",
+		"team.displaced.commit-template": "/path/to/template",
+		"team.displaced.unknown":         "value",
+	}
+
+	expectedValues := map[string]string{
+		"core.hooksPath":  "/path/to/hooks",
+		"commit.template": "/path/to/template",
+	}
+
+	values := FromBackups(backups)
+
+	if !reflect.DeepEqual(expectedValues, values) {
+		t.Errorf("expected: %s, got: %s", expectedValues, values)
+		t.Fail()
+	}
+}
+
+func TestFromBackupsShouldReturnNilWhenThereAreNoBackups(t *testing.T) {
+	values := FromBackups(map[string]string{})
+
+	if values != nil {
+		t.Errorf("expected: nil, got: %s", values)
+		t.Fail()
+	}
+}
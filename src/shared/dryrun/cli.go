@@ -0,0 +1,47 @@
+package dryrun
+
+import (
+	"bytes"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+)
+
+// Flag the --dry-run flag shared by all commands which modify configuration or files
+func Flag() *cli.BoolFlag {
+	return &cli.BoolFlag{Name: "dry-run", Value: false, Usage: "Print the operations that would be performed without performing them"}
+}
+
+// IsRequested check whether --dry-run has been provided to the application or to the command itself
+func IsRequested(c *cli.Context) bool {
+	for _, ctx := range c.Lineage() {
+		if ctx.Bool("dry-run") {
+			return true
+		}
+	}
+	return false
+}
+
+// MapOperationsToEffect print the recorded operations
+func MapOperationsToEffect(operations []string) effects.Effect {
+	return effects.NewExitOkMsg(FormatOperations(operations))
+}
+
+// FormatOperations render the recorded operations for the cli
+func FormatOperations(operations []string) string {
+	var buffer bytes.Buffer
+
+	if len(operations) == 0 {
+		buffer.WriteString(color.New(color.FgBlue).Add(color.Bold).Sprint("dry-run: no operations"))
+		return buffer.String()
+	}
+
+	buffer.WriteString(color.New(color.FgBlue).Add(color.Bold).Sprint("dry-run: operations"))
+	for _, operation := range operations {
+		buffer.WriteString(color.WhiteString("\n─ %s", operation))
+	}
+
+	return buffer.String()
+}
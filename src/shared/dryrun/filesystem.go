@@ -0,0 +1,51 @@
+package dryrun
+
+import (
+	"os"
+)
+
+// FileSystem record file system modifications instead of applying them
+type FileSystem struct {
+	Recorder Recorder
+}
+
+// NewFileSystem construct a new FileSystem
+func NewFileSystem(recorder Recorder) FileSystem {
+	return FileSystem{Recorder: recorder}
+}
+
+// MkdirAll record the creation of a directory along with its parents
+func (fs FileSystem) MkdirAll(path string, perm os.FileMode) error {
+	fs.Recorder.record("mkdir -p %s", path)
+	return nil
+}
+
+// WriteFile record writing data to a file
+func (fs FileSystem) WriteFile(path string, data []byte, perm os.FileMode) error {
+	fs.Recorder.record("write %s (mode %04o, %d bytes)", path, perm, len(data))
+	return nil
+}
+
+// Remove record the removal of a file or an empty directory
+func (fs FileSystem) Remove(path string) error {
+	fs.Recorder.record("rm %s", path)
+	return nil
+}
+
+// RemoveAll record the removal of a path along with all its children
+func (fs FileSystem) RemoveAll(path string) error {
+	fs.Recorder.record("rm -r %s", path)
+	return nil
+}
+
+// Symlink record the creation of a symbolic link
+func (fs FileSystem) Symlink(realPath string, linkPath string) error {
+	fs.Recorder.record("ln -s %s %s", realPath, linkPath)
+	return nil
+}
+
+// AppendFile record appending data to a file
+func (fs FileSystem) AppendFile(path string, data []byte, perm os.FileMode) error {
+	fs.Recorder.record("append %s (%d bytes)", path, len(data))
+	return nil
+}
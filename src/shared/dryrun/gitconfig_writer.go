@@ -0,0 +1,44 @@
+package dryrun
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+)
+
+// GitConfigWriter record modifications of git configuration settings instead of applying them
+type GitConfigWriter struct {
+	Recorder Recorder
+	HomeDir  string
+}
+
+// NewGitConfigWriter construct a new GitConfigWriter, the home directory locating the gitconfig file of the directory scope
+func NewGitConfigWriter(recorder Recorder, homeDir string) GitConfigWriter {
+	return GitConfigWriter{Recorder: recorder, HomeDir: homeDir}
+}
+
+// Add record git config --<scope> --add <key> <value>
+func (writer GitConfigWriter) Add(scope scope.Scope, key string, value string) error {
+	writer.Recorder.record("git config %s --add %s %s", scope.Flag(writer.HomeDir), key, quote(value))
+	return nil
+}
+
+// ReplaceAll record git config --<scope> --replace-all <key> <value>
+func (writer GitConfigWriter) ReplaceAll(scope scope.Scope, key string, value string) error {
+	writer.Recorder.record("git config %s --replace-all %s %s", scope.Flag(writer.HomeDir), key, quote(value))
+	return nil
+}
+
+// UnsetAll record git config --<scope> --unset-all <key>
+func (writer GitConfigWriter) UnsetAll(scope scope.Scope, key string) error {
+	writer.Recorder.record("git config %s --unset-all %s", scope.Flag(writer.HomeDir), key)
+	return nil
+}
+
+func quote(value string) string {
+	if strings.ContainsAny(value, " \t<>\"'") {
+		return fmt.Sprintf("%q", value)
+	}
+	return value
+}
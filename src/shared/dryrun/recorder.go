@@ -0,0 +1,24 @@
+package dryrun
+
+import (
+	"fmt"
+)
+
+// Recorder keeps track of the operations a command would have performed
+type Recorder struct {
+	operations *[]string
+}
+
+// NewRecorder construct a new Recorder without any operations
+func NewRecorder() Recorder {
+	return Recorder{operations: &[]string{}}
+}
+
+// Operations the recorded operations in the order in which they have been issued
+func (recorder Recorder) Operations() []string {
+	return append([]string{}, *recorder.operations...)
+}
+
+func (recorder Recorder) record(format string, args ...interface{}) {
+	*recorder.operations = append(*recorder.operations, fmt.Sprintf(format, args...))
+}
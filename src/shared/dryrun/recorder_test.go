@@ -0,0 +1,65 @@
+package dryrun
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hekmekk/git-team/src/shared/cli/effects"
+	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+)
+
+func TestRecordOperationsInOrder(t *testing.T) {
+	recorder := NewRecorder()
+
+	fs := NewFileSystem(recorder)
+	gitConfigWriter := NewGitConfigWriter(recorder, "/home/some-user")
+
+	fs.MkdirAll("/path/to/hooks", 0755)
+	fs.WriteFile("/path/to/hooks/proxy.sh", []byte("#!/bin/sh"), 0755)
+	fs.Remove("/path/to/hooks/commit-msg")
+	fs.Symlink("proxy.sh", "/path/to/hooks/commit-msg")
+	gitConfigWriter.ReplaceAll(gitconfigscope.Global, "core.hooksPath", "/path/to/hooks")
+	gitConfigWriter.UnsetAll(gitconfigscope.Local, "team.state.active-coauthors")
+	gitConfigWriter.Add(gitconfigscope.Local, "team.state.active-coauthors", "Mr. Noujz <noujz@mr.se>")
+	fs.RemoveAll("/path/to/commit-templates/repo-local/abc")
+
+	expectedOperations := []string{
+		"mkdir -p /path/to/hooks",
+		"write /path/to/hooks/proxy.sh (mode 0755, 9 bytes)",
+		"rm /path/to/hooks/commit-msg",
+		"ln -s proxy.sh /path/to/hooks/commit-msg",
+		"git config --global --replace-all core.hooksPath /path/to/hooks",
+		"git config --local --unset-all team.state.active-coauthors",
+		"git config --local --add team.state.active-coauthors \"Mr. Noujz <noujz@mr.se>\"",
+		"rm -r /path/to/commit-templates/repo-local/abc",
+	}
+
+	operations := recorder.Operations()
+
+	if !reflect.DeepEqual(expectedOperations, operations) {
+		t.Errorf("expected: %s, got: %s", expectedOperations, operations)
+		t.Fail()
+	}
+}
+
+func TestMapOperationsToEffect(t *testing.T) {
+	expectedEffect := effects.NewExitOkMsg("dry-run: operations\n─ mkdir -p /path/to/hooks\n─ rm /path/to/hooks/commit-msg")
+
+	effect := MapOperationsToEffect([]string{"mkdir -p /path/to/hooks", "rm /path/to/hooks/commit-msg"})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
+
+func TestMapNoOperationsToEffect(t *testing.T) {
+	expectedEffect := effects.NewExitOkMsg("dry-run: no operations")
+
+	effect := MapOperationsToEffect([]string{})
+
+	if !reflect.DeepEqual(expectedEffect, effect) {
+		t.Errorf("expected: %s, got: %s", expectedEffect, effect)
+		t.Fail()
+	}
+}
@@ -1,6 +1,7 @@
 package gitconfig
 
 import (
+	"os"
 	"os/exec"
 	"regexp"
 	"strings"
@@ -98,6 +99,42 @@ func list(exec func(scope.Scope, ...string) ([]string, error)) func(scope.Scope)
 	}
 }
 
+// Entry a setting along with the scope and the origin it has been read from
+type Entry struct {
+	Scope  string
+	Origin string
+	Key    string
+	Value  string
+}
+
+// GetRegexpOfAllScopes git config --show-scope --show-origin --null --get-regexp <pattern>
+func GetRegexpOfAllScopes(pattern string) ([]Entry, error) {
+	cmd := exec.Command("/usr/bin/env", "git", "config", "--show-scope", "--show-origin", "--null", "--get-regexp", pattern)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, gitconfigerror.New(err)
+	}
+
+	return parseEntries(string(out)), nil
+}
+
+// parseEntries split the NUL separated output of --show-scope --show-origin --null into its entries, in the order of precedence
+func parseEntries(out string) []Entry {
+	fields := strings.Split(strings.TrimSuffix(out, "\x00"), "\x00")
+
+	entries := []Entry{}
+	for i := 0; i+2 < len(fields); i += 3 {
+		keyAndValue := strings.SplitN(fields[i+2], "\n", 2)
+		entry := Entry{Scope: fields[i], Origin: fields[i+1], Key: keyAndValue[0]}
+		if len(keyAndValue) == 2 {
+			entry.Value = keyAndValue[1]
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
 // execute /usr/bin/env git config --<scope> <options>
 func execGitConfig(scope scope.Scope, options ...string) ([]string, error) {
 	gitConfigCommand := func(additionalOptions ...string) ([]byte, error) {
@@ -106,12 +143,12 @@ func execGitConfig(scope scope.Scope, options ...string) ([]string, error) {
 		return out, gitconfigerror.New(err)
 	}
 
-	return execGitConfigFactory(gitConfigCommand)(scope, options...)
+	return execGitConfigFactory(gitConfigCommand, os.Getenv)(scope, options...)
 }
 
-func execGitConfigFactory(cmd func(...string) ([]byte, error)) func(scope.Scope, ...string) ([]string, error) {
+func execGitConfigFactory(cmd func(...string) ([]byte, error), getEnv func(string) string) func(scope.Scope, ...string) ([]string, error) {
 	return func(scope scope.Scope, args ...string) ([]string, error) {
-		gitArgs := append([]string{scope.Flag()}, args...)
+		gitArgs := append([]string{scope.Flag(getEnv("HOME"))}, args...)
 
 		out, err := cmd(gitArgs...)
 
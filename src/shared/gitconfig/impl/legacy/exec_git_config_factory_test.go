@@ -9,6 +9,8 @@ import (
 	scope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
 )
 
+var getEnv = func(string) string { return "/home/some-user" }
+
 func TestShouldExecuteGitConfigWithTheExpectedCommandLineArguments(t *testing.T) {
 	t.Parallel()
 
@@ -18,6 +20,7 @@ func TestShouldExecuteGitConfigWithTheExpectedCommandLineArguments(t *testing.T)
 	}{
 		{scope.Global, "--global"},
 		{scope.Local, "--local"},
+		{scope.Directory, "--file=/home/some-user/.git-team/directory.gitconfig"},
 	}
 
 	for _, caseLoopVar := range cases {
@@ -37,7 +40,7 @@ func TestShouldExecuteGitConfigWithTheExpectedCommandLineArguments(t *testing.T)
 				return nil, nil
 			}
 
-			execGitConfigFactory(executor)(scope, providedOptions...)
+			execGitConfigFactory(executor, getEnv)(scope, providedOptions...)
 		})
 	}
 }
@@ -50,7 +53,7 @@ func TestShouldReturnTheTwoLines(t *testing.T) {
 		return out, nil
 	}
 
-	lines, err := execGitConfigFactory(executor)(scope.Global, "")
+	lines, err := execGitConfigFactory(executor, getEnv)(scope.Global, "")
 	if err != nil {
 		t.Error(err)
 		t.Fail()
@@ -69,7 +72,7 @@ func TestShouldReturnNoLines(t *testing.T) {
 		return []byte(""), nil
 	}
 
-	lines, err := execGitConfigFactory(executor)(scope.Global, "")
+	lines, err := execGitConfigFactory(executor, getEnv)(scope.Global, "")
 	if err != nil {
 		t.Error(err)
 		t.Fail()
@@ -88,7 +91,7 @@ func TestShouldReturnAnError(t *testing.T) {
 		return nil, errors.New("executing the git config command failed")
 	}
 
-	lines, err := execGitConfigFactory(executor)(scope.Global, "")
+	lines, err := execGitConfigFactory(executor, getEnv)(scope.Global, "")
 	if err == nil {
 		t.Error("expected an error")
 		t.Fail()
@@ -0,0 +1,49 @@
+package gitconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseEntriesShouldSplitScopeOriginKeyAndValue(t *testing.T) {
+	out := "global\x00file:/home/some-user/.gitconfig\x00team.state.active-coauthors\nA <a@x.y>\x00" +
+		"local\x00file:.git/config\x00core.commentchar\n;\x00" +
+		"local\x00file:.git/config\x00team.flag\x00"
+
+	expectedEntries := []Entry{
+		{Scope: "global", Origin: "file:/home/some-user/.gitconfig", Key: "team.state.active-coauthors", Value: "A <a@x.y>"},
+		{Scope: "local", Origin: "file:.git/config", Key: "core.commentchar", Value: ";"},
+		{Scope: "local", Origin: "file:.git/config", Key: "team.flag", Value: ""},
+	}
+
+	entries := parseEntries(out)
+
+	if !reflect.DeepEqual(expectedEntries, entries) {
+		t.Errorf("expected: %s, got: %s", expectedEntries, entries)
+		t.Fail()
+	}
+}
+
+func TestParseEntriesShouldKeepMultiLineValues(t *testing.T) {
+	out := "global\x00file:/home/some-user/.gitconfig\x00team.config.trailer-format\nline 1\nline 2\x00"
+
+	expectedEntries := []Entry{
+		{Scope: "global", Origin: "file:/home/some-user/.gitconfig", Key: "team.config.trailer-format", Value: "line 1\nline 2"},
+	}
+
+	entries := parseEntries(out)
+
+	if !reflect.DeepEqual(expectedEntries, entries) {
+		t.Errorf("expected: %s, got: %s", expectedEntries, entries)
+		t.Fail()
+	}
+}
+
+func TestParseEntriesShouldReturnNoEntriesForAnEmptyOutput(t *testing.T) {
+	entries := parseEntries("")
+
+	if len(entries) != 0 {
+		t.Errorf("expected no entries, got: %s", entries)
+		t.Fail()
+	}
+}
@@ -0,0 +1,132 @@
+package gitconfigimpl
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	gitconfigerror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
+	gitconfiglegacy "github.com/hekmekk/git-team/src/shared/gitconfig/impl/legacy"
+	scope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+)
+
+// Snapshot read data from the settings matching a pattern, which have been read from all scopes with a single git config call
+type Snapshot struct {
+	entries []gitconfiglegacy.Entry
+	homeDir string
+}
+
+// NewSnapshot read the settings matching the pattern from all scopes, the Directory scope's file being located within homeDir
+func NewSnapshot(pattern string, homeDir string) (Snapshot, error) {
+	entries, err := gitconfiglegacy.GetRegexpOfAllScopes(pattern)
+	if err != nil && !errors.Is(err, gitconfigerror.ErrSectionOrKeyIsInvalid) {
+		return Snapshot{}, err
+	}
+
+	return newSnapshot(entries, homeDir), nil
+}
+
+func newSnapshot(entries []gitconfiglegacy.Entry, homeDir string) Snapshot {
+	return Snapshot{entries: entries, homeDir: homeDir}
+}
+
+// Get read the last value for a key
+func (snapshot Snapshot) Get(scope scope.Scope, key string) (string, error) {
+	values, err := snapshot.GetAll(scope, key)
+	if err != nil {
+		return "", err
+	}
+
+	return values[len(values)-1], nil
+}
+
+// GetAll read all values for a key
+func (snapshot Snapshot) GetAll(scope scope.Scope, key string) ([]string, error) {
+	key = canonicalKey(key)
+
+	values := []string{}
+	for _, entry := range snapshot.entries {
+		if entry.Key == key && snapshot.isOf(scope, entry) {
+			values = append(values, entry.Value)
+		}
+	}
+
+	if len(values) == 0 {
+		return nil, gitconfigerror.ErrSectionOrKeyIsInvalid
+	}
+
+	return values, nil
+}
+
+// GetRegexp read all values matching a pattern
+func (snapshot Snapshot) GetRegexp(scope scope.Scope, pattern string) (map[string]string, error) {
+	mapping := make(map[string]string, 0)
+
+	matcher, err := regexp.Compile(pattern)
+	if err != nil {
+		return mapping, gitconfigerror.ErrTryingToUseAnInvalidRegexp
+	}
+
+	for _, entry := range snapshot.entries {
+		if matcher.MatchString(entry.Key) && snapshot.isOf(scope, entry) {
+			mapping[entry.Key] = entry.Value
+		}
+	}
+
+	if len(mapping) == 0 {
+		return mapping, gitconfigerror.ErrSectionOrKeyIsInvalid
+	}
+
+	return mapping, nil
+}
+
+// List show the part of the config the snapshot has been taken of
+func (snapshot Snapshot) List(scope scope.Scope) (map[string]string, error) {
+	mapping := make(map[string]string, 0)
+
+	for _, entry := range snapshot.entries {
+		if snapshot.isOf(scope, entry) {
+			mapping[entry.Key] = entry.Value
+		}
+	}
+
+	return mapping, nil
+}
+
+// Effective read the value in effect for a key, i.e. with all scopes applied
+func (snapshot Snapshot) Effective(key string) (string, error) {
+	key = canonicalKey(key)
+
+	for i := len(snapshot.entries) - 1; i >= 0; i-- {
+		if snapshot.entries[i].Key == key {
+			return snapshot.entries[i].Value, nil
+		}
+	}
+
+	return "", gitconfigerror.ErrSectionOrKeyIsInvalid
+}
+
+// the Directory scope's file is included by the global config, hence git reports its settings with the global scope
+func (snapshot Snapshot) isOf(gitConfigScope scope.Scope, entry gitconfiglegacy.Entry) bool {
+	isDirectory := entry.Origin == "file:"+scope.DirectoryConfigFile(snapshot.homeDir)
+
+	switch gitConfigScope {
+	case scope.Directory:
+		return isDirectory
+	case scope.Local:
+		return entry.Scope == "local"
+	default:
+		return entry.Scope == "global" && !isDirectory
+	}
+}
+
+// git reports section and variable names in lower case, only subsections are case sensitive
+func canonicalKey(key string) string {
+	first := strings.Index(key, ".")
+	last := strings.LastIndex(key, ".")
+	if first < 0 {
+		return strings.ToLower(key)
+	}
+
+	return strings.ToLower(key[:first]) + key[first:last] + strings.ToLower(key[last:])
+}
@@ -0,0 +1,90 @@
+package gitconfigimpl
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	gitconfigerror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
+	gitconfiglegacy "github.com/hekmekk/git-team/src/shared/gitconfig/impl/legacy"
+	scope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+)
+
+var snapshot = newSnapshot([]gitconfiglegacy.Entry{
+	{Scope: "system", Origin: "file:/etc/gitconfig", Key: "core.commentchar", Value: "%"},
+	{Scope: "global", Origin: "file:/home/some-user/.gitconfig", Key: "team.config.activation-scope", Value: "directory"},
+	{Scope: "global", Origin: "file:/home/some-user/.gitconfig", Key: "team.state.status", Value: "disabled"},
+	{Scope: "global", Origin: "file:/home/some-user/.git-team/directory.gitconfig", Key: "team.state.status", Value: "enabled"},
+	{Scope: "global", Origin: "file:/home/some-user/.git-team/directory.gitconfig", Key: "team.state.active-coauthors", Value: "A <a@x.y>"},
+	{Scope: "global", Origin: "file:/home/some-user/.git-team/directory.gitconfig", Key: "team.state.active-coauthors", Value: "B <b@x.y>"},
+	{Scope: "global", Origin: "file:/home/some-user/.git-team/directory.gitconfig", Key: "team.displaced.core.hookspath", Value: "/path/to/hooks"},
+	{Scope: "local", Origin: "file:.git/config", Key: "core.commentchar", Value: ";"},
+}, "/home/some-user")
+
+func TestSnapshotGetShouldReadTheValueOfTheRequestedScope(t *testing.T) {
+	cases := []struct {
+		scope         scope.Scope
+		expectedValue string
+	}{
+		{scope.Global, "disabled"},
+		{scope.Directory, "enabled"},
+	}
+
+	for _, caseLoopVar := range cases {
+		value, err := snapshot.Get(caseLoopVar.scope, "team.state.status")
+
+		if err != nil || caseLoopVar.expectedValue != value {
+			t.Errorf("expected: %s, got: %s (%s)", caseLoopVar.expectedValue, value, err)
+			t.Fail()
+		}
+	}
+}
+
+func TestSnapshotGetShouldFailForAMissingKey(t *testing.T) {
+	_, err := snapshot.Get(scope.Local, "team.state.status")
+
+	if !errors.Is(err, gitconfigerror.ErrSectionOrKeyIsInvalid) {
+		t.Errorf("expected: %s, got: %s", gitconfigerror.ErrSectionOrKeyIsInvalid, err)
+		t.Fail()
+	}
+}
+
+func TestSnapshotGetAllShouldReadAllValues(t *testing.T) {
+	expectedValues := []string{"A <a@x.y>", "B <b@x.y>"}
+
+	values, err := snapshot.GetAll(scope.Directory, "team.state.active-coauthors")
+
+	if err != nil || !reflect.DeepEqual(expectedValues, values) {
+		t.Errorf("expected: %s, got: %s (%s)", expectedValues, values, err)
+		t.Fail()
+	}
+}
+
+func TestSnapshotGetRegexpShouldReadTheMatchingValues(t *testing.T) {
+	expectedMapping := map[string]string{"team.displaced.core.hookspath": "/path/to/hooks"}
+
+	mapping, err := snapshot.GetRegexp(scope.Directory, "^team\\.displaced\\.")
+
+	if err != nil || !reflect.DeepEqual(expectedMapping, mapping) {
+		t.Errorf("expected: %s, got: %s (%s)", expectedMapping, mapping, err)
+		t.Fail()
+	}
+}
+
+func TestSnapshotEffectiveShouldReadTheValueOfTheHighestPrecedence(t *testing.T) {
+	value, err := snapshot.Effective("core.commentChar")
+
+	if err != nil || ";" != value {
+		t.Errorf("expected: %s, got: %s (%s)", ";", value, err)
+		t.Fail()
+	}
+}
+
+func TestSnapshotEffectiveShouldFailForAMissingKey(t *testing.T) {
+	_, err := snapshot.Effective("commit.cleanup")
+
+	if !errors.Is(err, gitconfigerror.ErrSectionOrKeyIsInvalid) {
+		t.Errorf("expected: %s, got: %s", gitconfigerror.ErrSectionOrKeyIsInvalid, err)
+		t.Fail()
+	}
+}
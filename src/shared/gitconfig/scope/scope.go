@@ -1,5 +1,11 @@
 package scope
 
+import (
+	"fmt"
+
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+)
+
 // Scope scope of gitconfig
 type Scope int
 
@@ -8,21 +14,47 @@ const (
 	Global Scope = iota
 	// Local local gitconfig
 	Local
+	// Directory dedicated gitconfig file which is included via includeIf for the configured activation-directory
+	Directory
 )
 
 func (scope Scope) String() string {
 	names := [...]string{
 		"global",
-		"local"}
+		"local",
+		"directory"}
 
 	return names[scope]
 }
 
-// Flag translate the gitconfig scope to the respective flag
-func (scope Scope) Flag() string {
+// Flag translate the gitconfig scope to the respective flag, the Directory scope's file being located within homeDir
+func (scope Scope) Flag(homeDir string) string {
 	flags := [...]string{
 		"--global",
-		"--local"}
+		"--local",
+		fmt.Sprintf("--file=%s", DirectoryConfigFile(homeDir))}
 
 	return flags[scope]
 }
+
+// DirectoryConfigFile the path to the gitconfig file backing the Directory scope
+func DirectoryConfigFile(homeDir string) string {
+	return fmt.Sprintf("%s/.git-team/directory.gitconfig", homeDir)
+}
+
+// DirectoryIncludeKey the includeIf key which pulls in the Directory scope for all repositories below activationDirectory
+func DirectoryIncludeKey(activationDirectory string) string {
+	return fmt.Sprintf("includeIf.gitdir:%s.path", activationDirectory)
+}
+
+// FromActivationScope translate the activation scope to the gitconfig scope where the respective settings live
+func FromActivationScope(activationScope activationscope.Scope) Scope {
+	switch activationScope {
+	case activationscope.RepoLocal:
+		return Local
+	case activationscope.Directory:
+		return Directory
+	default:
+		return Global
+	}
+}
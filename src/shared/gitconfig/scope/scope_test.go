@@ -3,6 +3,8 @@ package scope
 import (
 	"reflect"
 	"testing"
+
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
 )
 
 func TestFlag(t *testing.T) {
@@ -14,6 +16,7 @@ func TestFlag(t *testing.T) {
 	}{
 		{Global, "--global"},
 		{Local, "--local"},
+		{Directory, "--file=/home/some-user/.git-team/directory.gitconfig"},
 	}
 
 	for _, caseLoopVar := range cases {
@@ -22,7 +25,7 @@ func TestFlag(t *testing.T) {
 
 		t.Run(scope.String(), func(t *testing.T) {
 			t.Parallel()
-			flag := scope.Flag()
+			flag := scope.Flag("/home/some-user")
 
 			if !reflect.DeepEqual(expectedFlag, flag) {
 				t.Errorf("expected: %s, got: %s", expectedFlag, scope)
@@ -31,3 +34,31 @@ func TestFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestFromActivationScope(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		activationScope activationscope.Scope
+		expectedScope   Scope
+	}{
+		{activationscope.Global, Global},
+		{activationscope.RepoLocal, Local},
+		{activationscope.Directory, Directory},
+	}
+
+	for _, caseLoopVar := range cases {
+		activationScope := caseLoopVar.activationScope
+		expectedScope := caseLoopVar.expectedScope
+
+		t.Run(activationScope.String(), func(t *testing.T) {
+			t.Parallel()
+			scope := FromActivationScope(activationScope)
+
+			if expectedScope != scope {
+				t.Errorf("expected: %s, got: %s", expectedScope, scope)
+				t.Fail()
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package gitrepo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	gitconfigerror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
+)
+
+// CommonDir the absolute path of the git directory which is shared by all worktrees of the current repository
+func CommonDir() (string, error) {
+	return commonDir(execGitRevParse, os.Getwd)
+}
+
+func commonDir(revParse func(...string) (string, error), getWd func() (string, error)) (string, error) {
+	dir, err := revParse("--git-common-dir")
+	if err != nil {
+		return "", err
+	}
+
+	if dir == "" {
+		return "", errors.New("failed to determine the common git dir: empty output")
+	}
+
+	if filepath.IsAbs(dir) {
+		return filepath.Clean(dir), nil
+	}
+
+	// git prints the common dir relative to the current working directory unless it is located elsewhere
+	workingDir, err := getWd()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(workingDir, dir), nil
+}
+
+// ConfigValue read a single value from the local config of the repository located at gitDir
+func ConfigValue(gitDir string, key string) (string, error) {
+	cmd := exec.Command("/usr/bin/env", "git", "config", fmt.Sprintf("--file=%s", filepath.Join(gitDir, "config")), "--get", key)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", gitconfigerror.New(err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// EffectiveConfigValue read a single value from the config in effect for the current working directory, i.e. with all scopes applied
+func EffectiveConfigValue(key string) (string, error) {
+	cmd := exec.Command("/usr/bin/env", "git", "config", "--get", key)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", gitconfigerror.New(err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Output the standard output of git run with the given arguments, e.g. of git log
+func Output(args ...string) (string, error) {
+	return execGit(nil, nil, args...)
+}
+
+// RevList the commits listed by git rev-list for the given arguments, e.g. a revision range
+func RevList(args ...string) ([]string, error) {
+	out, err := execGit(nil, nil, append([]string{"rev-list"}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return nonEmptyLines(out), nil
+}
+
+// CommitMessage the raw message of a commit
+func CommitMessage(rev string) (string, error) {
+	out, err := execGit(nil, nil, "show", "--no-patch", "--format=%B", rev)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(out, "\n") + "\n", nil
+}
+
+// UpdateRef point a ref at the commit a revision resolves to, e.g. ORIG_HEAD for recovery
+func UpdateRef(ref string, rev string) error {
+	_, err := execGit(nil, nil, "update-ref", ref, rev)
+	return err
+}
+
+// AmendMessage replace the message of HEAD, leaving staged changes untouched. The git hooks as well as git-team's hook logic are skipped.
+func AmendMessage(message string) error {
+	_, err := execGit(strings.NewReader(message), []string{"GIT_TEAM_SKIP=1"}, "commit", "--amend", "--only", "--allow-empty", "--no-verify", "--cleanup=verbatim", "--file=-")
+	return err
+}
+
+// Rebase run a non-interactive git rebase with the given arguments. git-team's hook logic is skipped for the rewritten commits.
+func Rebase(args ...string) error {
+	_, err := execGit(nil, []string{"GIT_TEAM_SKIP=1"}, append([]string{"rebase"}, args...)...)
+	return err
+}
+
+// RevParse the arguments git rev-parse prints for the given arguments, one per line, e.g. with --revs-only or --no-revs
+func RevParse(args ...string) ([]string, error) {
+	out, err := execGit(nil, nil, append([]string{"rev-parse"}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return nonEmptyLines(out), nil
+}
+
+// ShowLog run git log on the terminal for exactly the given commits, in the given order
+func ShowLog(commits []string, args ...string) error {
+	cmd := exec.Command("/usr/bin/env", append([]string{"git", "log", "--no-walk=unsorted", "--stdin"}, args...)...)
+	cmd.Stdin = strings.NewReader(strings.Join(commits, "\n") + "\n")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// Blame the output of git blame --porcelain for the given arguments, e.g. a revision and a file
+func Blame(args ...string) (string, error) {
+	return execGit(nil, nil, append([]string{"blame", "--porcelain"}, args...)...)
+}
+
+// ListFiles the tracked files matching the given paths, relative to the root of the repository
+func ListFiles(paths ...string) ([]string, error) {
+	out, err := execGit(nil, nil, append([]string{"ls-files", "-z", "--full-name", "--"}, paths...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for _, file := range strings.Split(out, "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// CommitterIdentity the identity of the shape "Name <email>" new commits are committed with, honoring GIT_COMMITTER_NAME and GIT_COMMITTER_EMAIL
+func CommitterIdentity() (string, error) {
+	out, err := execGit(nil, nil, "var", "GIT_COMMITTER_IDENT")
+	if err != nil {
+		return "", err
+	}
+
+	ident := strings.TrimSpace(out)
+	if end := strings.LastIndex(ident, ">"); end >= 0 {
+		ident = ident[:end+1]
+	}
+	return ident, nil
+}
+
+// GitPath the path of a file within the git dir, e.g. of SQUASH_MSG, see git rev-parse --git-path
+func GitPath(name string) (string, error) {
+	out, err := execGit(nil, nil, "rev-parse", "--git-path", name)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(out), nil
+}
+
+// CheckMailmap the canonical identities of the given identities of the shape "Name <email>" according to the mailmap, in the same order
+func CheckMailmap(identities []string) ([]string, error) {
+	if len(identities) == 0 {
+		return []string{}, nil
+	}
+
+	out, err := execGit(strings.NewReader(strings.Join(identities, "\n")+"\n"), nil, "check-mailmap", "--stdin")
+	if err != nil {
+		return nil, err
+	}
+
+	return nonEmptyLines(out), nil
+}
+
+// execute /usr/bin/env git <args>, returning stdout or an error containing stderr
+func execGit(stdin io.Reader, env []string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("/usr/bin/env", append([]string{"git"}, args...)...)
+	cmd.Stdin = stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s failed: %s", args[0], strings.TrimSpace(stderr.String()+"\n"+stdout.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+func nonEmptyLines(out string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	return lines
+}
+
+// execute /usr/bin/env git rev-parse <options>
+func execGitRevParse(options ...string) (string, error) {
+	cmd := exec.Command("/usr/bin/env", append([]string{"git", "rev-parse"}, options...)...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse %s failed: %s", strings.Join(options, " "), strings.TrimSpace(string(out)))
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,85 @@
+package gitrepo
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCommonDir(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		revParseOutput    string
+		expectedCommonDir string
+	}{
+		{".git", "/path/to/repo/.git"},
+		{"../../.git", "/path/.git"},
+		{"/path/to/main/.git", "/path/to/main/.git"},
+		{"/path/to/main/.git/", "/path/to/main/.git"},
+	}
+
+	for _, caseLoopVar := range cases {
+		revParseOutput := caseLoopVar.revParseOutput
+		expectedCommonDir := caseLoopVar.expectedCommonDir
+
+		t.Run(revParseOutput, func(t *testing.T) {
+			t.Parallel()
+
+			revParse := func(options ...string) (string, error) {
+				if !reflect.DeepEqual([]string{"--git-common-dir"}, options) {
+					return "", errors.New("wrong options")
+				}
+				return revParseOutput, nil
+			}
+			getWd := func() (string, error) {
+				return "/path/to/repo", nil
+			}
+
+			commonDir, err := commonDir(revParse, getWd)
+
+			if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+				t.Fail()
+			}
+
+			if expectedCommonDir != commonDir {
+				t.Errorf("expected: %s, got: %s", expectedCommonDir, commonDir)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestCommonDirShouldFailWhenRevParseFails(t *testing.T) {
+	expectedErr := errors.New("not a git repository")
+
+	revParse := func(...string) (string, error) {
+		return "", expectedErr
+	}
+
+	_, err := commonDir(revParse, nil)
+
+	if expectedErr != err {
+		t.Errorf("expected: %s, got: %s", expectedErr, err)
+		t.Fail()
+	}
+}
+
+func TestCommonDirShouldFailWhenGetWdFails(t *testing.T) {
+	expectedErr := errors.New("failed to get working dir")
+
+	revParse := func(...string) (string, error) {
+		return ".git", nil
+	}
+	getWd := func() (string, error) {
+		return "", expectedErr
+	}
+
+	_, err := commonDir(revParse, getWd)
+
+	if expectedErr != err {
+		t.Errorf("expected: %s, got: %s", expectedErr, err)
+		t.Fail()
+	}
+}
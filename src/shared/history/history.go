@@ -0,0 +1,110 @@
+package history
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hekmekk/git-team/src/shared/gitrepo"
+	"github.com/hekmekk/git-team/src/shared/trailer"
+)
+
+const (
+	fieldSeparator  = "\x1f"
+	recordSeparator = "\x1e"
+	logFormat       = "--format=%H" + fieldSeparator + "%an <%ae>" + fieldSeparator + "%cn <%ce>" + fieldSeparator + "%at" + fieldSeparator + "%B" + recordSeparator
+)
+
+// Commit a commit along with the people involved in it
+type Commit struct {
+	Hash       string
+	Author     string
+	Committer  string
+	AuthorDate time.Time
+	Message    string
+}
+
+// Coauthors the co-authors named in the trailer block of the commit message, in the configured format as well as in the default one
+func (commit Commit) Coauthors(format trailer.Format) []string {
+	formats := []trailer.Format{format}
+	if format != trailer.Default() {
+		formats = append(formats, trailer.Default())
+	}
+
+	prefixes := []string{}
+	for _, f := range formats {
+		prefixes = append(prefixes, f.Prefix())
+	}
+
+	seen := map[string]bool{}
+	coauthors := []string{}
+	for _, line := range trailer.Block(commit.Message, prefixes, trailer.NewCleanup("", "verbatim")) {
+		for _, f := range formats {
+			prefix := f.Prefix()
+			if len(line) < len(prefix) || !strings.EqualFold(line[:len(prefix)], prefix) {
+				continue
+			}
+
+			coauthor := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line[len(prefix):]), strings.TrimSpace(f.Suffix())))
+			if coauthor != "" && !seen[Key(coauthor)] {
+				seen[Key(coauthor)] = true
+				coauthors = append(coauthors, coauthor)
+			}
+			break
+		}
+	}
+
+	return coauthors
+}
+
+// People the author followed by the co-authors of the commit, without duplicates
+func (commit Commit) People(format trailer.Format) []string {
+	people := []string{commit.Author}
+	for _, coauthor := range commit.Coauthors(format) {
+		if Key(coauthor) != Key(commit.Author) {
+			people = append(people, coauthor)
+		}
+	}
+	return people
+}
+
+// Key identifies a person by the lowercased email of an identity of the shape "Name <email>", falling back to the identity itself
+func Key(identity string) string {
+	start := strings.LastIndex(identity, "<")
+	end := strings.LastIndex(identity, ">")
+	if start >= 0 && end > start+1 {
+		return strings.ToLower(strings.TrimSpace(identity[start+1 : end]))
+	}
+	return strings.TrimSpace(identity)
+}
+
+// Log the commits git log lists for the given arguments, e.g. a revision range and --since
+func Log(args ...string) ([]Commit, error) {
+	out, err := gitrepo.Output(append([]string{"log", logFormat}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLog(out), nil
+}
+
+func parseLog(out string) []Commit {
+	commits := []Commit{}
+	for _, record := range strings.Split(out, recordSeparator) {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), fieldSeparator, 5)
+		if len(fields) != 5 {
+			continue
+		}
+
+		timestamp, _ := strconv.ParseInt(fields[3], 10, 64)
+
+		commits = append(commits, Commit{
+			Hash:       fields[0],
+			Author:     fields[1],
+			Committer:  fields[2],
+			AuthorDate: time.Unix(timestamp, 0),
+			Message:    fields[4],
+		})
+	}
+	return commits
+}
@@ -0,0 +1,75 @@
+package history
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hekmekk/git-team/src/shared/trailer"
+)
+
+func TestParseLog(t *testing.T) {
+	t.Parallel()
+
+	out := "abc\x1fA <a@x.y>\x1fC <c@x.y>\x1f1600000000\x1fsubject\n\nCo-authored-by: B <b@x.y>\n\x1e\ndef\x1fB <b@x.y>\x1fB <b@x.y>\x1f1600000060\x1fsolo\n\x1e\n"
+
+	expectedCommits := []Commit{
+		{Hash: "abc", Author: "A <a@x.y>", Committer: "C <c@x.y>", AuthorDate: time.Unix(1600000000, 0), Message: "subject\n\nCo-authored-by: B <b@x.y>\n"},
+		{Hash: "def", Author: "B <b@x.y>", Committer: "B <b@x.y>", AuthorDate: time.Unix(1600000060, 0), Message: "solo\n"},
+	}
+
+	commits := parseLog(out)
+
+	if !reflect.DeepEqual(expectedCommits, commits) {
+		t.Errorf("expected: %v, got: %v", expectedCommits, commits)
+		t.Fail()
+	}
+}
+
+func TestCoauthors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name              string
+		message           string
+		format            trailer.Format
+		expectedCoauthors []string
+	}{
+		{"trailer block", "subject\n\nCo-authored-by: A <a@x.y>\nSigned-off-by: S <s@x.y>\nco-authored-by: B <b@x.y>\n", trailer.Default(), []string{"A <a@x.y>", "B <b@x.y>"}},
+		{"duplicates", "subject\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: a <A@X.Y>\n", trailer.Default(), []string{"A <a@x.y>"}},
+		{"body only", "subject\n\nCo-authored-by: A <a@x.y> helped\nwith this\n", trailer.Default(), []string{}},
+		{"configured and default format", "subject\n\nPair: [A <a@x.y>]\nCo-authored-by: B <b@x.y>\n", trailer.NewFormat("Pair", "{key}: [{value}]"), []string{"A <a@x.y>", "B <b@x.y>"}},
+	}
+
+	for _, caseLoopVar := range cases {
+		message := caseLoopVar.message
+		format := caseLoopVar.format
+		expectedCoauthors := caseLoopVar.expectedCoauthors
+
+		t.Run(caseLoopVar.name, func(t *testing.T) {
+			t.Parallel()
+
+			coauthors := Commit{Message: message}.Coauthors(format)
+
+			if !reflect.DeepEqual(expectedCoauthors, coauthors) {
+				t.Errorf("expected: %s, got: %s", expectedCoauthors, coauthors)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestPeopleShouldNotRepeatTheAuthor(t *testing.T) {
+	t.Parallel()
+
+	commit := Commit{Author: "A <a@x.y>", Message: "subject\n\nCo-authored-by: A <A@x.y>\nCo-authored-by: B <b@x.y>\n"}
+
+	expectedPeople := []string{"A <a@x.y>", "B <b@x.y>"}
+
+	people := commit.People(trailer.Default())
+
+	if !reflect.DeepEqual(expectedPeople, people) {
+		t.Errorf("expected: %s, got: %s", expectedPeople, people)
+		t.Fail()
+	}
+}
@@ -0,0 +1,116 @@
+package role
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hekmekk/git-team/src/shared/trailer"
+)
+
+// Role a part somebody takes in a commit besides being a co-author, which is recorded with its own trailer
+type Role string
+
+const (
+	// Reviewer somebody who reviewed the changes while they were made
+	Reviewer Role = "reviewer"
+	// Navigator the navigator of a pair
+	Navigator Role = "navigator"
+)
+
+var trailerKeys = map[Role]string{
+	Reviewer:  "Reviewed-by",
+	Navigator: "Navigated-by",
+}
+
+// All the known roles, in alphabetical order
+func All() []Role {
+	return []Role{Navigator, Reviewer}
+}
+
+// FromString the role with the given name
+func FromString(name string) (Role, error) {
+	role := Role(name)
+	if _, isKnown := trailerKeys[role]; !isKnown {
+		return "", fmt.Errorf("unknown role '%s', expected one of: %s", name, strings.Join(names(), ", "))
+	}
+	return role, nil
+}
+
+// TrailerKey the trailer key the role is recorded with
+func (role Role) TrailerKey() string {
+	return trailerKeys[role]
+}
+
+// Assignment a role taken by an identity, which is either an alias or of the shape "Name <email>"
+type Assignment struct {
+	Role     Role
+	Identity string
+}
+
+// ParseAssignment parse an assignment of the shape "<role>=<identity>"
+func ParseAssignment(raw string) (Assignment, error) {
+	parts := strings.SplitN(raw, "=", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return Assignment{}, fmt.Errorf("role must be of the shape <role>=<identity>: '%s'", raw)
+	}
+
+	role, err := FromString(parts[0])
+	if err != nil {
+		return Assignment{}, err
+	}
+
+	return Assignment{Role: role, Identity: parts[1]}, nil
+}
+
+// String the assignment in the shape "<role>=<identity>", as it is persisted
+func (assignment Assignment) String() string {
+	return fmt.Sprintf("%s=%s", assignment.Role, assignment.Identity)
+}
+
+// Sort sort assignments by role and identity, dropping duplicates
+func Sort(assignments []Assignment) []Assignment {
+	seen := make(map[Assignment]bool)
+	sorted := []Assignment{}
+	for _, assignment := range assignments {
+		if !seen[assignment] {
+			seen[assignment] = true
+			sorted = append(sorted, assignment)
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].String() < sorted[j].String()
+	})
+
+	return sorted
+}
+
+// TrailerLines the trailer lines of the co-authors followed by those of the assignments
+func TrailerLines(coauthors []string, assignments []Assignment, format trailer.Format) []string {
+	lines := []string{}
+	for _, coauthor := range coauthors {
+		lines = append(lines, format.Line(coauthor))
+	}
+	for _, assignment := range assignments {
+		lines = append(lines, format.WithKey(assignment.Role.TrailerKey()).Line(assignment.Identity))
+	}
+	return lines
+}
+
+// TrailerPrefixes the parts of the trailer lines preceding the identity, for co-authors and each of the roles
+func TrailerPrefixes(format trailer.Format) []string {
+	prefixes := []string{format.Prefix()}
+	for _, role := range All() {
+		prefixes = append(prefixes, format.WithKey(role.TrailerKey()).Prefix())
+	}
+	return prefixes
+}
+
+func names() []string {
+	names := []string{}
+	for _, role := range All() {
+		names = append(names, string(role))
+	}
+	return names
+}
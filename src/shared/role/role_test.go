@@ -0,0 +1,85 @@
+package role
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseAssignment(t *testing.T) {
+	expectedAssignment := Assignment{Role: Reviewer, Identity: "A <a@x.y>"}
+
+	assignment, err := ParseAssignment("reviewer=A <a@x.y>")
+
+	if err != nil {
+		t.Errorf("expected no error, got: %s", err)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedAssignment, assignment) {
+		t.Errorf("expected: %s, got: %s", expectedAssignment, assignment)
+		t.Fail()
+	}
+}
+
+func TestParseAssignmentFailsForMalformedAssignments(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		raw         string
+		expectedErr error
+	}{
+		{"reviewer", errors.New("role must be of the shape <role>=<identity>: 'reviewer'")},
+		{"reviewer=", errors.New("role must be of the shape <role>=<identity>: 'reviewer='")},
+		{"tester=alice", errors.New("unknown role 'tester', expected one of: navigator, reviewer")},
+	}
+
+	for _, caseLoopVar := range cases {
+		raw := caseLoopVar.raw
+		expectedErr := caseLoopVar.expectedErr
+
+		t.Run(raw, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseAssignment(raw)
+
+			if !reflect.DeepEqual(expectedErr, err) {
+				t.Errorf("expected: %s, got: %s", expectedErr, err)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestTrailerKey(t *testing.T) {
+	expectedTrailerKey := "Reviewed-by"
+
+	trailerKey := Reviewer.TrailerKey()
+
+	if expectedTrailerKey != trailerKey {
+		t.Errorf("expected: %s, got: %s", expectedTrailerKey, trailerKey)
+		t.Fail()
+	}
+}
+
+func TestSort(t *testing.T) {
+	assignments := []Assignment{
+		{Role: Reviewer, Identity: "B <b@x.y>"},
+		{Role: Navigator, Identity: "C <c@x.y>"},
+		{Role: Reviewer, Identity: "A <a@x.y>"},
+		{Role: Reviewer, Identity: "B <b@x.y>"},
+	}
+
+	expectedAssignments := []Assignment{
+		{Role: Navigator, Identity: "C <c@x.y>"},
+		{Role: Reviewer, Identity: "A <a@x.y>"},
+		{Role: Reviewer, Identity: "B <b@x.y>"},
+	}
+
+	sorted := Sort(assignments)
+
+	if !reflect.DeepEqual(expectedAssignments, sorted) {
+		t.Errorf("expected: %s, got: %s", expectedAssignments, sorted)
+		t.Fail()
+	}
+}
@@ -0,0 +1,80 @@
+package roster
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/hekmekk/git-team/src/core/assignment"
+	giterror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
+	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/interface"
+	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+	"github.com/hekmekk/git-team/src/shared/history"
+)
+
+// Roster the assignments of aliases to co-authors, used to recognize people by their identity
+type Roster struct {
+	assignments []assignment.Assignment
+	aliasByKey  map[string]string
+}
+
+// New constructs a new Roster from the given assignments
+func New(assignments []assignment.Assignment) Roster {
+	sorted := append([]assignment.Assignment{}, assignments...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Alias < sorted[j].Alias
+	})
+
+	aliasByKey := map[string]string{}
+	for _, a := range sorted {
+		if _, exists := aliasByKey[history.Key(a.Coauthor)]; !exists {
+			aliasByKey[history.Key(a.Coauthor)] = a.Alias
+		}
+	}
+
+	return Roster{assignments: sorted, aliasByKey: aliasByKey}
+}
+
+// Load read the roster from the aliases configured via git team assignments
+func Load(gitConfigReader gitconfig.Reader) (Roster, error) {
+	aliasCoauthorMap, err := gitConfigReader.GetRegexp(gitconfigscope.Global, "team.alias")
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return Roster{}, err
+	}
+
+	assignments := []assignment.Assignment{}
+	for rawAlias, coauthor := range aliasCoauthorMap {
+		assignments = append(assignments, assignment.Assignment{Alias: strings.TrimPrefix(rawAlias, "team.alias."), Coauthor: coauthor})
+	}
+
+	return New(assignments), nil
+}
+
+// Assignments the assignments of the roster, sorted by alias
+func (roster Roster) Assignments() []assignment.Assignment {
+	return roster.assignments
+}
+
+// Alias the alias of an identity, which is matched by email
+func (roster Roster) Alias(identity string) (string, bool) {
+	alias, ok := roster.aliasByKey[history.Key(identity)]
+	return alias, ok
+}
+
+// Name the alias of an identity if there is one, the identity itself otherwise
+func (roster Roster) Name(identity string) string {
+	if alias, ok := roster.Alias(identity); ok {
+		return alias
+	}
+	return identity
+}
+
+// Resolve the identity an alias is assigned to
+func (roster Roster) Resolve(alias string) (string, bool) {
+	for _, a := range roster.assignments {
+		if a.Alias == alias {
+			return a.Coauthor, true
+		}
+	}
+	return "", false
+}
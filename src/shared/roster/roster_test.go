@@ -0,0 +1,111 @@
+package roster
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hekmekk/git-team/src/core/assignment"
+	gitconfigerror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
+	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+)
+
+type gitConfigReaderMock struct {
+	getRegexp func(gitconfigscope.Scope, string) (map[string]string, error)
+}
+
+func (mock gitConfigReaderMock) Get(scope gitconfigscope.Scope, key string) (string, error) {
+	return "", nil
+}
+
+func (mock gitConfigReaderMock) GetAll(scope gitconfigscope.Scope, key string) ([]string, error) {
+	return []string{}, nil
+}
+
+func (mock gitConfigReaderMock) GetRegexp(scope gitconfigscope.Scope, pattern string) (map[string]string, error) {
+	return mock.getRegexp(scope, pattern)
+}
+
+func (mock gitConfigReaderMock) List(scope gitconfigscope.Scope) (map[string]string, error) {
+	return nil, nil
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	reader := gitConfigReaderMock{
+		getRegexp: func(scope gitconfigscope.Scope, pattern string) (map[string]string, error) {
+			if scope != gitconfigscope.Global || pattern != "team.alias" {
+				return nil, errors.New("wrong scope or pattern")
+			}
+			return map[string]string{"team.alias.b": "B <b@x.y>", "team.alias.a": "A <a@x.y>"}, nil
+		},
+	}
+
+	expectedAssignments := []assignment.Assignment{{Alias: "a", Coauthor: "A <a@x.y>"}, {Alias: "b", Coauthor: "B <b@x.y>"}}
+
+	roster, err := Load(reader)
+
+	if err != nil {
+		t.Error(err)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedAssignments, roster.Assignments()) {
+		t.Errorf("expected: %s, got: %s", expectedAssignments, roster.Assignments())
+		t.Fail()
+	}
+}
+
+func TestLoadShouldSucceedWithoutAssignments(t *testing.T) {
+	t.Parallel()
+
+	reader := gitConfigReaderMock{
+		getRegexp: func(gitconfigscope.Scope, string) (map[string]string, error) {
+			return nil, gitconfigerror.ErrSectionOrKeyIsInvalid
+		},
+	}
+
+	roster, err := Load(reader)
+
+	if err != nil || len(roster.Assignments()) != 0 {
+		t.Errorf("expected an empty roster, got: %s, %s", roster.Assignments(), err)
+		t.Fail()
+	}
+}
+
+func TestName(t *testing.T) {
+	t.Parallel()
+
+	roster := New([]assignment.Assignment{{Alias: "a", Coauthor: "A <a@x.y>"}})
+
+	cases := map[string]string{
+		"A <a@x.y>":       "a",
+		"Someone <A@X.Y>": "a",
+		"B <b@x.y>":       "B <b@x.y>",
+		"not an identity": "not an identity",
+	}
+
+	for identity, expectedName := range cases {
+		if name := roster.Name(identity); name != expectedName {
+			t.Errorf("expected: %s, got: %s", expectedName, name)
+			t.Fail()
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	roster := New([]assignment.Assignment{{Alias: "a", Coauthor: "A <a@x.y>"}})
+
+	if coauthor, ok := roster.Resolve("a"); !ok || coauthor != "A <a@x.y>" {
+		t.Errorf("expected: %s, got: %s", "A <a@x.y>", coauthor)
+		t.Fail()
+	}
+
+	if _, ok := roster.Resolve("b"); ok {
+		t.Error("expected b to be unknown")
+		t.Fail()
+	}
+}
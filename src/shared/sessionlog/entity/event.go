@@ -0,0 +1,26 @@
+package sessionlogentity
+
+import (
+	"time"
+
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+)
+
+// Action what has been done to git-team
+type Action string
+
+const (
+	// Enabled git-team has been enabled, starting a session
+	Enabled Action = "enable"
+	// Disabled git-team has been disabled, ending a session
+	Disabled Action = "disable"
+)
+
+// Event an entry of the session log, recording when git-team has been enabled or disabled
+type Event struct {
+	Time       time.Time
+	Action     Action
+	Scope      activationscope.Scope
+	Repository string
+	Coauthors  []string
+}
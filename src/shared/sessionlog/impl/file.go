@@ -0,0 +1,121 @@
+package sessionlogimpl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	sessionlog "github.com/hekmekk/git-team/src/shared/sessionlog/entity"
+)
+
+// Path the location of the session log within the home directory
+func Path(homeDir string) string {
+	return filepath.Join(homeDir, ".git-team", "sessions.log")
+}
+
+// AppendFile append data to the file at path, creating the file along with its directory if necessary
+func AppendFile(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// FileDataSource read the session log from a file
+type FileDataSource struct {
+	Path     string
+	ReadFile func(string) ([]byte, error)
+}
+
+// NewFileDataSource construct a new FileDataSource
+func NewFileDataSource(path string, readFile func(string) ([]byte, error)) FileDataSource {
+	return FileDataSource{Path: path, ReadFile: readFile}
+}
+
+// Read all events of the log in the order in which they have been appended, a missing log file is considered to be empty
+func (ds FileDataSource) Read() ([]sessionlog.Event, error) {
+	data, err := ds.ReadFile(ds.Path)
+	if err != nil && errors.Is(err, os.ErrNotExist) {
+		return []sessionlog.Event{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session log: %s", err)
+	}
+
+	events := []sessionlog.Event{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" {
+			continue
+		}
+
+		event, err := parse(line)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// FileDataSink append to the session log file
+type FileDataSink struct {
+	Path       string
+	AppendFile func(string, []byte, os.FileMode) error
+}
+
+// NewFileDataSink construct a new FileDataSink
+func NewFileDataSink(path string, appendFile func(string, []byte, os.FileMode) error) FileDataSink {
+	return FileDataSink{Path: path, AppendFile: appendFile}
+}
+
+// Append add an event to the end of the log
+func (ds FileDataSink) Append(event sessionlog.Event) error {
+	fields := []string{event.Time.Format(time.RFC3339), string(event.Action), event.Scope.String(), event.Repository}
+	fields = append(fields, event.Coauthors...)
+
+	return ds.AppendFile(ds.Path, []byte(strings.Join(fields, "\t")+"\n"), 0644)
+}
+
+// one event per line: <time>\t<action>\t<scope>\t<repository>[\t<co-author>]...
+func parse(line string) (sessionlog.Event, error) {
+	fields := strings.Split(line, "\t")
+	if len(fields) < 4 {
+		return sessionlog.Event{}, fmt.Errorf("malformed session log entry: '%s'", line)
+	}
+
+	eventTime, err := time.Parse(time.RFC3339, fields[0])
+	if err != nil {
+		return sessionlog.Event{}, fmt.Errorf("malformed session log entry: '%s'", line)
+	}
+
+	action := sessionlog.Action(fields[1])
+	if action != sessionlog.Enabled && action != sessionlog.Disabled {
+		return sessionlog.Event{}, fmt.Errorf("malformed session log entry: '%s'", line)
+	}
+
+	return sessionlog.Event{
+		Time:       eventTime,
+		Action:     action,
+		Scope:      activationscope.FromString(fields[2]),
+		Repository: fields[3],
+		Coauthors:  append([]string{}, fields[4:]...),
+	}, nil
+}
@@ -0,0 +1,146 @@
+package sessionlogimpl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	sessionlog "github.com/hekmekk/git-team/src/shared/sessionlog/entity"
+)
+
+const logPath = "/home/some-user/.git-team/sessions.log"
+
+func TestPath(t *testing.T) {
+	expectedPath := logPath
+
+	path := Path("/home/some-user")
+
+	if expectedPath != path {
+		t.Errorf("expected: %s, got: %s", expectedPath, path)
+		t.Fail()
+	}
+}
+
+func TestReadSucceeds(t *testing.T) {
+	expectedEvents := []sessionlog.Event{
+		{Time: time.Date(2021, 6, 7, 9, 0, 0, 0, time.UTC), Action: sessionlog.Enabled, Scope: activationscope.RepoLocal, Repository: "/path/to/repo", Coauthors: []string{"A <a@x.y>", "B <b@x.y>"}},
+		{Time: time.Date(2021, 6, 7, 11, 30, 0, 0, time.UTC), Action: sessionlog.Disabled, Scope: activationscope.Global, Repository: "", Coauthors: []string{}},
+	}
+
+	readFile := func(path string) ([]byte, error) {
+		if path != logPath {
+			return nil, fmt.Errorf("wrong path: %s", path)
+		}
+		return []byte("2021-06-07T09:00:00Z\tenable\trepo-local\t/path/to/repo\tA <a@x.y>\tB <b@x.y>\n2021-06-07T11:30:00Z\tdisable\tglobal\t\n"), nil
+	}
+
+	events, err := NewFileDataSource(logPath, readFile).Read()
+
+	if err != nil {
+		t.Errorf("expected no error, got: %s", err)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedEvents, events) {
+		t.Errorf("expected: %v, got: %v", expectedEvents, events)
+		t.Fail()
+	}
+}
+
+func TestReadSucceedsWhenLogFileDoesNotExist(t *testing.T) {
+	expectedEvents := []sessionlog.Event{}
+
+	readFile := func(path string) ([]byte, error) {
+		return nil, os.ErrNotExist
+	}
+
+	events, err := NewFileDataSource(logPath, readFile).Read()
+
+	if err != nil {
+		t.Errorf("expected no error, got: %s", err)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedEvents, events) {
+		t.Errorf("expected: %v, got: %v", expectedEvents, events)
+		t.Fail()
+	}
+}
+
+func TestReadFailsOnMalformedEntry(t *testing.T) {
+	for _, line := range []string{"2021-06-07T09:00:00Z\tenable\n", "yesterday\tenable\tglobal\t\n", "2021-06-07T09:00:00Z\tpause\tglobal\t\n"} {
+		readFile := func(path string) ([]byte, error) {
+			return []byte(line), nil
+		}
+
+		_, err := NewFileDataSource(logPath, readFile).Read()
+
+		if err == nil {
+			t.Errorf("expected an error for: %s", line)
+			t.Fail()
+		}
+	}
+}
+
+func TestReadFailsWhenReadingTheLogFileFails(t *testing.T) {
+	readFile := func(path string) ([]byte, error) {
+		return nil, errors.New("permission denied")
+	}
+
+	_, err := NewFileDataSource(logPath, readFile).Read()
+
+	if err == nil {
+		t.Error("expected an error")
+		t.Fail()
+	}
+}
+
+func TestAppend(t *testing.T) {
+	expectedData := "2021-06-07T09:00:00Z\tenable\tglobal\t/path/to/repo\tA <a@x.y>\tB <b@x.y>\n"
+
+	var data string
+	appendFile := func(path string, content []byte, _ os.FileMode) error {
+		if path != logPath {
+			return fmt.Errorf("wrong path: %s", path)
+		}
+		data = string(content)
+		return nil
+	}
+
+	err := NewFileDataSink(logPath, appendFile).Append(sessionlog.Event{
+		Time:       time.Date(2021, 6, 7, 9, 0, 0, 0, time.UTC),
+		Action:     sessionlog.Enabled,
+		Scope:      activationscope.Global,
+		Repository: "/path/to/repo",
+		Coauthors:  []string{"A <a@x.y>", "B <b@x.y>"},
+	})
+
+	if err != nil {
+		t.Errorf("expected no error, got: %s", err)
+		t.Fail()
+	}
+
+	if expectedData != data {
+		t.Errorf("expected: %s, got: %s", expectedData, data)
+		t.Fail()
+	}
+}
+
+func TestAppendFailsWhenWritingTheLogFileFails(t *testing.T) {
+	expectedErr := errors.New("permission denied")
+
+	appendFile := func(path string, content []byte, _ os.FileMode) error {
+		return expectedErr
+	}
+
+	err := NewFileDataSink(logPath, appendFile).Append(sessionlog.Event{Action: sessionlog.Disabled})
+
+	if expectedErr != err {
+		t.Errorf("expected: %s, got: %s", expectedErr, err)
+		t.Fail()
+	}
+}
@@ -0,0 +1,10 @@
+package sessionloginterface
+
+import (
+	sessionlog "github.com/hekmekk/git-team/src/shared/sessionlog/entity"
+)
+
+// Reader read the session log
+type Reader interface {
+	Read() ([]sessionlog.Event, error)
+}
@@ -0,0 +1,10 @@
+package sessionloginterface
+
+import (
+	sessionlog "github.com/hekmekk/git-team/src/shared/sessionlog/entity"
+)
+
+// Writer add events to the session log
+type Writer interface {
+	Append(event sessionlog.Event) error
+}
@@ -0,0 +1,21 @@
+package sessionlog
+
+import (
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	sessionlogentity "github.com/hekmekk/git-team/src/shared/sessionlog/entity"
+	sessionloginterface "github.com/hekmekk/git-team/src/shared/sessionlog/interface"
+)
+
+// Record append the event to the session log, along with the repository in case of a repo-local session
+func Record(writer sessionloginterface.Writer, getGitCommonDir func() (string, error), event sessionlogentity.Event) error {
+	if event.Scope == activationscope.RepoLocal {
+		// keyed by the common git dir, so that the sessions of all worktrees of a repository add up
+		gitCommonDir, err := getGitCommonDir()
+		if err != nil {
+			return err
+		}
+		event.Repository = gitCommonDir
+	}
+
+	return writer.Append(event)
+}
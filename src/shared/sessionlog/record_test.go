@@ -0,0 +1,110 @@
+package sessionlog
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	sessionlogentity "github.com/hekmekk/git-team/src/shared/sessionlog/entity"
+)
+
+type sessionLogWriterMock struct {
+	append func(sessionlogentity.Event) error
+}
+
+func (mock sessionLogWriterMock) Append(event sessionlogentity.Event) error {
+	return mock.append(event)
+}
+
+var now = time.Date(2021, 6, 7, 9, 0, 0, 0, time.UTC)
+
+func TestRecordShouldAppendTheRepositoryOfARepoLocalSession(t *testing.T) {
+	var appended sessionlogentity.Event
+
+	writer := sessionLogWriterMock{
+		append: func(event sessionlogentity.Event) error {
+			appended = event
+			return nil
+		},
+	}
+
+	getGitCommonDir := func() (string, error) {
+		return "/path/to/repo/.git", nil
+	}
+
+	event := sessionlogentity.Event{Time: now, Action: sessionlogentity.Enabled, Scope: activationscope.RepoLocal, Coauthors: []string{"A <a@x.y>"}}
+
+	expectedEvent := sessionlogentity.Event{Time: now, Action: sessionlogentity.Enabled, Scope: activationscope.RepoLocal, Repository: "/path/to/repo/.git", Coauthors: []string{"A <a@x.y>"}}
+
+	err := Record(writer, getGitCommonDir, event)
+
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedEvent, appended) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, appended)
+		t.Fail()
+	}
+}
+
+func TestRecordShouldAppendAGlobalSessionWithoutRepository(t *testing.T) {
+	var appended sessionlogentity.Event
+
+	writer := sessionLogWriterMock{
+		append: func(event sessionlogentity.Event) error {
+			appended = event
+			return nil
+		},
+	}
+
+	getGitCommonDir := func() (string, error) {
+		t.Error("unexpected call to getGitCommonDir")
+		t.Fail()
+		return "", nil
+	}
+
+	event := sessionlogentity.Event{Time: now, Action: sessionlogentity.Disabled, Scope: activationscope.Global, Coauthors: []string{}}
+
+	expectedEvent := event
+
+	err := Record(writer, getGitCommonDir, event)
+
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedEvent, appended) {
+		t.Errorf("expected: %v, got: %v", expectedEvent, appended)
+		t.Fail()
+	}
+}
+
+func TestRecordShouldFailWhenTheRepositoryCantBeDetermined(t *testing.T) {
+	writer := sessionLogWriterMock{
+		append: func(event sessionlogentity.Event) error {
+			t.Errorf("unexpected call to Append with: %v", event)
+			t.Fail()
+			return nil
+		},
+	}
+
+	getGitCommonDir := func() (string, error) {
+		return "", errors.New("not inside a git repository")
+	}
+
+	event := sessionlogentity.Event{Time: now, Action: sessionlogentity.Enabled, Scope: activationscope.RepoLocal, Coauthors: []string{}}
+
+	expectedErr := errors.New("not inside a git repository")
+
+	err := Record(writer, getGitCommonDir, event)
+
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf("expected: %s, got: %s", expectedErr, err)
+		t.Fail()
+	}
+}
@@ -1,5 +1,9 @@
 package stateentity
 
+import (
+	"github.com/hekmekk/git-team/src/shared/role"
+)
+
 type teamStatus string
 
 const (
@@ -11,6 +15,8 @@ const (
 type State struct {
 	Status    teamStatus
 	Coauthors []string
+	Roles     []role.Assignment
+	Displaced map[string]string
 }
 
 // NewStateEnabled the constructor for the enabled state
@@ -27,3 +33,15 @@ func NewStateDisabled() State {
 func (state State) IsEnabled() bool {
 	return state.Status == enabled
 }
+
+// WithDisplaced returns a copy of the state which knows about the given displaced settings (key -> previous value)
+func (state State) WithDisplaced(displaced map[string]string) State {
+	state.Displaced = displaced
+	return state
+}
+
+// WithRoles returns a copy of the state which knows about the given role assignments
+func (state State) WithRoles(roles []role.Assignment) State {
+	state.Roles = roles
+	return state
+}
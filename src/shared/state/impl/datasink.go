@@ -7,6 +7,7 @@ import (
 	giterror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
 	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/interface"
 	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+	"github.com/hekmekk/git-team/src/shared/role"
 	state "github.com/hekmekk/git-team/src/shared/state/entity"
 )
 
@@ -21,8 +22,8 @@ func NewGitConfigDataSink(gitConfigWriter gitconfig.Writer) GitConfigDataSink {
 }
 
 // PersistEnabled persist the current state as enabled
-func (ds GitConfigDataSink) PersistEnabled(scope activationscope.Scope, coauthors []string) error {
-	return ds.persist(scope, state.NewStateEnabled(coauthors))
+func (ds GitConfigDataSink) PersistEnabled(scope activationscope.Scope, coauthors []string, roles []role.Assignment) error {
+	return ds.persist(scope, state.NewStateEnabled(coauthors).WithRoles(roles))
 }
 
 // PersistDisabled persist the current state as disabled
@@ -33,12 +34,7 @@ func (ds GitConfigDataSink) PersistDisabled(scope activationscope.Scope) error {
 func (ds GitConfigDataSink) persist(activationScope activationscope.Scope, state state.State) error {
 	gitConfigWriter := ds.GitConfigWriter
 
-	var gitConfigScope gitconfigscope.Scope
-	if activationScope == activationscope.Global {
-		gitConfigScope = gitconfigscope.Global
-	} else {
-		gitConfigScope = gitconfigscope.Local
-	}
+	gitConfigScope := gitconfigscope.FromActivationScope(activationScope)
 
 	if err := gitConfigWriter.UnsetAll(gitConfigScope, "team.state.active-coauthors"); err != nil && !errors.Is(err, giterror.ErrTryingToUnsetAnOptionWhichDoesNotExist) {
 		return errors.New("failed to unset team.state.active-coauthors")
@@ -50,6 +46,22 @@ func (ds GitConfigDataSink) persist(activationScope activationscope.Scope, state
 		}
 	}
 
+	if err := gitConfigWriter.UnsetAll(gitConfigScope, "team.state.active-roles"); err != nil && !errors.Is(err, giterror.ErrTryingToUnsetAnOptionWhichDoesNotExist) {
+		return errors.New("failed to unset team.state.active-roles")
+	}
+
+	for _, assignment := range state.Roles {
+		if err := gitConfigWriter.Add(gitConfigScope, "team.state.active-roles", assignment.String()); err != nil {
+			return errors.New("failed to set team.state.active-roles")
+		}
+	}
+
+	if !state.IsEnabled() {
+		if err := gitConfigWriter.UnsetAll(gitConfigScope, "team.state.template-source"); err != nil && !errors.Is(err, giterror.ErrTryingToUnsetAnOptionWhichDoesNotExist) {
+			return errors.New("failed to unset team.state.template-source")
+		}
+	}
+
 	if err := gitConfigWriter.ReplaceAll(gitConfigScope, "team.state.status", string(state.Status)); err != nil {
 		return errors.New("failed to replace team.state.status")
 	}
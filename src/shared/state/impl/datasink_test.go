@@ -9,6 +9,7 @@ import (
 	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
 	gitconfigerror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
 	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+	"github.com/hekmekk/git-team/src/shared/role"
 )
 
 func TestPersistSucceeds(t *testing.T) {
@@ -26,7 +27,7 @@ func TestPersistSucceeds(t *testing.T) {
 		On("ReplaceAll", mock.Anything, mock.Anything, mock.Anything).
 		Return(nil)
 
-	err := NewGitConfigDataSink(gitConfigWriter).PersistEnabled(activationscope.Global, []string{"CO-AUTHOR"})
+	err := NewGitConfigDataSink(gitConfigWriter).PersistEnabled(activationscope.Global, []string{"CO-AUTHOR"}, []role.Assignment{})
 
 	require.Nil(t, err)
 }
@@ -46,7 +47,7 @@ func TestPersistSucceedsWhenTryingToRemoveNonExistingActiveCoauthorsFromGitConfi
 		On("ReplaceAll", mock.Anything, mock.Anything, mock.Anything).
 		Return(nil)
 
-	err := NewGitConfigDataSink(gitConfigWriter).PersistEnabled(activationscope.Global, []string{"CO-AUTHOR"})
+	err := NewGitConfigDataSink(gitConfigWriter).PersistEnabled(activationscope.Global, []string{"CO-AUTHOR"}, []role.Assignment{})
 
 	require.Nil(t, err)
 }
@@ -58,7 +59,7 @@ func TestPersistFailsDueToAnotherUnsetAllFailure(t *testing.T) {
 		On("UnsetAll", mock.Anything, mock.Anything).
 		Return(gitconfigerror.ErrConfigFileCannotBeWritten)
 
-	err := NewGitConfigDataSink(gitConfigWriter).PersistEnabled(activationscope.Global, []string{"CO-AUTHOR"})
+	err := NewGitConfigDataSink(gitConfigWriter).PersistEnabled(activationscope.Global, []string{"CO-AUTHOR"}, []role.Assignment{})
 
 	require.Error(t, err)
 }
@@ -74,7 +75,7 @@ func TestPersistFailsDueToAddFailure(t *testing.T) {
 		On("Add", mock.Anything, mock.Anything, mock.Anything).
 		Return(gitconfigerror.ErrConfigFileCannotBeWritten)
 
-	err := NewGitConfigDataSink(gitConfigWriter).PersistEnabled(activationscope.Global, []string{"CO-AUTHOR"})
+	err := NewGitConfigDataSink(gitConfigWriter).PersistEnabled(activationscope.Global, []string{"CO-AUTHOR"}, []role.Assignment{})
 
 	require.Error(t, err)
 }
@@ -108,6 +109,7 @@ func TestPersistPassesThroughTheCorrectScope(t *testing.T) {
 	}{
 		{activationscope.Global, gitconfigscope.Global},
 		{activationscope.RepoLocal, gitconfigscope.Local},
+		{activationscope.Directory, gitconfigscope.Directory},
 	}
 
 	for _, caseLoopVar := range cases {
@@ -143,3 +145,28 @@ func TestPersistPassesThroughTheCorrectScope(t *testing.T) {
 	}
 
 }
+
+func TestPersistEnabledShouldPersistRoles(t *testing.T) {
+	gitConfigWriter := &mocks.Writer{}
+
+	gitConfigWriter.
+		On("UnsetAll", mock.Anything, mock.Anything).
+		Return(nil)
+
+	gitConfigWriter.
+		On("Add", gitconfigscope.Global, "team.state.active-coauthors", "CO-AUTHOR").
+		Return(nil)
+
+	gitConfigWriter.
+		On("Add", gitconfigscope.Global, "team.state.active-roles", "reviewer=REVIEWER").
+		Return(nil)
+
+	gitConfigWriter.
+		On("ReplaceAll", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	err := NewGitConfigDataSink(gitConfigWriter).PersistEnabled(activationscope.Global, []string{"CO-AUTHOR"}, []role.Assignment{{Role: role.Reviewer, Identity: "REVIEWER"}})
+
+	require.Nil(t, err)
+	gitConfigWriter.AssertCalled(t, "Add", gitconfigscope.Global, "team.state.active-roles", "reviewer=REVIEWER")
+}
@@ -1,11 +1,15 @@
 package stateimpl
 
 import (
+	"errors"
 	"fmt"
 
 	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	"github.com/hekmekk/git-team/src/shared/displaced"
+	giterror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
 	gitconfig "github.com/hekmekk/git-team/src/shared/gitconfig/interface"
 	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+	"github.com/hekmekk/git-team/src/shared/role"
 	state "github.com/hekmekk/git-team/src/shared/state/entity"
 )
 
@@ -21,22 +25,40 @@ func NewGitConfigDataSource(gitConfigReader gitconfig.Reader) GitConfigDataSourc
 
 // Query read the current state from gitconfig
 func (ds GitConfigDataSource) Query(activationScope activationscope.Scope) (state.State, error) {
-	var gitConfigScope gitconfigscope.Scope
-	if activationScope == activationscope.Global {
-		gitConfigScope = gitconfigscope.Global
-	} else {
-		gitConfigScope = gitconfigscope.Local
-	}
+	gitConfigScope := gitconfigscope.FromActivationScope(activationScope)
 
 	status, err := ds.GitConfigReader.Get(gitConfigScope, "team.state.status")
 	if err != nil || "disabled" == status || "" == status {
 		return state.NewStateDisabled(), nil
 	}
 
+	// git-team may be enabled with roles only
 	activeCoauthors, err := ds.GitConfigReader.GetAll(gitConfigScope, "team.state.active-coauthors")
+	if errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		activeCoauthors, err = []string{}, nil
+	}
 	if err != nil {
 		return state.State{}, fmt.Errorf("no active co-authors found: %s", err)
 	}
 
-	return state.NewStateEnabled(activeCoauthors), nil
+	rawRoles, err := ds.GitConfigReader.GetAll(gitConfigScope, "team.state.active-roles")
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return state.State{}, fmt.Errorf("failed to read active roles: %s", err)
+	}
+
+	var roles []role.Assignment
+	for _, rawRole := range rawRoles {
+		assignment, err := role.ParseAssignment(rawRole)
+		if err != nil {
+			return state.State{}, fmt.Errorf("invalid active role found in config. Did you edit it manually? %s", err)
+		}
+		roles = append(roles, assignment)
+	}
+
+	backups, err := ds.GitConfigReader.GetRegexp(gitConfigScope, displaced.BackupKeyPattern)
+	if err != nil && !errors.Is(err, giterror.ErrSectionOrKeyIsInvalid) {
+		return state.State{}, fmt.Errorf("failed to read displaced settings: %s", err)
+	}
+
+	return state.NewStateEnabled(activeCoauthors).WithRoles(roles).WithDisplaced(displaced.FromBackups(backups)), nil
 }
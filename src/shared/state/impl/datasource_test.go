@@ -1,17 +1,21 @@
 package stateimpl
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
 	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	gitconfigerror "github.com/hekmekk/git-team/src/shared/gitconfig/error"
 	gitconfigscope "github.com/hekmekk/git-team/src/shared/gitconfig/scope"
+	"github.com/hekmekk/git-team/src/shared/role"
 	state "github.com/hekmekk/git-team/src/shared/state/entity"
 )
 
 type gitConfigReaderMock struct {
-	get    func(gitconfigscope.Scope, string) (string, error)
-	getAll func(gitconfigscope.Scope, string) ([]string, error)
+	get       func(gitconfigscope.Scope, string) (string, error)
+	getAll    func(gitconfigscope.Scope, string) ([]string, error)
+	getRegexp func(gitconfigscope.Scope, string) (map[string]string, error)
 }
 
 func (mock gitConfigReaderMock) Get(scope gitconfigscope.Scope, key string) (string, error) {
@@ -23,7 +27,10 @@ func (mock gitConfigReaderMock) GetAll(scope gitconfigscope.Scope, key string) (
 }
 
 func (mock gitConfigReaderMock) GetRegexp(scope gitconfigscope.Scope, pattern string) (map[string]string, error) {
-	return nil, nil
+	if mock.getRegexp == nil {
+		return nil, nil
+	}
+	return mock.getRegexp(scope, pattern)
 }
 
 func (mock gitConfigReaderMock) List(scope gitconfigscope.Scope) (map[string]string, error) {
@@ -38,7 +45,14 @@ func TestQueryDisabled(t *testing.T) {
 			return "disabled", nil
 		},
 		getAll: func(scope gitconfigscope.Scope, key string) ([]string, error) {
-			return []string{}, nil
+			switch key {
+			case "team.state.active-coauthors":
+				return []string{}, nil
+			case "team.state.active-roles":
+				return nil, gitconfigerror.ErrSectionOrKeyIsInvalid
+			default:
+				return nil, fmt.Errorf("wrong key: %s", key)
+			}
 		},
 	}
 
@@ -64,7 +78,118 @@ func TestQueryEnabled(t *testing.T) {
 			return "enabled", nil
 		},
 		getAll: func(scope gitconfigscope.Scope, key string) ([]string, error) {
-			return activeCoauthors, nil
+			switch key {
+			case "team.state.active-coauthors":
+				return activeCoauthors, nil
+			case "team.state.active-roles":
+				return nil, gitconfigerror.ErrSectionOrKeyIsInvalid
+			default:
+				return nil, fmt.Errorf("wrong key: %s", key)
+			}
+		},
+	}
+
+	state, err := NewGitConfigDataSource(gitConfigReader).Query(activationscope.Global)
+
+	if err != nil {
+		t.Error(err)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedState, state) {
+		t.Errorf("expected: %s, got: %s", expectedState, state)
+		t.Fail()
+	}
+}
+
+func TestQueryEnabledWithDisplacedSettings(t *testing.T) {
+	activeCoauthors := []string{"Mr. Noujz <noujz@mr.se>"}
+	expectedState := state.State{Status: "enabled", Coauthors: activeCoauthors, Displaced: map[string]string{"core.hooksPath": "/path/to/hooks"}}
+
+	gitConfigReader := &gitConfigReaderMock{
+		get: func(scope gitconfigscope.Scope, key string) (string, error) {
+			return "enabled", nil
+		},
+		getAll: func(scope gitconfigscope.Scope, key string) ([]string, error) {
+			switch key {
+			case "team.state.active-coauthors":
+				return activeCoauthors, nil
+			case "team.state.active-roles":
+				return nil, gitconfigerror.ErrSectionOrKeyIsInvalid
+			default:
+				return nil, fmt.Errorf("wrong key: %s", key)
+			}
+		},
+		getRegexp: func(scope gitconfigscope.Scope, pattern string) (map[string]string, error) {
+			if pattern != "^team\\.displaced\\." {
+				return nil, fmt.Errorf("wrong pattern: %s", pattern)
+			}
+			return map[string]string{"team.displaced.hooks-path": "/path/to/hooks"}, nil
+		},
+	}
+
+	state, err := NewGitConfigDataSource(gitConfigReader).Query(activationscope.Global)
+
+	if err != nil {
+		t.Error(err)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedState, state) {
+		t.Errorf("expected: %s, got: %s", expectedState, state)
+		t.Fail()
+	}
+}
+
+func TestQueryEnabledWithRoles(t *testing.T) {
+	activeCoauthors := []string{"Mr. Noujz <noujz@mr.se>"}
+	expectedState := state.State{Status: "enabled", Coauthors: activeCoauthors, Roles: []role.Assignment{{Role: role.Reviewer, Identity: "Mrs. Noujz <noujz@mrs.se>"}}}
+
+	gitConfigReader := &gitConfigReaderMock{
+		get: func(scope gitconfigscope.Scope, key string) (string, error) {
+			return "enabled", nil
+		},
+		getAll: func(scope gitconfigscope.Scope, key string) ([]string, error) {
+			switch key {
+			case "team.state.active-coauthors":
+				return activeCoauthors, nil
+			case "team.state.active-roles":
+				return []string{"reviewer=Mrs. Noujz <noujz@mrs.se>"}, nil
+			default:
+				return nil, fmt.Errorf("wrong key: %s", key)
+			}
+		},
+	}
+
+	state, err := NewGitConfigDataSource(gitConfigReader).Query(activationscope.Global)
+
+	if err != nil {
+		t.Error(err)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedState, state) {
+		t.Errorf("expected: %s, got: %s", expectedState, state)
+		t.Fail()
+	}
+}
+
+func TestQueryEnabledWithRolesOnly(t *testing.T) {
+	expectedState := state.State{Status: "enabled", Coauthors: []string{}, Roles: []role.Assignment{{Role: role.Reviewer, Identity: "Mrs. Noujz <noujz@mrs.se>"}}}
+
+	gitConfigReader := &gitConfigReaderMock{
+		get: func(scope gitconfigscope.Scope, key string) (string, error) {
+			return "enabled", nil
+		},
+		getAll: func(scope gitconfigscope.Scope, key string) ([]string, error) {
+			switch key {
+			case "team.state.active-coauthors":
+				return nil, gitconfigerror.ErrSectionOrKeyIsInvalid
+			case "team.state.active-roles":
+				return []string{"reviewer=Mrs. Noujz <noujz@mrs.se>"}, nil
+			default:
+				return nil, fmt.Errorf("wrong key: %s", key)
+			}
 		},
 	}
 
@@ -81,6 +206,59 @@ func TestQueryEnabled(t *testing.T) {
 	}
 }
 
+func TestQueryShouldFailWhenAnActiveRoleIsInvalid(t *testing.T) {
+	gitConfigReader := &gitConfigReaderMock{
+		get: func(scope gitconfigscope.Scope, key string) (string, error) {
+			return "enabled", nil
+		},
+		getAll: func(scope gitconfigscope.Scope, key string) ([]string, error) {
+			switch key {
+			case "team.state.active-coauthors":
+				return []string{"Mr. Noujz <noujz@mr.se>"}, nil
+			case "team.state.active-roles":
+				return []string{"tester=Mrs. Noujz <noujz@mrs.se>"}, nil
+			default:
+				return nil, fmt.Errorf("wrong key: %s", key)
+			}
+		},
+	}
+
+	_, err := NewGitConfigDataSource(gitConfigReader).Query(activationscope.Global)
+
+	if err == nil {
+		t.Error("expected an error")
+		t.Fail()
+	}
+}
+
+func TestQueryShouldFailWhenReadingDisplacedSettingsFails(t *testing.T) {
+	gitConfigReader := &gitConfigReaderMock{
+		get: func(scope gitconfigscope.Scope, key string) (string, error) {
+			return "enabled", nil
+		},
+		getAll: func(scope gitconfigscope.Scope, key string) ([]string, error) {
+			switch key {
+			case "team.state.active-coauthors":
+				return []string{"Mr. Noujz <noujz@mr.se>"}, nil
+			case "team.state.active-roles":
+				return nil, gitconfigerror.ErrSectionOrKeyIsInvalid
+			default:
+				return nil, fmt.Errorf("wrong key: %s", key)
+			}
+		},
+		getRegexp: func(scope gitconfigscope.Scope, pattern string) (map[string]string, error) {
+			return nil, gitconfigerror.ErrConfigFileIsInvalid
+		},
+	}
+
+	_, err := NewGitConfigDataSource(gitConfigReader).Query(activationscope.Global)
+
+	if err == nil {
+		t.Error("expected an error")
+		t.Fail()
+	}
+}
+
 func TestQueryDisabledWhenStatusUnset(t *testing.T) {
 	expectedState := state.State{Status: "disabled", Coauthors: []string{}}
 
@@ -89,7 +267,14 @@ func TestQueryDisabledWhenStatusUnset(t *testing.T) {
 			return "", nil
 		},
 		getAll: func(scope gitconfigscope.Scope, key string) ([]string, error) {
-			return []string{}, nil
+			switch key {
+			case "team.state.active-coauthors":
+				return []string{}, nil
+			case "team.state.active-roles":
+				return nil, gitconfigerror.ErrSectionOrKeyIsInvalid
+			default:
+				return nil, fmt.Errorf("wrong key: %s", key)
+			}
 		},
 	}
 
@@ -115,6 +300,7 @@ func TestQueryTranslatesActivationScopeToGitconfigScopeCorrectly(t *testing.T) {
 	}{
 		{activationscope.Global, gitconfigscope.Global},
 		{activationscope.RepoLocal, gitconfigscope.Local},
+		{activationscope.Directory, gitconfigscope.Directory},
 	}
 
 	for _, caseLoopVar := range properties {
@@ -137,7 +323,14 @@ func TestQueryTranslatesActivationScopeToGitconfigScopeCorrectly(t *testing.T) {
 						t.Errorf("wrong scope, expected: %s, got: %s", gitConfigScope, scope)
 						t.Fail()
 					}
-					return []string{"Mr. Noujz <noujz@mr.se>"}, nil
+					switch key {
+					case "team.state.active-coauthors":
+						return []string{"Mr. Noujz <noujz@mr.se>"}, nil
+					case "team.state.active-roles":
+						return nil, gitconfigerror.ErrSectionOrKeyIsInvalid
+					default:
+						return nil, fmt.Errorf("wrong key: %s", key)
+					}
 				},
 			}
 
@@ -2,10 +2,11 @@ package stateinterface
 
 import (
 	activationscope "github.com/hekmekk/git-team/src/shared/activation/scope"
+	"github.com/hekmekk/git-team/src/shared/role"
 )
 
 // Writer persist the current state
 type Writer interface {
-	PersistEnabled(scope activationscope.Scope, coauthors []string) error
+	PersistEnabled(scope activationscope.Scope, coauthors []string, roles []role.Assignment) error
 	PersistDisabled(scope activationscope.Scope) error
 }
@@ -0,0 +1,7 @@
+package templateindexentity
+
+// Entry associates a repo-local commit template directory with the repository it has been created for
+type Entry struct {
+	TemplateDir string
+	GitDir      string
+}
@@ -0,0 +1,114 @@
+package templateindeximpl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	templateindex "github.com/hekmekk/git-team/src/shared/templateindex/entity"
+)
+
+// Path the location of the index file for the given commit templates base dir
+func Path(templatesBaseDir string) string {
+	return filepath.Join(templatesBaseDir, "repo-local", "index")
+}
+
+// FileDataSource read the index from a file
+type FileDataSource struct {
+	Path     string
+	ReadFile func(string) ([]byte, error)
+}
+
+// NewFileDataSource construct a new FileDataSource
+func NewFileDataSource(path string, readFile func(string) ([]byte, error)) FileDataSource {
+	return FileDataSource{Path: path, ReadFile: readFile}
+}
+
+// Read all entries of the index, a missing index file is considered to be empty
+func (ds FileDataSource) Read() ([]templateindex.Entry, error) {
+	return read(ds.Path, ds.ReadFile)
+}
+
+// FileDataSink write the index to a file
+type FileDataSink struct {
+	Path      string
+	ReadFile  func(string) ([]byte, error)
+	WriteFile func(string, []byte, os.FileMode) error
+}
+
+// NewFileDataSink construct a new FileDataSink
+func NewFileDataSink(path string, readFile func(string) ([]byte, error), writeFile func(string, []byte, os.FileMode) error) FileDataSink {
+	return FileDataSink{Path: path, ReadFile: readFile, WriteFile: writeFile}
+}
+
+// Add add an entry to the index, replacing a previous entry for the same template dir
+func (ds FileDataSink) Add(entry templateindex.Entry) error {
+	entries, err := read(ds.Path, ds.ReadFile)
+	if err != nil {
+		return err
+	}
+
+	return ds.write(append(without(entries, entry.TemplateDir), entry))
+}
+
+// Remove remove the entry for the given template dir from the index
+func (ds FileDataSink) Remove(templateDir string) error {
+	entries, err := read(ds.Path, ds.ReadFile)
+	if err != nil {
+		return err
+	}
+
+	return ds.write(without(entries, templateDir))
+}
+
+func (ds FileDataSink) write(entries []templateindex.Entry) error {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].TemplateDir < entries[j].TemplateDir })
+
+	var builder strings.Builder
+	for _, entry := range entries {
+		builder.WriteString(fmt.Sprintf("%s\t%s\n", entry.TemplateDir, entry.GitDir))
+	}
+
+	return ds.WriteFile(ds.Path, []byte(builder.String()), 0644)
+}
+
+// one entry per line: <template dir>\t<git dir>
+func read(path string, readFile func(string) ([]byte, error)) ([]templateindex.Entry, error) {
+	data, err := readFile(path)
+	if err != nil && errors.Is(err, os.ErrNotExist) {
+		return []templateindex.Entry{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %s", err)
+	}
+
+	entries := []templateindex.Entry{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" {
+			continue
+		}
+
+		fields := strings.SplitN(line, "\t", 2)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("malformed index entry: '%s'", line)
+		}
+
+		entries = append(entries, templateindex.Entry{TemplateDir: fields[0], GitDir: fields[1]})
+	}
+
+	return entries, nil
+}
+
+func without(entries []templateindex.Entry, templateDir string) []templateindex.Entry {
+	remaining := []templateindex.Entry{}
+	for _, entry := range entries {
+		if entry.TemplateDir != templateDir {
+			remaining = append(remaining, entry)
+		}
+	}
+	return remaining
+}
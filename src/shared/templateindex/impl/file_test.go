@@ -0,0 +1,170 @@
+package templateindeximpl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	templateindex "github.com/hekmekk/git-team/src/shared/templateindex/entity"
+)
+
+const indexPath = "/path/to/commit-templates/repo-local/index"
+
+func TestPath(t *testing.T) {
+	expectedPath := indexPath
+
+	path := Path("/path/to/commit-templates")
+
+	if expectedPath != path {
+		t.Errorf("expected: %s, got: %s", expectedPath, path)
+		t.Fail()
+	}
+}
+
+func TestReadSucceeds(t *testing.T) {
+	expectedEntries := []templateindex.Entry{
+		{TemplateDir: "/path/to/template/a", GitDir: "/path/to/repo/a/.git"},
+		{TemplateDir: "/path/to/template/b", GitDir: "/path/to/repo/b/.git"},
+	}
+
+	readFile := func(path string) ([]byte, error) {
+		if path != indexPath {
+			return nil, fmt.Errorf("wrong path: %s", path)
+		}
+		return []byte("/path/to/template/a\t/path/to/repo/a/.git\n/path/to/template/b\t/path/to/repo/b/.git\n"), nil
+	}
+
+	entries, err := NewFileDataSource(indexPath, readFile).Read()
+
+	if err != nil {
+		t.Errorf("expected no error, got: %s", err)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedEntries, entries) {
+		t.Errorf("expected: %s, got: %s", expectedEntries, entries)
+		t.Fail()
+	}
+}
+
+func TestReadSucceedsWhenIndexFileDoesNotExist(t *testing.T) {
+	expectedEntries := []templateindex.Entry{}
+
+	readFile := func(path string) ([]byte, error) {
+		return nil, os.ErrNotExist
+	}
+
+	entries, err := NewFileDataSource(indexPath, readFile).Read()
+
+	if err != nil {
+		t.Errorf("expected no error, got: %s", err)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(expectedEntries, entries) {
+		t.Errorf("expected: %s, got: %s", expectedEntries, entries)
+		t.Fail()
+	}
+}
+
+func TestReadFailsOnMalformedEntry(t *testing.T) {
+	readFile := func(path string) ([]byte, error) {
+		return []byte("/path/to/template/a\n"), nil
+	}
+
+	_, err := NewFileDataSource(indexPath, readFile).Read()
+
+	if err == nil {
+		t.Error("expected an error")
+		t.Fail()
+	}
+}
+
+func TestReadFailsWhenReadingTheIndexFileFails(t *testing.T) {
+	readFile := func(path string) ([]byte, error) {
+		return nil, errors.New("permission denied")
+	}
+
+	_, err := NewFileDataSource(indexPath, readFile).Read()
+
+	if err == nil {
+		t.Error("expected an error")
+		t.Fail()
+	}
+}
+
+func TestAddReplacesAnExistingEntry(t *testing.T) {
+	expectedData := "/path/to/template/a\t/path/to/repo/moved/.git\n/path/to/template/b\t/path/to/repo/b/.git\n"
+
+	readFile := func(path string) ([]byte, error) {
+		return []byte("/path/to/template/b\t/path/to/repo/b/.git\n/path/to/template/a\t/path/to/repo/a/.git\n"), nil
+	}
+
+	var data string
+	writeFile := func(path string, content []byte, _ os.FileMode) error {
+		if path != indexPath {
+			return fmt.Errorf("wrong path: %s", path)
+		}
+		data = string(content)
+		return nil
+	}
+
+	err := NewFileDataSink(indexPath, readFile, writeFile).Add(templateindex.Entry{TemplateDir: "/path/to/template/a", GitDir: "/path/to/repo/moved/.git"})
+
+	if err != nil {
+		t.Errorf("expected no error, got: %s", err)
+		t.Fail()
+	}
+
+	if expectedData != data {
+		t.Errorf("expected: %s, got: %s", expectedData, data)
+		t.Fail()
+	}
+}
+
+func TestRemove(t *testing.T) {
+	expectedData := "/path/to/template/b\t/path/to/repo/b/.git\n"
+
+	readFile := func(path string) ([]byte, error) {
+		return []byte("/path/to/template/a\t/path/to/repo/a/.git\n/path/to/template/b\t/path/to/repo/b/.git\n"), nil
+	}
+
+	var data string
+	writeFile := func(path string, content []byte, _ os.FileMode) error {
+		data = string(content)
+		return nil
+	}
+
+	err := NewFileDataSink(indexPath, readFile, writeFile).Remove("/path/to/template/a")
+
+	if err != nil {
+		t.Errorf("expected no error, got: %s", err)
+		t.Fail()
+	}
+
+	if expectedData != data {
+		t.Errorf("expected: %s, got: %s", expectedData, data)
+		t.Fail()
+	}
+}
+
+func TestRemoveFailsWhenWritingTheIndexFileFails(t *testing.T) {
+	expectedErr := errors.New("permission denied")
+
+	readFile := func(path string) ([]byte, error) {
+		return []byte{}, nil
+	}
+
+	writeFile := func(path string, content []byte, _ os.FileMode) error {
+		return expectedErr
+	}
+
+	err := NewFileDataSink(indexPath, readFile, writeFile).Remove("/path/to/template/a")
+
+	if expectedErr != err {
+		t.Errorf("expected: %s, got: %s", expectedErr, err)
+		t.Fail()
+	}
+}
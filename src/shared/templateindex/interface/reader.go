@@ -0,0 +1,10 @@
+package templateindexinterface
+
+import (
+	templateindex "github.com/hekmekk/git-team/src/shared/templateindex/entity"
+)
+
+// Reader read the index of repo-local commit templates
+type Reader interface {
+	Read() ([]templateindex.Entry, error)
+}
@@ -0,0 +1,11 @@
+package templateindexinterface
+
+import (
+	templateindex "github.com/hekmekk/git-team/src/shared/templateindex/entity"
+)
+
+// Writer modify the index of repo-local commit templates
+type Writer interface {
+	Add(entry templateindex.Entry) error
+	Remove(templateDir string) error
+}
@@ -0,0 +1,242 @@
+package trailer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultCommentChar the comment character git uses unless core.commentChar says otherwise
+const DefaultCommentChar = "#"
+
+const scissors = " ------------------------ >8 ------------------------"
+
+var (
+	anyTrailerPattern = regexp.MustCompile(`^[A-Za-z0-9-]+[ \t]*:`)
+	blankPattern      = regexp.MustCompile(`^[ \t]*$`)
+	emailPattern      = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Cleanup how git cleans up a commit message, see core.commentChar and commit.cleanup
+type Cleanup struct {
+	CommentChar string
+	Mode        string
+}
+
+// NewCleanup constructs a new Cleanup, falling back to the defaults of git for empty values
+func NewCleanup(commentChar string, mode string) Cleanup {
+	if commentChar == "" || commentChar == "auto" {
+		commentChar = DefaultCommentChar
+	}
+	if mode == "" {
+		mode = "default"
+	}
+	return Cleanup{CommentChar: commentChar, Mode: mode}
+}
+
+func (cleanup Cleanup) stripsComments() bool {
+	return cleanup.Mode != "verbatim" && cleanup.Mode != "whitespace" && cleanup.Mode != "scissors"
+}
+
+func (cleanup Cleanup) cutsAtScissors() bool {
+	return cleanup.Mode != "verbatim" && cleanup.Mode != "whitespace"
+}
+
+// Inject appends the trailer lines to the message, just like `git interpret-trailers` would: they are added to an existing trailer block or else
+// become a new paragraph, right above the comments and the scissors line which git strips according to the cleanup.
+// A trailer line is left out if a line with the same prefix names the same identity (by email if there is one) in the trailer block already.
+func Inject(message string, trailerLines []string, prefixes []string, cleanup Cleanup) string {
+	lines := splitLines(message)
+
+	first, last, hasTrailerBlock := findTrailerBlock(lines, prefixes, cleanup)
+
+	seen := map[string]bool{}
+	for i := first + 1; hasTrailerBlock && i <= last; i++ {
+		if prefix := prefixOf(lines[i], prefixes); prefix != "" {
+			seen[identityKey(lines[i], prefix)] = true
+		}
+	}
+
+	additions := []string{}
+	for _, line := range trailerLines {
+		if line == "" {
+			continue
+		}
+		key := identityKey(line, prefixOf(line, prefixes))
+		if !seen[key] {
+			seen[key] = true
+			additions = append(additions, line)
+		}
+	}
+
+	result := append([]string{}, lines[:last+1]...)
+	if len(additions) > 0 && !hasTrailerBlock {
+		result = append(result, "")
+	}
+	result = append(result, additions...)
+	result = append(result, lines[last+1:]...)
+
+	if len(result) == 0 {
+		return ""
+	}
+
+	return strings.Join(result, "\n") + "\n"
+}
+
+// Collapse moves the trailer lines starting with one of the prefixes, wherever they are in the message, into one block at its end, dropping duplicates.
+// This merges the trailer blocks of several commit messages combined into one, e.g. when squashing. Comments and everything from the scissors line on are left untouched.
+func Collapse(message string, prefixes []string, cleanup Cleanup) string {
+	lines := splitLines(message)
+
+	endOfMessage := len(lines)
+	for i := 0; cleanup.cutsAtScissors() && i < len(lines); i++ {
+		if lines[i] == cleanup.CommentChar+scissors {
+			endOfMessage = i
+			break
+		}
+	}
+
+	collected := []string{}
+	remaining := []string{}
+	removedSinceLastLine := false
+	for i, line := range lines {
+		isComment := cleanup.stripsComments() && strings.HasPrefix(line, cleanup.CommentChar)
+		if i < endOfMessage && !isComment && prefixOf(line, prefixes) != "" {
+			collected = append(collected, line)
+			removedSinceLastLine = true
+			continue
+		}
+
+		// drop the blank line which separated a paragraph that consisted of the collected lines only
+		if isBlank(line) && removedSinceLastLine && len(remaining) > 0 && isBlank(remaining[len(remaining)-1]) {
+			continue
+		}
+
+		remaining = append(remaining, line)
+		removedSinceLastLine = false
+	}
+
+	if len(collected) == 0 {
+		return message
+	}
+
+	for removedSinceLastLine && len(remaining) > 0 && isBlank(remaining[len(remaining)-1]) {
+		remaining = remaining[:len(remaining)-1]
+	}
+
+	return Inject(strings.Join(remaining, "\n")+"\n", collected, prefixes, cleanup)
+}
+
+// Values the values of the lines in the trailer block of the message which start with the prefix, e.g. the co-authors
+func Values(message string, prefix string, cleanup Cleanup) []string {
+	lines := splitLines(message)
+
+	first, last, hasTrailerBlock := findTrailerBlock(lines, []string{prefix}, cleanup)
+
+	values := []string{}
+	for i := first + 1; hasTrailerBlock && i <= last; i++ {
+		if strings.HasPrefix(lines[i], prefix) {
+			values = append(values, strings.TrimSpace(strings.TrimPrefix(lines[i], prefix)))
+		}
+	}
+
+	return values
+}
+
+// Block the lines of the trailer block of the message, i.e. of its last paragraph if that consists of trailers only
+func Block(message string, prefixes []string, cleanup Cleanup) []string {
+	lines := splitLines(message)
+
+	first, last, hasTrailerBlock := findTrailerBlock(lines, prefixes, cleanup)
+	if !hasTrailerBlock {
+		return []string{}
+	}
+
+	return append([]string{}, lines[first+1:last+1]...)
+}
+
+// findTrailerBlock locate the trailer block, which spans the lines after first up to and including last.
+// Last is the last line of content in any case, i.e. trailing comments, blank lines and everything from the scissors line on are skipped.
+func findTrailerBlock(lines []string, prefixes []string, cleanup Cleanup) (int, int, bool) {
+	isComment := func(line string) bool {
+		return cleanup.stripsComments() && strings.HasPrefix(line, cleanup.CommentChar)
+	}
+
+	// everything from the scissors line on is removed by git
+	endOfMessage := len(lines)
+	for i := 0; cleanup.cutsAtScissors() && i < len(lines); i++ {
+		if lines[i] == cleanup.CommentChar+scissors {
+			endOfMessage = i
+			break
+		}
+	}
+
+	last := endOfMessage - 1
+	for last >= 0 && (isBlank(lines[last]) || isComment(lines[last])) {
+		last--
+	}
+
+	// the last paragraph is a trailer block if it consists of trailers (and comments or continuation lines) only, but is not the title, i.e. the first paragraph
+	first := last
+	hasTrailerBlock := last >= 0
+	hasTrailer := false
+	for first >= 0 && !isBlank(lines[first]) {
+		line := lines[first]
+		isTrailer := prefixOf(line, prefixes) != "" || anyTrailerPattern.MatchString(line)
+		if !isTrailer && !isComment(line) && !isContinuation(line) {
+			hasTrailerBlock = false
+		}
+		hasTrailer = hasTrailer || isTrailer
+		first--
+	}
+	if !hasTrailer {
+		hasTrailerBlock = false
+	}
+
+	endOfTitle := -1
+	for i, line := range lines {
+		if !isComment(line) && isBlank(line) {
+			endOfTitle = i
+			break
+		}
+	}
+	if endOfTitle < 0 || first < endOfTitle {
+		hasTrailerBlock = false
+	}
+
+	return first, last, hasTrailerBlock
+}
+
+func splitLines(message string) []string {
+	if message == "" {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(message, "\n"), "\n")
+}
+
+func isBlank(line string) bool {
+	return blankPattern.MatchString(line)
+}
+
+func isContinuation(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+}
+
+// prefixOf the longest of the prefixes the line starts with
+func prefixOf(line string, prefixes []string) string {
+	longest := ""
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(line, prefix) && len(prefix) > len(longest) {
+			longest = prefix
+		}
+	}
+	return longest
+}
+
+// identityKey the prefix along with the identity a trailer line names, which is its email if there is one
+func identityKey(line string, prefix string) string {
+	value := strings.TrimPrefix(line, prefix)
+	if email := emailPattern.FindString(value); email != "" {
+		return prefix + "\x00" + strings.ToLower(email)
+	}
+	return prefix + "\x00" + strings.TrimRight(value, " \t")
+}
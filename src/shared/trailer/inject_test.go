@@ -0,0 +1,250 @@
+package trailer
+
+import (
+	"reflect"
+	"testing"
+)
+
+var (
+	coauthorLines = []string{"Co-authored-by: A <a@x.y>", "Co-authored-by: B <b@x.y>", "Co-authored-by: C <c@x.y>"}
+	prefixes      = []string{"Co-authored-by: ", "Navigated-by: ", "Reviewed-by: "}
+)
+
+func TestInject(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name            string
+		message         string
+		cleanup         Cleanup
+		expectedMessage string
+	}{
+		{
+			"empty message",
+			"",
+			NewCleanup("", ""),
+			"\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n",
+		},
+		{
+			"message without a trailer block",
+			"subject\n\nbody\n",
+			NewCleanup("", ""),
+			"subject\n\nbody\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n",
+		},
+		{
+			"message whose last paragraph consists of indented lines only",
+			"Squashed commit of the following:\n\ncommit 1234\n\n    subject\n\n    Co-authored-by: A <a@x.y>\n",
+			NewCleanup("", ""),
+			"Squashed commit of the following:\n\ncommit 1234\n\n    subject\n\n    Co-authored-by: A <a@x.y>\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n",
+		},
+		{
+			"message which already contains some of the co-authors",
+			"subject\n\nSigned-off-by: S <s@x.y>\nCo-authored-by: b <B@X.Y>\n",
+			NewCleanup("", ""),
+			"subject\n\nSigned-off-by: S <s@x.y>\nCo-authored-by: b <B@X.Y>\nCo-authored-by: A <a@x.y>\nCo-authored-by: C <c@x.y>\n",
+		},
+		{
+			"message which already contains all of the co-authors",
+			"subject\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n",
+			NewCleanup("", ""),
+			"subject\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n",
+		},
+		{
+			"message which quotes a trailer within its body",
+			"subject\n\nstop adding Co-authored-by: lines twice\n",
+			NewCleanup("", ""),
+			"subject\n\nstop adding Co-authored-by: lines twice\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n",
+		},
+		{
+			"title which looks like a trailer",
+			"Fix: the bug\n",
+			NewCleanup("", ""),
+			"Fix: the bug\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n",
+		},
+		{
+			"template with an empty title",
+			"\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>",
+			NewCleanup("", ""),
+			"\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n",
+		},
+		{
+			"merge with comments",
+			"Merge branch x\n\n# Please enter a commit message\n",
+			NewCleanup("", ""),
+			"Merge branch x\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n\n# Please enter a commit message\n",
+		},
+		{
+			"merge with comments and a custom comment char",
+			"Merge branch x\n\n; Please enter a commit message\n",
+			NewCleanup(";", ""),
+			"Merge branch x\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n\n; Please enter a commit message\n",
+		},
+		{
+			"merge with cleanup mode verbatim",
+			"Merge branch x\n\n# kept\n",
+			NewCleanup("", "verbatim"),
+			"Merge branch x\n\n# kept\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n",
+		},
+		{
+			"squash above the scissors line",
+			"squash\n\n# ------------------------ >8 ------------------------\ndiff\n",
+			NewCleanup("", ""),
+			"squash\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n\n# ------------------------ >8 ------------------------\ndiff\n",
+		},
+		{
+			"trailer block with a continuation line",
+			"subject\n\nSigned-off-by: S <s@x.y>\n  continued\n",
+			NewCleanup("", ""),
+			"subject\n\nSigned-off-by: S <s@x.y>\n  continued\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\nCo-authored-by: C <c@x.y>\n",
+		},
+	}
+
+	for _, caseLoopVar := range cases {
+		message := caseLoopVar.message
+		cleanup := caseLoopVar.cleanup
+		expectedMessage := caseLoopVar.expectedMessage
+
+		t.Run(caseLoopVar.name, func(t *testing.T) {
+			t.Parallel()
+
+			injected := Inject(message, coauthorLines, prefixes, cleanup)
+
+			if expectedMessage != injected {
+				t.Errorf("expected: %q, got: %q", expectedMessage, injected)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestInjectShouldDeduplicatePerPrefix(t *testing.T) {
+	t.Parallel()
+
+	message := "subject\n\nCo-authored-by: R <r@x.y>\n"
+	expectedMessage := "subject\n\nCo-authored-by: R <r@x.y>\nReviewed-by: R <r@x.y>\n"
+
+	injected := Inject(message, []string{"Co-authored-by: R <r@x.y>", "Reviewed-by: R <r@x.y>"}, prefixes, NewCleanup("", ""))
+
+	if expectedMessage != injected {
+		t.Errorf("expected: %q, got: %q", expectedMessage, injected)
+		t.Fail()
+	}
+}
+
+func TestInjectShouldKeepTheMessageWithoutTrailerLines(t *testing.T) {
+	t.Parallel()
+
+	message := "subject\n\nbody\n"
+
+	injected := Inject(message, []string{}, prefixes, NewCleanup("", ""))
+
+	if message != injected {
+		t.Errorf("expected: %q, got: %q", message, injected)
+		t.Fail()
+	}
+}
+
+func TestValues(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name           string
+		message        string
+		expectedValues []string
+	}{
+		{"trailer block", "subject\n\nbody\n\nCo-authored-by: A <a@x.y>\nSigned-off-by: S <s@x.y>\nCo-authored-by: B <b@x.y>\n# comment\n", []string{"A <a@x.y>", "B <b@x.y>"}},
+		{"trailer within the body", "subject\n\nCo-authored-by: A <a@x.y> was here\nand left\n", []string{}},
+		{"title only", "Co-authored-by: A <a@x.y>\n", []string{}},
+		{"empty message", "", []string{}},
+	}
+
+	for _, caseLoopVar := range cases {
+		message := caseLoopVar.message
+		expectedValues := caseLoopVar.expectedValues
+
+		t.Run(caseLoopVar.name, func(t *testing.T) {
+			t.Parallel()
+
+			values := Values(message, "Co-authored-by: ", NewCleanup("", ""))
+
+			if !reflect.DeepEqual(expectedValues, values) {
+				t.Errorf("expected: %s, got: %s", expectedValues, values)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestBlock(t *testing.T) {
+	t.Parallel()
+
+	message := "subject\n\nbody\n\nCo-authored-by: A <a@x.y>\nSigned-off-by: S <s@x.y>\n\n# comment\n"
+	expectedBlock := []string{"Co-authored-by: A <a@x.y>", "Signed-off-by: S <s@x.y>"}
+
+	block := Block(message, prefixes, NewCleanup("", ""))
+
+	if !reflect.DeepEqual(expectedBlock, block) {
+		t.Errorf("expected: %s, got: %s", expectedBlock, block)
+		t.Fail()
+	}
+
+	block = Block("subject\n\nbody\n", prefixes, NewCleanup("", ""))
+
+	if !reflect.DeepEqual([]string{}, block) {
+		t.Errorf("expected: %s, got: %s", []string{}, block)
+		t.Fail()
+	}
+}
+
+func TestCollapse(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name            string
+		message         string
+		cleanup         Cleanup
+		expectedMessage string
+	}{
+		{
+			"messages combined by git rebase",
+			"# This is a combination of 3 commits.\n# This is the 1st commit message:\n\na\n\nCo-authored-by: A <a@x.y>\n\n# This is the commit message #2:\n\nb\n\nCo-authored-by: a <A@x.y>\nCo-authored-by: B <b@x.y>\n\n# The commit message #3 will be skipped:\n\n# c\n#\n# Co-authored-by: C <c@x.y>\n",
+			NewCleanup("", ""),
+			"# This is a combination of 3 commits.\n# This is the 1st commit message:\n\na\n\n# This is the commit message #2:\n\nb\n\nCo-authored-by: A <a@x.y>\nCo-authored-by: B <b@x.y>\n\n# The commit message #3 will be skipped:\n\n# c\n#\n# Co-authored-by: C <c@x.y>\n",
+		},
+		{
+			"trailer blocks with other trailers",
+			"a\n\nSigned-off-by: S <s@x.y>\nCo-authored-by: A <a@x.y>\n\nb\n\nAcked-by: B <b@x.y>\nCo-authored-by: A <a@x.y>\n",
+			NewCleanup("", ""),
+			"a\n\nSigned-off-by: S <s@x.y>\n\nb\n\nAcked-by: B <b@x.y>\nCo-authored-by: A <a@x.y>\n",
+		},
+		{
+			"message without trailers",
+			"a\n\n\nb\n",
+			NewCleanup("", ""),
+			"a\n\n\nb\n",
+		},
+		{
+			"trailers below the scissors line",
+			"a\n\nCo-authored-by: A <a@x.y>\n\nb\n# ------------------------ >8 ------------------------\nCo-authored-by: B <b@x.y>\n",
+			NewCleanup("", ""),
+			"a\n\nb\n\nCo-authored-by: A <a@x.y>\n# ------------------------ >8 ------------------------\nCo-authored-by: B <b@x.y>\n",
+		},
+	}
+
+	for _, caseLoopVar := range cases {
+		message := caseLoopVar.message
+		cleanup := caseLoopVar.cleanup
+		expectedMessage := caseLoopVar.expectedMessage
+
+		t.Run(caseLoopVar.name, func(t *testing.T) {
+			t.Parallel()
+
+			collapsed := Collapse(message, prefixes, cleanup)
+
+			if expectedMessage != collapsed {
+				t.Errorf("expected: %q, got: %q", expectedMessage, collapsed)
+				t.Fail()
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package trailer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultKey the trailer key used when none has been configured
+const DefaultKey = "Co-authored-by"
+
+// DefaultFormat the line format used when none has been configured
+const DefaultFormat = KeyPlaceholder + ": " + ValuePlaceholder
+
+// KeyPlaceholder is replaced by the trailer key within a line format
+const KeyPlaceholder = "{key}"
+
+// ValuePlaceholder is replaced by the co-author within a line format
+const ValuePlaceholder = "{value}"
+
+var keyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*$`)
+
+// Format describes how a co-author is rendered into a line of a commit message
+type Format struct {
+	Key    string
+	Format string
+}
+
+// NewFormat constructs a new Format, falling back to the defaults for empty values
+func NewFormat(key string, format string) Format {
+	if key == "" {
+		key = DefaultKey
+	}
+	if format == "" {
+		format = DefaultFormat
+	}
+	return Format{Key: key, Format: format}
+}
+
+// Default the Format used when nothing has been configured
+func Default() Format {
+	return NewFormat("", "")
+}
+
+// WithKey the same format for a different trailer key, e.g. the key of a role
+func (f Format) WithKey(key string) Format {
+	return Format{Key: key, Format: f.Format}
+}
+
+// Line render a co-author into a single line, without the trailing newline
+func (f Format) Line(value string) string {
+	return f.Prefix() + value + f.Suffix()
+}
+
+// Prefix the rendered part of a line preceding the co-author, used to detect existing lines
+func (f Format) Prefix() string {
+	prefix := f.Format
+	if i := strings.Index(prefix, ValuePlaceholder); i >= 0 {
+		prefix = prefix[:i]
+	}
+	return f.withKey(prefix)
+}
+
+// Suffix the rendered part of a line following the co-author
+func (f Format) Suffix() string {
+	i := strings.Index(f.Format, ValuePlaceholder)
+	if i < 0 {
+		return ""
+	}
+	return f.withKey(f.Format[i+len(ValuePlaceholder):])
+}
+
+func (f Format) withKey(part string) string {
+	return strings.Replace(part, KeyPlaceholder, f.Key, 1)
+}
+
+// ValidateKey a trailer key consists of alphanumeric characters and hyphens only
+func ValidateKey(key string) error {
+	if !keyPattern.MatchString(key) {
+		return fmt.Errorf("trailer-key must consist of alphanumeric characters and hyphens only: '%s'", key)
+	}
+	return nil
+}
+
+// ValidateFormat a line format must contain the value placeholder exactly once, but not at its start, and must fit on a single line
+func ValidateFormat(format string) error {
+	if strings.Count(format, ValuePlaceholder) != 1 {
+		return fmt.Errorf("trailer-format must contain '%s' exactly once: '%s'", ValuePlaceholder, format)
+	}
+	// the part preceding the co-author identifies existing lines, hence it must not be empty
+	if strings.HasPrefix(format, ValuePlaceholder) {
+		return fmt.Errorf("trailer-format must not start with '%s': '%s'", ValuePlaceholder, format)
+	}
+	if strings.Count(format, KeyPlaceholder) > 1 {
+		return fmt.Errorf("trailer-format must not contain '%s' more than once: '%s'", KeyPlaceholder, format)
+	}
+	if strings.ContainsAny(format, "\n\r") {
+		return fmt.Errorf("trailer-format must not span multiple lines: '%s'", format)
+	}
+	return nil
+}
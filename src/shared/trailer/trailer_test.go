@@ -0,0 +1,113 @@
+package trailer
+
+import (
+	"testing"
+)
+
+func TestLine(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name         string
+		format       Format
+		expectedLine string
+	}{
+		{"default", Default(), "Co-authored-by: A <a@x.y>"},
+		{"custom key", NewFormat("Pair", ""), "Pair: A <a@x.y>"},
+		{"custom format", NewFormat("Pair", "{key} → {value} (pairing)"), "Pair → A <a@x.y> (pairing)"},
+		{"format without key", NewFormat("", "with {value}"), "with A <a@x.y>"},
+		{"format with suffix", NewFormat("", "{key}: {value};"), "Co-authored-by: A <a@x.y>;"},
+	}
+
+	for _, caseLoopVar := range cases {
+		format := caseLoopVar.format
+		expectedLine := caseLoopVar.expectedLine
+
+		t.Run(caseLoopVar.name, func(t *testing.T) {
+			t.Parallel()
+
+			line := format.Line("A <a@x.y>")
+
+			if expectedLine != line {
+				t.Errorf("expected: %s, got: %s", expectedLine, line)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	expectedPrefix := "Co-authored-by: "
+
+	prefix := Default().Prefix()
+
+	if expectedPrefix != prefix {
+		t.Errorf("expected: %s, got: %s", expectedPrefix, prefix)
+		t.Fail()
+	}
+}
+
+func TestValidateKey(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		key     string
+		isValid bool
+	}{
+		{"Co-authored-by", true},
+		{"Pair", true},
+		{"", false},
+		{"-Pair", false},
+		{"Pair:", false},
+		{"Paired with", false},
+	}
+
+	for _, caseLoopVar := range cases {
+		key := caseLoopVar.key
+		isValid := caseLoopVar.isValid
+
+		t.Run(key, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateKey(key)
+
+			if isValid != (err == nil) {
+				t.Errorf("expected valid: %t, got error: %s", isValid, err)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestValidateFormat(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		format  string
+		isValid bool
+	}{
+		{"{key}: {value}", true},
+		{"with {value}", true},
+		{"{value}", false},
+		{"{key}: {value} {value}", false},
+		{"{key}: {key} {value}", false},
+		{"{key}:", false},
+		{"{key}:\n{value}", false},
+	}
+
+	for _, caseLoopVar := range cases {
+		format := caseLoopVar.format
+		isValid := caseLoopVar.isValid
+
+		t.Run(format, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateFormat(format)
+
+			if isValid != (err == nil) {
+				t.Errorf("expected valid: %t, got error: %s", isValid, err)
+				t.Fail()
+			}
+		})
+	}
+}